@@ -0,0 +1,181 @@
+package bytepool
+
+import (
+	"io"
+	"sync"
+)
+
+// FixedSizePool adapts a BytePool to the Get() []byte / Put([]byte) interface used by
+// net/http/httputil.BufferPool and by HTTP/2 frame writers, which always request
+// buffers of one fixed size
+type FixedSizePool struct {
+	pool *BytePool
+	size int
+}
+
+// NewFixedSizePool creates an adapter handing out buffers of the given size from p
+func NewFixedSizePool(p *BytePool, size int) *FixedSizePool {
+	if size <= 0 {
+		panic("fixed size must be positive")
+	}
+	return &FixedSizePool{pool: p, size: size}
+}
+
+// Get returns a buffer with length equal to the configured size
+func (f *FixedSizePool) Get() []byte {
+	return f.pool.Get(f.size)
+}
+
+// Put returns a buffer obtained from Get back to the pool
+func (f *FixedSizePool) Put(buf []byte) {
+	f.pool.Put(buf)
+}
+
+// Funcs returns a Get/Put func pair for libraries that take allocation hooks as plain functions
+func (p *BytePool) Funcs() (get func(size int) []byte, put func(buf []byte)) {
+	return p.Get, p.Put
+}
+
+// SlicePointerPool adapts a BytePool to the Get(length int) *[]byte / Put(*[]byte)
+// interface of grpc-go's mem.BufferPool, so gRPC codecs and transports lease their
+// buffers from the same tiers as the rest of the process
+type SlicePointerPool struct {
+	pool *BytePool
+}
+
+// NewSlicePointerPool creates the *[]byte adapter over p
+func NewSlicePointerPool(p *BytePool) *SlicePointerPool {
+	return &SlicePointerPool{pool: p}
+}
+
+// Get returns a pointer to a buffer of the given length
+func (s *SlicePointerPool) Get(length int) *[]byte {
+	buf := s.pool.Get(length)
+	return &buf
+}
+
+// Put returns the buffer behind ptr to the pool, nil is ignored
+func (s *SlicePointerPool) Put(ptr *[]byte) {
+	if ptr == nil {
+		return
+	}
+	s.pool.Put(*ptr)
+	*ptr = nil
+}
+
+// ByteBuffer mirrors the ByteBuffer of valyala/bytebufferpool used across the fasthttp
+// ecosystem: code working on the exported B field ports by swapping the pool type
+// Growth leases larger storage from the pool instead of letting append reallocate
+type ByteBuffer struct {
+	B    []byte
+	pool *BytePool
+}
+
+// Len returns the number of bytes in the buffer
+func (b *ByteBuffer) Len() int { return len(b.B) }
+
+// Bytes returns b.B
+func (b *ByteBuffer) Bytes() []byte { return b.B }
+
+// String returns the content as a string
+func (b *ByteBuffer) String() string { return string(b.B) }
+
+// Reset empties the buffer, keeping its storage
+func (b *ByteBuffer) Reset() { b.B = b.B[:0] }
+
+// Write appends p to the buffer
+func (b *ByteBuffer) Write(p []byte) (int, error) {
+	b.grow(len(p))
+	b.B = append(b.B, p...)
+	return len(p), nil
+}
+
+// WriteString appends s to the buffer
+func (b *ByteBuffer) WriteString(s string) (int, error) {
+	b.grow(len(s))
+	b.B = append(b.B, s...)
+	return len(s), nil
+}
+
+// WriteByte appends c to the buffer
+func (b *ByteBuffer) WriteByte(c byte) error {
+	b.grow(1)
+	b.B = append(b.B, c)
+	return nil
+}
+
+// Set replaces the content with p
+func (b *ByteBuffer) Set(p []byte) {
+	b.B = b.B[:0]
+	_, _ = b.Write(p)
+}
+
+// SetString replaces the content with s
+func (b *ByteBuffer) SetString(s string) {
+	b.B = b.B[:0]
+	_, _ = b.WriteString(s)
+}
+
+// WriteTo writes the content to w
+func (b *ByteBuffer) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(b.B)
+	return int64(n), err
+}
+
+// grow moves the content to larger pooled storage when n more bytes do not fit
+// Storage is capped at the largest tier, beyond it append takes over and the storage is
+// left to GC on Put
+func (b *ByteBuffer) grow(n int) {
+	need := len(b.B) + n
+	limit := b.pool.clampLength(b.pool.GetMax())
+	if need <= cap(b.B) {
+		return
+	}
+	if need > limit {
+		// hand pooled storage back now, append would drop it
+		if b.B != nil && b.pool.state.Load().tier(cap(b.B)) != nil {
+			buf := append(make([]byte, 0, need), b.B...)
+			b.pool.Put(b.B)
+			b.B = buf
+		}
+		return
+	}
+	buf := b.pool.Get(min(max(need, 2*cap(b.B)), limit))[:len(b.B)]
+	copy(buf, b.B)
+	if b.B != nil {
+		b.pool.Put(b.B)
+	}
+	b.B = buf
+}
+
+// ByteBufferPool mirrors valyala/bytebufferpool.Pool on top of a BytePool
+type ByteBufferPool struct {
+	pool    *BytePool
+	buffers sync.Pool
+}
+
+// NewByteBufferPool creates a ByteBuffer pool leasing storage from p
+func NewByteBufferPool(p *BytePool) *ByteBufferPool {
+	return &ByteBufferPool{pool: p}
+}
+
+// Get returns an empty ByteBuffer, its storage is leased on the first write
+func (bp *ByteBufferPool) Get() *ByteBuffer {
+	if v := bp.buffers.Get(); v != nil {
+		return v.(*ByteBuffer)
+	}
+	return &ByteBuffer{pool: bp.pool}
+}
+
+// Put returns the storage of b to the pool and b itself for reuse
+// b must not be used after Put
+func (bp *ByteBufferPool) Put(b *ByteBuffer) {
+	if b.B != nil {
+		// storage grown by append beyond the largest tier never came from the pool
+		if bp.pool.state.Load().tier(cap(b.B)) != nil {
+			bp.pool.Put(b.B)
+		}
+		b.B = nil
+	}
+	bp.buffers.Put(b)
+}
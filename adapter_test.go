@@ -0,0 +1,116 @@
+package bytepool
+
+import (
+	"net/http/httputil"
+	"strings"
+	"testing"
+)
+
+var _ httputil.BufferPool = (*FixedSizePool)(nil)
+
+func TestFixedSizePool(t *testing.T) {
+	pool := NewPools(SizePowerOfTwo())
+	adapter := NewFixedSizePool(pool, 16384)
+
+	buf := adapter.Get()
+	if len(buf) != 16384 {
+		t.Errorf("Expected length 16384, got %d", len(buf))
+	}
+	adapter.Put(buf)
+
+	stats := pool.GetPoolStats()
+	if stats["total_get"].(int64) != 1 || stats["total_put"].(int64) != 1 {
+		t.Errorf("Expected balanced stats, got get=%v put=%v", stats["total_get"], stats["total_put"])
+	}
+}
+
+func TestBytePool_Funcs(t *testing.T) {
+	pool := NewPools([]int{128, 256})
+	get, put := pool.Funcs()
+
+	buf := get(200)
+	if len(buf) != 200 || cap(buf) != 256 {
+		t.Errorf("Expected len 200 cap 256, got len %d cap %d", len(buf), cap(buf))
+	}
+	put(buf)
+
+	if pool.GetPoolStats()["total_put"].(int64) != 1 {
+		t.Error("Expected put func to return buffer to the pool")
+	}
+}
+
+func TestSlicePointerPool(t *testing.T) {
+	pool := NewPools([]int{128, 256})
+	adapter := NewSlicePointerPool(pool)
+
+	ptr := adapter.Get(200)
+	if len(*ptr) != 200 || cap(*ptr) != 256 {
+		t.Errorf("Expected len 200 cap 256, got len %d cap %d", len(*ptr), cap(*ptr))
+	}
+	adapter.Put(ptr)
+	adapter.Put(nil)
+	if *ptr != nil {
+		t.Error("Expected the pointer to be cleared on Put")
+	}
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected balanced stats, got %d outstanding", pool.Outstanding())
+	}
+}
+
+func TestByteBufferPool(t *testing.T) {
+	pool := NewPools([]int{128, 256, 512})
+	bp := NewByteBufferPool(pool)
+
+	b := bp.Get()
+	b.SetString("hello")
+	if cap(b.B) != 128 {
+		t.Errorf("Expected storage from tier 128, got cap %d", cap(b.B))
+	}
+	_, _ = b.Write(make([]byte, 200))
+	_ = b.WriteByte('!')
+	if b.Len() != 206 || cap(b.B) != 256 || b.String()[:5] != "hello" {
+		t.Errorf("Expected content moved to tier 256, got len %d cap %d", b.Len(), cap(b.B))
+	}
+	if pool.Outstanding() != 1 {
+		t.Errorf("Expected the outgrown storage returned, got %d outstanding", pool.Outstanding())
+	}
+
+	var sb strings.Builder
+	if n, err := b.WriteTo(&sb); n != 206 || err != nil {
+		t.Errorf("Expected 206 bytes written, got %d, %v", n, err)
+	}
+
+	bp.Put(b)
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected storage returned on Put, got %d outstanding", pool.Outstanding())
+	}
+
+	b = bp.Get()
+	_, _ = b.Write(make([]byte, 1000)) // beyond the largest tier
+	if b.Len() != 1000 {
+		t.Errorf("Expected 1000 bytes, got %d", b.Len())
+	}
+	bp.Put(b)
+}
+
+func TestByteBufferPool_GrowCapped(t *testing.T) {
+	pool := NewPools([]int{1024, 1500})
+	bp := NewByteBufferPool(pool)
+
+	b := bp.Get()
+	_, _ = b.Write(make([]byte, 1000))
+	_, _ = b.Write(make([]byte, 100))
+	if b.Len() != 1100 || cap(b.B) != 1500 {
+		t.Errorf("Expected growth capped at tier 1500, got len %d cap %d", b.Len(), cap(b.B))
+	}
+	_, _ = b.Write(make([]byte, 1000)) // beyond the largest tier
+	bp.Put(b)
+
+	report := pool.Stats()
+	if report.Discarded != 0 || report.Misuses.Oversize != 0 {
+		t.Errorf("Expected no discards or misuses, got %d and %d", report.Discarded, report.Misuses.Oversize)
+	}
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected storage returned, got %d outstanding", pool.Outstanding())
+	}
+}
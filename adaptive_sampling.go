@@ -0,0 +1,91 @@
+package bytepool
+
+import (
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// adaptiveCheckEvery is the number of Gets between throughput checks
+const adaptiveCheckEvery = 1024
+
+// adaptiveWindow is the shortest interval the throughput is measured over
+const adaptiveWindow = 100 * time.Millisecond
+
+// WithAdaptiveSampling thins the recent-length tracker when Get throughput exceeds
+// maxGetsPerSecond, so about maxGetsPerSecond lengths are recorded per second, and
+// returns to the configured TrackerSampleEvery when the load drops. The throughput is
+// measured every few thousand Gets and on Stats, the effective rate is reported as
+// Report.TrackerSampleEvery
+func WithAdaptiveSampling(maxGetsPerSecond int) Option {
+	if maxGetsPerSecond <= 0 {
+		panic("adaptive sampling threshold must be positive")
+	}
+	return func(p *BytePool) {
+		p.adaptive = &adaptiveSampler{threshold: float64(maxGetsPerSecond)}
+	}
+}
+
+// adaptiveSampler derives the tracker sample rate from the measured Get throughput
+type adaptiveSampler struct {
+	threshold float64
+	gets      atomic.Int64
+	every     atomic.Int64 // effective sample rate, 0 until the first measurement
+
+	mu          sync.Mutex
+	windowStart time.Time
+	windowGets  int64
+}
+
+// sampled reports whether the length of the current Get is recorded by the tracker
+func (p *BytePool) sampled(st *poolState) bool {
+	a := p.adaptive
+	if a == nil {
+		return st.sampled()
+	}
+	if a.gets.Add(1)%adaptiveCheckEvery == 0 {
+		a.adjust(p.now(), st.cfg.TrackerSampleEvery)
+	}
+	every := a.rate(st.cfg.TrackerSampleEvery)
+	return every <= 1 || rand.Uint32N(uint32(every)) == 0
+}
+
+// rate returns the effective sample rate, at least the configured base
+func (a *adaptiveSampler) rate(base int) int {
+	return max(int(a.every.Load()), base, 1)
+}
+
+// adjust measures the throughput since the window started and sets the rate for it
+func (a *adaptiveSampler) adjust(now time.Time, base int) {
+	if !a.mu.TryLock() {
+		return
+	}
+	defer a.mu.Unlock()
+
+	gets := a.gets.Load()
+	if a.windowStart.IsZero() {
+		a.windowStart, a.windowGets = now, gets
+		return
+	}
+	elapsed := now.Sub(a.windowStart)
+	if elapsed < adaptiveWindow {
+		return
+	}
+	perSecond := float64(gets-a.windowGets) / elapsed.Seconds()
+	every := int64(max(base, 1))
+	if perSecond > a.threshold {
+		every *= int64(perSecond/a.threshold + 0.5)
+	}
+	a.every.Store(every)
+	a.windowStart, a.windowGets = now, gets
+}
+
+// trackerSampleEvery returns the effective tracker sample rate, remeasured under adaptive sampling
+func (p *BytePool) trackerSampleEvery(st *poolState) int {
+	if p.adaptive == nil {
+		return max(st.cfg.TrackerSampleEvery, 1)
+	}
+	p.adaptive.adjust(p.now(), st.cfg.TrackerSampleEvery)
+	return p.adaptive.rate(st.cfg.TrackerSampleEvery)
+}
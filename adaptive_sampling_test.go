@@ -0,0 +1,41 @@
+package bytepool
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBytePool_AdaptiveSampling(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	pool := NewPools([]int{128}, WithClock(clock), WithAdaptiveSampling(10000), WithTrackerCapacity(256))
+
+	if every := pool.Stats().TrackerSampleEvery; every != 1 {
+		t.Fatalf("Expected every Get recorded before any load, got one in %d", every)
+	}
+
+	for range 100000 {
+		pool.Put(pool.Get(100))
+	}
+	clock.Advance(time.Second)
+	if every := pool.Stats().TrackerSampleEvery; every != 10 {
+		t.Errorf("Expected one in 10 Gets recorded at 10x the threshold, got one in %d", every)
+	}
+	if every := pool.GetPoolStats()["tracker_sample_every"]; every != 10 {
+		t.Errorf("Expected the effective rate in GetPoolStats, got %v", every)
+	}
+
+	for range 1000 {
+		pool.Put(pool.Get(100))
+	}
+	clock.Advance(10 * time.Second)
+	if every := pool.Stats().TrackerSampleEvery; every != 1 {
+		t.Errorf("Expected the rate restored when load drops, got one in %d", every)
+	}
+}
+
+func TestBytePool_TrackerSampleEvery(t *testing.T) {
+	pool := NewPools([]int{128})
+	if every := pool.Stats().TrackerSampleEvery; every != 1 {
+		t.Errorf("Expected every Get recorded by default, got one in %d", every)
+	}
+}
@@ -0,0 +1,138 @@
+package bytepool
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Aggregate merges the statistics of several pools into one report, for services
+// running a pool per NUMA node or per listener that want a combined view
+// Counters and byte gauges are summed, tiers are merged by size, latency percentiles
+// keep the worst pool, Frozen is set when any pool is frozen, Time is the latest
+// snapshot and Labels keeps only the labels all pools agree on
+func Aggregate(pools ...*BytePool) Report {
+	var agg Report
+	tiers := make(map[int]*TierStats)
+	for i, p := range pools {
+		r := p.Stats()
+		if r.Time.After(agg.Time) {
+			agg.Time = r.Time
+		}
+		agg.TotalGet += r.TotalGet
+		agg.TotalPut += r.TotalPut
+		agg.Discarded += r.Discarded
+		agg.Discards.OversizeGet += r.Discards.OversizeGet
+		agg.Discards.OversizePut += r.Discards.OversizePut
+		agg.Discards.TierMismatch += r.Discards.TierMismatch
+		agg.Discards.Frozen += r.Discards.Frozen
+		agg.Inefficient += r.Inefficient
+		agg.Throttled += r.Throttled
+		agg.Degraded += r.Degraded
+		agg.Consolidations += r.Consolidations
+		agg.ConsolidatedBytes += r.ConsolidatedBytes
+		agg.TierFallbacks += r.TierFallbacks
+		agg.Spilled += r.Spilled
+		agg.SpilledBytes += r.SpilledBytes
+		agg.SpilledInUse += r.SpilledInUse
+		agg.RetainsExpired += r.RetainsExpired
+		agg.EventsDropped += r.EventsDropped
+		agg.Refused += r.Refused
+		agg.Wiped += r.Wiped
+		agg.GrowthCopies += r.GrowthCopies
+		agg.GrowthCopiedBytes += r.GrowthCopiedBytes
+		agg.PutAnomalies += r.PutAnomalies
+		agg.Misuses.DoublePut += r.Misuses.DoublePut
+		agg.Misuses.ForeignPut += r.Misuses.ForeignPut
+		agg.Misuses.RefCountUnderflow += r.Misuses.RefCountUnderflow
+		agg.Misuses.Oversize += r.Misuses.Oversize
+		agg.Misuses.RefCountOverflow += r.Misuses.RefCountOverflow
+		agg.TrackerLen += r.TrackerLen
+		agg.TrackerCap += r.TrackerCap
+		agg.TrackerSampleEvery = max(agg.TrackerSampleEvery, r.TrackerSampleEvery)
+		agg.HoldSamples += r.HoldSamples
+		agg.HoldP50 = max(agg.HoldP50, r.HoldP50)
+		agg.HoldP99 = max(agg.HoldP99, r.HoldP99)
+		agg.InUseBytes += r.InUseBytes
+		agg.IdleBytes += r.IdleBytes
+		agg.HeldBytes += r.HeldBytes
+		agg.Frozen = agg.Frozen || r.Frozen
+		if i == 0 {
+			agg.Labels = r.Labels
+		} else {
+			agg.Labels = commonLabels(agg.Labels, r.Labels)
+		}
+
+		for _, tier := range r.Tiers {
+			if merged, ok := tiers[tier.Size]; ok {
+				merged.merge(tier)
+			} else {
+				tiers[tier.Size] = &tier
+			}
+		}
+	}
+	agg.Tiers = make([]TierStats, 0, len(tiers))
+	for _, tier := range tiers {
+		agg.Tiers = append(agg.Tiers, *tier)
+	}
+	slices.SortFunc(agg.Tiers, func(a, b TierStats) int { return cmp.Compare(a.Size, b.Size) })
+	return agg
+}
+
+// merge adds the statistics of the same tier size from another pool
+func (t *TierStats) merge(o TierStats) {
+	t.Get += o.Get
+	t.Put += o.Put
+	t.InUse += o.InUse
+	t.InUseBytes += o.InUseBytes
+	t.IdleBytes += o.IdleBytes
+	switch {
+	case t.Idle < 0 || o.Idle < 0:
+		// one pool cannot tell, keep the known part as an approximation
+		t.Idle = max(t.Idle, 0) + max(o.Idle, 0)
+		t.IdleExact = false
+	default:
+		t.Idle += o.Idle
+		t.IdleExact = t.IdleExact && o.IdleExact
+	}
+	t.HitSamples += o.HitSamples
+	t.HitP99 = max(t.HitP99, o.HitP99)
+	t.MissSamples += o.MissSamples
+	t.MissP99 = max(t.MissP99, o.MissP99)
+	t.SurvivedGC += o.SurvivedGC
+	t.GCDrains += o.GCDrains
+	if o.SurvivedLastGC != nil {
+		// the tier survived only if it did in every pool
+		survived := *o.SurvivedLastGC && (t.SurvivedLastGC == nil || *t.SurvivedLastGC)
+		t.SurvivedLastGC = &survived
+	}
+	if o.Eviction != nil {
+		if t.Eviction == nil {
+			e := *o.Eviction
+			t.Eviction = &e
+		} else {
+			e := *t.Eviction
+			if e.Policy != o.Eviction.Policy {
+				e.Policy = "mixed"
+			}
+			e.Hits += o.Eviction.Hits
+			e.Misses += o.Eviction.Misses
+			e.Evicted += o.Eviction.Evicted
+			e.Trimmed += o.Eviction.Trimmed
+			t.Eviction = &e
+		}
+	}
+}
+
+// commonLabels returns the labels present with the same value in both sets
+func commonLabels(a, b map[string]string) map[string]string {
+	var out map[string]string
+	for k, v := range a {
+		if b[k] == v {
+			if out == nil {
+				out = make(map[string]string)
+			}
+			out[k] = v
+		}
+	}
+	return out
+}
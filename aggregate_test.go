@@ -0,0 +1,38 @@
+package bytepool
+
+import "testing"
+
+func TestAggregate(t *testing.T) {
+	a := NewPools([]int{128, 1024}, WithLabels(map[string]string{"service": "media", "node": "0"}))
+	b := NewPools([]int{128, 4096}, WithLabels(map[string]string{"service": "media", "node": "1"}))
+
+	a.Put(a.Get(100))
+	held := b.Get(100)
+	b.Get(2000)
+
+	agg := Aggregate(a, b)
+	if agg.TotalGet != 3 || agg.TotalPut != 1 {
+		t.Errorf("Expected 3 gets and 1 put, got %d/%d", agg.TotalGet, agg.TotalPut)
+	}
+	sizes := make([]int, 0, len(agg.Tiers))
+	for _, tier := range agg.Tiers {
+		sizes = append(sizes, tier.Size)
+	}
+	if len(sizes) != 3 || sizes[0] != 128 || sizes[1] != 1024 || sizes[2] != 4096 {
+		t.Fatalf("Expected merged tiers 128, 1024, 4096, got %v", sizes)
+	}
+	if tier := agg.Tiers[0]; tier.Get != 2 || tier.Put != 1 || tier.InUse != 1 {
+		t.Errorf("Expected the 128 tier merged across pools, got %+v", tier)
+	}
+	if agg.InUseBytes != 128+4096 {
+		t.Errorf("Expected %d bytes in use, got %d", 128+4096, agg.InUseBytes)
+	}
+	if len(agg.Labels) != 1 || agg.Labels["service"] != "media" {
+		t.Errorf("Expected only the common labels, got %v", agg.Labels)
+	}
+	b.Put(held)
+
+	if empty := Aggregate(); len(empty.Tiers) != 0 || empty.TotalGet != 0 {
+		t.Errorf("Expected an empty report, got %+v", empty)
+	}
+}
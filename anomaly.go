@@ -0,0 +1,32 @@
+package bytepool
+
+import (
+	"log/slog"
+	"sync/atomic"
+)
+
+// WithPutAnomalyHook calls fn with the tier size whenever a Put would make the Puts of
+// a tier exceed its Gets, a sign of a double Put or of a buffer the pool never leased
+// The check runs on every Put at the cost of one load, it relies on the tier counters
+// and is skipped without StatsTierCounters or with WithBatchedStats. Anomalies are
+// counted in Stats with or without a hook, fn must not block
+func WithPutAnomalyHook(fn func(size int)) Option {
+	return func(p *BytePool) {
+		p.onPutAnomaly = fn
+	}
+}
+
+// checkPutAnomaly reports whether the Put just counted in stat has no matching Get
+// The excess Put is taken back out of the counters so a single anomaly is reported once
+func (p *BytePool) checkPutAnomaly(stat *PoolStats, put int64, size int) bool {
+	if put <= atomic.LoadInt64(&stat.Get) {
+		return false
+	}
+	atomic.AddInt64(&stat.Put, -1)
+	atomic.AddInt64(&p.putAnomalies, 1)
+	if p.onPutAnomaly != nil {
+		p.onPutAnomaly(size)
+	}
+	p.logEvent(slog.LevelWarn, eventPutAnomaly, "bytepool: put without matching get", slog.Int("cap", size))
+	return true
+}
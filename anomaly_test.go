@@ -0,0 +1,39 @@
+package bytepool
+
+import "testing"
+
+func TestBytePool_PutAnomaly(t *testing.T) {
+	var sizes []int
+	pool := NewPools([]int{128, 1024}, WithPutAnomalyHook(func(size int) { sizes = append(sizes, size) }))
+
+	buf := pool.Get(100)
+	pool.Put(buf)
+	pool.Put(make([]byte, 1024)) // never leased
+	pool.Put(buf)                // double put outside debug mode
+
+	report := pool.Stats()
+	if report.PutAnomalies != 2 {
+		t.Errorf("Expected 2 anomalies, got %d", report.PutAnomalies)
+	}
+	if len(sizes) != 2 || sizes[0] != 1024 || sizes[1] != 128 {
+		t.Errorf("Expected hook called for 1024 and 128, got %v", sizes)
+	}
+	if report.TotalPut != 1 || report.Tiers[0].Put != 1 || report.Tiers[1].Put != 0 {
+		t.Errorf("Expected excess puts kept out of the counters, got %+v", report)
+	}
+
+	// a single anomaly does not flag the balanced Puts after it
+	pool.Put(pool.Get(100))
+	if got := pool.Stats().PutAnomalies; got != 2 {
+		t.Errorf("Expected no new anomaly, got %d", got)
+	}
+}
+
+func TestBytePool_PutAnomalyWithoutTierCounters(t *testing.T) {
+	pool := NewPools([]int{128})
+	pool.SetStatsLevel(StatsNone)
+	pool.Put(make([]byte, 128))
+	if got := pool.Stats().PutAnomalies; got != 0 {
+		t.Errorf("Expected the check skipped, got %d anomalies", got)
+	}
+}
@@ -0,0 +1,162 @@
+package bytepool
+
+import (
+	"math/rand/v2"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Tuning holds the parameters chosen by WithAutoTune, reported in Stats for reproducibility
+type Tuning struct {
+	GOMAXPROCS  int     `json:"gomaxprocs"`
+	Contention  float64 `json:"contention"`   // shared over private atomic add cost, 1 means uncontended
+	Shards      int     `json:"shards"`       // recent-length tracker shards
+	SampleEvery int     `json:"sample_every"` // one in SampleEvery Gets is recorded by the tracker
+}
+
+// calibrationOps is the number of atomic adds each goroutine performs per measurement
+const calibrationOps = 4096
+
+// maxTrackerShards bounds the number of tracker shards
+const maxTrackerShards = 64
+
+// WithAutoTune runs a short micro-calibration at construction that measures atomic contention
+// across GOMAXPROCS goroutines, then shards the recent-length tracker and samples its
+// pushes accordingly. On a single core or without measurable contention nothing changes
+// A tracker configured by WithRingQueue, WithRingQueueType or WithTrackerBuckets is kept
+// unsharded and a configured TrackerSampleEvery above 1 is kept. The chosen parameters
+// are reported in Stats().Tuning
+func WithAutoTune() Option {
+	return func(p *BytePool) {
+		p.autoTune = true
+	}
+}
+
+// applyAutoTune calibrates and applies the tuning, called by NewPools after the options
+func (p *BytePool) applyAutoTune() {
+	p.tune(calibrate(runtime.GOMAXPROCS(0)))
+}
+
+// tune applies t, sharding only the default tracker and sampling only when no rate was
+// configured
+func (p *BytePool) tune(t Tuning) {
+	if t.Shards > 1 {
+		if _, ok := p.tracker().(*RingQueue[int]); ok && !p.customTracker {
+			p.SetTracker(newShardedQueue(p.tracker().Cap(), t.Shards))
+		} else {
+			t.Shards = 1
+		}
+	}
+	if configured := p.initial.TrackerSampleEvery; configured > 1 {
+		t.SampleEvery = configured
+	}
+	p.tuning = &t
+	p.initial.TrackerSampleEvery = t.SampleEvery
+}
+
+// calibrate measures contention on procs cores and derives the tuning parameters
+func calibrate(procs int) Tuning {
+	t := Tuning{GOMAXPROCS: procs, Contention: 1, Shards: 1, SampleEvery: 1}
+	if procs < 2 {
+		return t
+	}
+
+	var shared int64
+	sharedCost := measureAdds(procs, func(int) *int64 { return &shared })
+	private := make([]struct {
+		n int64
+		_ cacheLinePad
+	}, procs)
+	privateCost := measureAdds(procs, func(i int) *int64 { return &private[i].n })
+	if privateCost > 0 {
+		t.Contention = float64(sharedCost) / float64(privateCost)
+	}
+	if t.Contention < 2 {
+		return t
+	}
+
+	// one shard per core removes writePos contention, sampling further thins pushes
+	t.Shards = min(nextPowerOfTwo(procs), maxTrackerShards)
+	t.SampleEvery = min(max(int(t.Contention/2), 1), 16)
+	return t
+}
+
+// measureAdds runs procs goroutines each adding calibrationOps times to counter(i)
+func measureAdds(procs int, counter func(i int) *int64) time.Duration {
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := range procs {
+		wg.Add(1)
+		go func(c *int64) {
+			defer wg.Done()
+			<-start
+			for range calibrationOps {
+				atomic.AddInt64(c, 1)
+			}
+		}(counter(i))
+	}
+	begin := time.Now()
+	close(start)
+	wg.Wait()
+	return time.Since(begin)
+}
+
+// nextPowerOfTwo returns the smallest power of two not below n
+func nextPowerOfTwo(n int) int {
+	v := 1
+	for v < n {
+		v <<= 1
+	}
+	return v
+}
+
+// sampled reports whether the current Get should be recorded by the tracker
+func (st *poolState) sampled() bool {
+	return st.cfg.TrackerSampleEvery <= 1 || rand.Uint32N(uint32(st.cfg.TrackerSampleEvery)) == 0
+}
+
+// shardedQueue spreads tracker pushes over several ring queues to avoid a shared write position
+// Bytes returns the shards concatenated, so samples are not in chronological order
+type shardedQueue struct {
+	shards []RingQueuer
+}
+
+// newShardedQueue creates a tracker holding about capacity samples in n shards
+func newShardedQueue(capacity, n int) *shardedQueue {
+	per := max((capacity+n-1)/n, 1)
+	q := shardedQueue{shards: make([]RingQueuer, n)}
+	for i := range q.shards {
+		q.shards[i] = NewRingQueue[int](per)
+	}
+	return &q
+}
+
+func (q *shardedQueue) Push(item int) {
+	q.shards[rand.IntN(len(q.shards))].Push(item)
+}
+
+func (q *shardedQueue) Bytes() []int {
+	out := make([]int, 0, q.Len())
+	for _, s := range q.shards {
+		out = append(out, s.Bytes()...)
+	}
+	return out
+}
+
+func (q *shardedQueue) Len() int {
+	n := 0
+	for _, s := range q.shards {
+		n += s.Len()
+	}
+	return n
+}
+
+func (q *shardedQueue) Cap() int {
+	n := 0
+	for _, s := range q.shards {
+		n += s.Cap()
+	}
+	return n
+}
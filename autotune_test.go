@@ -0,0 +1,112 @@
+package bytepool
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestWithAutoTune(t *testing.T) {
+	pool := NewPools(SizePowerOfTwo(), WithAutoTune())
+	tuning := pool.Stats().Tuning
+	if tuning == nil {
+		t.Fatal("Expected tuning in stats")
+	}
+	if tuning.GOMAXPROCS != runtime.GOMAXPROCS(0) || tuning.Shards < 1 || tuning.SampleEvery < 1 {
+		t.Errorf("Unexpected tuning %+v", tuning)
+	}
+	if tuning.Shards > 1 && pool.Stats().TrackerCap < 256 {
+		t.Errorf("Expected sharded tracker to keep capacity, got %d", pool.Stats().TrackerCap)
+	}
+
+	pool.Put(pool.Get(100))
+	if NewPools(SizePowerOfTwo()).Stats().Tuning != nil {
+		t.Error("Expected no tuning without WithAutoTune")
+	}
+}
+
+func TestAutoTuneKeepsConfiguredTracker(t *testing.T) {
+	contended := Tuning{GOMAXPROCS: 8, Contention: 4, Shards: 8, SampleEvery: 2}
+
+	custom := NewRingQueue[int](512)
+	for _, tc := range []struct {
+		name string
+		opt  Option
+	}{
+		{"ring queue", WithRingQueue(custom)},
+		{"mutex ring queue", WithRingQueueType(MutexRingQueue)},
+		{"lock-free ring queue", WithRingQueueType(LockFreeRingQueue)},
+		{"buckets", WithTrackerBuckets(TrackerTierBuckets)},
+	} {
+		pool := configure(SizePowerOfTwo(), []Option{tc.opt})
+		tracker := pool.tracker()
+		pool.tune(contended)
+		if pool.tracker() != tracker || pool.tuning.Shards != 1 {
+			t.Errorf("%s: Expected the configured tracker kept, got %T with %d shards", tc.name, pool.tracker(), pool.tuning.Shards)
+		}
+	}
+
+	pool := configure(SizePowerOfTwo(), nil)
+	pool.tune(contended)
+	if _, ok := pool.tracker().(*shardedQueue); !ok || pool.tuning.Shards != 8 {
+		t.Errorf("Expected the default tracker sharded, got %T", pool.tracker())
+	}
+}
+
+func TestAutoTuneKeepsConfiguredSampleRate(t *testing.T) {
+	uncontended := Tuning{GOMAXPROCS: 1, Contention: 1, Shards: 1, SampleEvery: 1}
+	contended := Tuning{GOMAXPROCS: 8, Contention: 4, Shards: 8, SampleEvery: 2}
+
+	cfg := PoolConfig{Sizes: SizePowerOfTwo(), TrackerSampleEvery: 8}
+	pool := configure(nil, []Option{cfg.Option()})
+	pool.tune(uncontended)
+	if pool.initial.TrackerSampleEvery != 8 || pool.tuning.SampleEvery != 8 {
+		t.Errorf("Expected the configured rate kept, got %d", pool.initial.TrackerSampleEvery)
+	}
+
+	pool = configure(SizePowerOfTwo(), nil)
+	pool.tune(contended)
+	if pool.initial.TrackerSampleEvery != 2 {
+		t.Errorf("Expected the tuned rate without configuration, got %d", pool.initial.TrackerSampleEvery)
+	}
+}
+
+func TestCalibrate(t *testing.T) {
+	if got := calibrate(1); got.Shards != 1 || got.SampleEvery != 1 {
+		t.Errorf("Expected no sharding on a single core, got %+v", got)
+	}
+	got := calibrate(4)
+	if got.Shards < 1 || got.Shards > maxTrackerShards || got.SampleEvery < 1 || got.SampleEvery > 16 {
+		t.Errorf("Tuning out of range %+v", got)
+	}
+}
+
+func TestShardedQueue(t *testing.T) {
+	q := newShardedQueue(256, 8)
+	if q.Cap() != 256 {
+		t.Errorf("Expected cap 256, got %d", q.Cap())
+	}
+	for i := range 100 {
+		q.Push(i)
+	}
+	if q.Len() != 100 || len(q.Bytes()) != 100 {
+		t.Errorf("Expected 100 samples, got %d", q.Len())
+	}
+}
+
+func TestTrackerSampling(t *testing.T) {
+	pool := NewPools(SizePowerOfTwo())
+	cfg := pool.Config()
+	cfg.TrackerSampleEvery = 1 << 30
+	if err := pool.ApplyConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	for range 100 {
+		pool.Put(pool.Get(10))
+	}
+	if n := pool.tracker().Len(); n > 1 {
+		t.Errorf("Expected sampled tracker to record almost nothing, got %d", n)
+	}
+	if pool.Stats().TotalGet != 100 {
+		t.Error("Expected sampling not to affect counters")
+	}
+}
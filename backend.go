@@ -0,0 +1,348 @@
+package bytepool
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+	"weak"
+)
+
+// Backend creates the stores holding idle buffers for each tier
+type Backend interface {
+	// NewStore creates the store for buffers of the given tier size
+	NewStore(size int) Store
+}
+
+// ClockBackend is implemented by backends whose stores keep time, their stores are
+// created with the pool clock so WithClock also drives their expiry
+type ClockBackend interface {
+	Backend
+	// NewStoreWithClock creates the store for buffers of the given tier size
+	NewStoreWithClock(size int, clock Clock) Store
+}
+
+// newStore creates the store of a tier, handing the pool clock to backends that use one
+func (p *BytePool) newStore(backend Backend, size int) Store {
+	if b, ok := backend.(ClockBackend); ok {
+		return b.NewStoreWithClock(size, p.clock)
+	}
+	return backend.NewStore(size)
+}
+
+// Store holds idle buffers of a single tier
+type Store interface {
+	// Get returns an idle buffer, or nil when the store is empty
+	Get() *[]byte
+	// Put stores an idle buffer, the store may drop it
+	Put(buf *[]byte)
+}
+
+// backendRange assigns a backend to the tiers within [min, max]
+type backendRange struct {
+	min, max int
+	backend  Backend
+}
+
+// WithBackend sets the default backend for all tiers, sync.Pool is used when not set
+func WithBackend(backend Backend) Option {
+	return func(p *BytePool) {
+		p.backend = backend
+	}
+}
+
+// WithBackendForRange uses backend for tiers whose size is within [min, max]
+// When ranges overlap, the one added last wins
+func WithBackendForRange(min, max int, backend Backend) Option {
+	return func(p *BytePool) {
+		p.backendRanges = append(p.backendRanges, backendRange{min: min, max: max, backend: backend})
+	}
+}
+
+// backendFor returns the backend responsible for the given tier size
+func (p *BytePool) backendFor(size int) Backend {
+	for i := len(p.backendRanges) - 1; i >= 0; i-- {
+		r := p.backendRanges[i]
+		if size >= r.min && size <= r.max {
+			return r.backend
+		}
+	}
+	if p.backend != nil {
+		return p.backend
+	}
+	return SyncPoolBackend()
+}
+
+// SyncPoolBackend returns a backend storing idle buffers in sync.Pool
+// Idle buffers are reclaimed by the GC, which suits small, frequently reused tiers
+// Tiers listed by SizeSmall keep fixed size arrays, so they are pooled without boxing
+func SyncPoolBackend() Backend {
+	return syncPoolBackend{}
+}
+
+type syncPoolBackend struct{}
+
+func (syncPoolBackend) NewStore(size int) Store {
+	if s := newInlineStore(size); s != nil {
+		return s
+	}
+	s := &syncPoolStore{}
+	watchGC(weak.Make(s), func(s *syncPoolStore) { s.idle.rotate() })
+	return s
+}
+
+type syncPoolStore struct {
+	p    sync.Pool
+	idle idleEstimate
+}
+
+func (s *syncPoolStore) Get() *[]byte {
+	v := s.p.Get()
+	if v == nil {
+		s.idle.miss()
+		return nil
+	}
+	s.idle.hit()
+	return v.(*[]byte)
+}
+
+func (s *syncPoolStore) Put(buf *[]byte) {
+	s.idle.put()
+	s.p.Put(buf)
+}
+
+// ApproxLen returns the approximate number of idle buffers
+func (s *syncPoolStore) ApproxLen() int {
+	return s.idle.len()
+}
+
+// idleEstimate follows the idle buffers of an opaque sync.Pool from its Puts and Gets
+// sync.Pool keeps a buffer for up to two GC cycles, so the estimate keeps the Puts since
+// the last cycle and the ones of the cycle before, dropping older ones on every rotate.
+// A Get finding the pool empty resets it, catching buffers dropped between cycles, e.g.
+// the random drops of the race detector
+type idleEstimate struct {
+	current atomic.Int64 // net Puts since the last GC cycle
+	victim  atomic.Int64 // net Puts of the cycle before, still reusable until the next one
+}
+
+// put counts a stored buffer
+func (e *idleEstimate) put() {
+	e.current.Add(1)
+}
+
+// hit counts a reused buffer, taken from the victim generation once the current is spent
+func (e *idleEstimate) hit() {
+	if e.current.Add(-1) >= 0 {
+		return
+	}
+	e.current.Add(1)
+	if e.victim.Add(-1) < 0 {
+		e.victim.Store(0)
+	}
+}
+
+// miss records an empty pool
+func (e *idleEstimate) miss() {
+	e.current.Store(0)
+	e.victim.Store(0)
+}
+
+// rotate follows a GC cycle, called through watchGC
+func (e *idleEstimate) rotate() {
+	e.victim.Store(e.current.Swap(0))
+}
+
+// len returns the estimated idle buffers
+func (e *idleEstimate) len() int {
+	return int(max(e.current.Load()+e.victim.Load(), 0))
+}
+
+// evictKind selects the free list eviction behavior
+type evictKind int
+
+const (
+	evictLIFO evictKind = iota
+	evictFIFO
+	evictLRU
+)
+
+// EvictionPolicy decides which idle buffer a bounded free list reuses and which one it drops
+type EvictionPolicy struct {
+	kind    evictKind
+	idleTTL time.Duration
+}
+
+var (
+	// EvictLIFO reuses the most recently returned buffer and drops the oldest one when
+	// full, keeping the reused buffers warm in CPU caches
+	EvictLIFO = EvictionPolicy{kind: evictLIFO}
+	// EvictFIFO reuses the oldest buffer and drops the returned one when full, spreading
+	// use evenly over all idle buffers
+	EvictFIFO = EvictionPolicy{kind: evictFIFO}
+)
+
+// EvictLRU behaves like EvictLIFO and also trims buffers idle for longer than idleTTL,
+// so a tier gives its memory back once a burst is over
+func EvictLRU(idleTTL time.Duration) EvictionPolicy {
+	if idleTTL <= 0 {
+		panic("idle ttl must be positive")
+	}
+	return EvictionPolicy{kind: evictLRU, idleTTL: idleTTL}
+}
+
+// String returns the policy name
+func (e EvictionPolicy) String() string {
+	switch e.kind {
+	case evictFIFO:
+		return "fifo"
+	case evictLRU:
+		return "lru"
+	default:
+		return "lifo"
+	}
+}
+
+// EvictionStats reports how a free list store served Gets and dropped buffers
+type EvictionStats struct {
+	Policy  string `json:"policy"`
+	Hits    int64  `json:"hits"`    // Gets served by an idle buffer
+	Misses  int64  `json:"misses"`  // Gets finding the store empty
+	Evicted int64  `json:"evicted"` // buffers dropped because the store was full
+	Trimmed int64  `json:"trimmed"` // buffers dropped after idling past the TTL or under memory pressure
+}
+
+// evictionStatser is implemented by stores reporting eviction statistics
+type evictionStatser interface {
+	EvictionStats() EvictionStats
+}
+
+// FreeListBackend returns a backend keeping at most maxIdle buffers per tier in a free list
+// The most recently returned buffer is reused first and the least recently used one is
+// dropped when the list is full, so large buffers survive GC but memory stays bounded
+func FreeListBackend(maxIdle int) Backend {
+	return FreeListBackendWithPolicy(maxIdle, EvictLIFO)
+}
+
+// FreeListBackendWithPolicy is like FreeListBackend with a selectable eviction policy
+// Combine it with WithBackendForRange to give tiers different policies
+func FreeListBackendWithPolicy(maxIdle int, policy EvictionPolicy) Backend {
+	if maxIdle <= 0 {
+		panic("free list size must be positive")
+	}
+	return freeListBackend{maxIdle: maxIdle, policy: policy}
+}
+
+type freeListBackend struct {
+	maxIdle int
+	policy  EvictionPolicy
+}
+
+func (b freeListBackend) NewStore(size int) Store {
+	return b.NewStoreWithClock(size, systemClock{})
+}
+
+func (b freeListBackend) NewStoreWithClock(_ int, clock Clock) Store {
+	s := &freeListStore{items: make([]*[]byte, b.maxIdle), policy: b.policy, clock: clock}
+	if b.policy.kind == evictLRU {
+		s.stamps = make([]time.Time, b.maxIdle)
+	}
+	return s
+}
+
+// freeListStore is a bounded deque, head is the least recently returned buffer
+type freeListStore struct {
+	mu     sync.Mutex
+	items  []*[]byte
+	stamps []time.Time // return times parallel to items, EvictLRU only
+	head   int
+	count  int
+	policy EvictionPolicy
+	clock  Clock
+
+	hits, misses, evicted, trimmed int64
+}
+
+func (s *freeListStore) Get() *[]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.trim()
+	if s.count == 0 {
+		s.misses++
+		return nil
+	}
+	s.hits++
+	var pos int
+	if s.policy.kind == evictFIFO {
+		pos = s.head
+		s.head = (s.head + 1) % len(s.items)
+	} else {
+		pos = (s.head + s.count - 1) % len(s.items)
+	}
+	s.count--
+	buf := s.items[pos]
+	s.items[pos] = nil
+	return buf
+}
+
+func (s *freeListStore) Put(buf *[]byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.trim()
+	if s.count == len(s.items) {
+		s.evicted++
+		if s.policy.kind == evictFIFO {
+			// keep the queue order, the returned buffer is the one dropped
+			return
+		}
+		// evict the least recently used buffer
+		s.dropHead()
+	}
+	pos := (s.head + s.count) % len(s.items)
+	s.items[pos] = buf
+	if s.stamps != nil {
+		s.stamps[pos] = s.clock.Now()
+	}
+	s.count++
+}
+
+// trim drops buffers idle for longer than the TTL, oldest first
+func (s *freeListStore) trim() {
+	if s.stamps == nil {
+		return
+	}
+	cutoff := s.clock.Now().Add(-s.policy.idleTTL)
+	for s.count > 0 && s.stamps[s.head].Before(cutoff) {
+		s.dropHead()
+		s.trimmed++
+	}
+}
+
+// dropHead removes the least recently returned buffer
+func (s *freeListStore) dropHead() {
+	s.items[s.head] = nil
+	s.head = (s.head + 1) % len(s.items)
+	s.count--
+}
+
+// Len returns the number of idle buffers
+func (s *freeListStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.trim()
+	return s.count
+}
+
+// EvictionStats returns the hit and eviction counters of the store
+func (s *freeListStore) EvictionStats() EvictionStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return EvictionStats{
+		Policy:  s.policy.String(),
+		Hits:    s.hits,
+		Misses:  s.misses,
+		Evicted: s.evicted,
+		Trimmed: s.trimmed,
+	}
+}
@@ -0,0 +1,198 @@
+package bytepool
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestFreeListStore(t *testing.T) {
+	store := FreeListBackend(2).NewStore(128).(*freeListStore)
+
+	if store.Get() != nil {
+		t.Fatal("Expected empty store to return nil")
+	}
+
+	a, b, c := make([]byte, 128), make([]byte, 128), make([]byte, 128)
+	store.Put(&a)
+	store.Put(&b)
+	store.Put(&c) // evicts a
+
+	if store.Len() != 2 {
+		t.Errorf("Expected 2 idle buffers, got %d", store.Len())
+	}
+	if got := store.Get(); got != &c {
+		t.Error("Expected most recently returned buffer first")
+	}
+	if got := store.Get(); got != &b {
+		t.Error("Expected b second")
+	}
+	if store.Get() != nil {
+		t.Error("Expected a to have been evicted")
+	}
+}
+
+func TestWithBackendForRange(t *testing.T) {
+	pool := NewPools([]int{128, 1024, 65536},
+		WithBackendForRange(4096, 1<<30, FreeListBackend(4)))
+
+	if _, ok := pool.state.Load().tier(128).store.(*syncPoolStore); !ok {
+		t.Errorf("Expected sync.Pool store for small tier, got %T", pool.state.Load().tier(128).store)
+	}
+	if _, ok := pool.state.Load().tier(65536).store.(*freeListStore); !ok {
+		t.Errorf("Expected free list store for large tier, got %T", pool.state.Load().tier(65536).store)
+	}
+
+	buf := pool.Get(60000)
+	pool.Put(buf)
+	again := pool.Get(50000)
+	if &again[0] != &buf[0] {
+		t.Error("Expected free list to return the same buffer")
+	}
+	if cap(again) != 65536 {
+		t.Errorf("Expected cap 65536, got %d", cap(again))
+	}
+}
+
+func TestWithBackend(t *testing.T) {
+	pool := NewPools([]int{128, 256}, WithBackend(FreeListBackend(1)))
+	for _, tier := range pool.state.Load().tiers {
+		if _, ok := tier.store.(*freeListStore); !ok {
+			t.Errorf("Expected free list store for tier %d, got %T", tier.size, tier.store)
+		}
+	}
+}
+
+func TestFreeListStore_FIFO(t *testing.T) {
+	store := FreeListBackendWithPolicy(2, EvictFIFO).NewStore(128).(*freeListStore)
+
+	a, b, c := make([]byte, 128), make([]byte, 128), make([]byte, 128)
+	store.Put(&a)
+	store.Put(&b)
+	store.Put(&c) // dropped, the list is full
+
+	if got := store.Get(); got != &a {
+		t.Error("Expected oldest buffer first")
+	}
+	store.Put(&c)
+	if got := store.Get(); got != &b {
+		t.Error("Expected b second")
+	}
+	if got := store.Get(); got != &c {
+		t.Error("Expected c third")
+	}
+
+	stats := store.EvictionStats()
+	if stats.Policy != "fifo" || stats.Hits != 3 || stats.Evicted != 1 {
+		t.Errorf("Expected fifo with 3 hits and 1 eviction, got %+v", stats)
+	}
+}
+
+func TestFreeListStore_LRUTrim(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	backend := FreeListBackendWithPolicy(4, EvictLRU(time.Minute)).(ClockBackend)
+	store := backend.NewStoreWithClock(128, clock).(*freeListStore)
+
+	a, b := make([]byte, 128), make([]byte, 128)
+	store.Put(&a)
+	clock.Advance(45 * time.Second)
+	store.Put(&b)
+	clock.Advance(30 * time.Second) // a has idled past the TTL
+
+	if store.Len() != 1 {
+		t.Errorf("Expected 1 idle buffer after trimming, got %d", store.Len())
+	}
+	if got := store.Get(); got != &b {
+		t.Error("Expected b to survive trimming")
+	}
+	if store.Get() != nil {
+		t.Error("Expected empty store")
+	}
+
+	stats := store.EvictionStats()
+	if stats.Policy != "lru" || stats.Trimmed != 1 || stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("Expected lru with 1 trim, 1 hit and 1 miss, got %+v", stats)
+	}
+}
+
+func TestBytePool_LRUTrimUsesPoolClock(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	pool := NewPools([]int{65536}, WithClock(clock),
+		WithBackend(FreeListBackendWithPolicy(4, EvictLRU(time.Minute))))
+
+	pool.Put(pool.Get(60000))
+	clock.Advance(2 * time.Minute)
+	pool.Put(pool.Get(60000))
+
+	eviction := pool.Stats().Tiers[0].Eviction
+	if eviction == nil || eviction.Trimmed != 1 {
+		t.Errorf("Expected the idle buffer trimmed on the pool clock, got %+v", eviction)
+	}
+}
+
+func TestBytePool_EvictionStats(t *testing.T) {
+	pool := NewPools([]int{128, 65536},
+		WithBackendForRange(4096, 1<<30, FreeListBackendWithPolicy(1, EvictFIFO)))
+
+	pool.Put(pool.Get(60000))
+	pool.Put(make([]byte, 65536)) // evicted
+
+	for _, tier := range pool.Stats().Tiers {
+		switch tier.Size {
+		case 128:
+			if tier.Eviction != nil {
+				t.Errorf("Expected no eviction stats for sync.Pool tier, got %+v", tier.Eviction)
+			}
+		case 65536:
+			if tier.Eviction == nil || tier.Eviction.Evicted != 1 || tier.Eviction.Misses != 1 {
+				t.Errorf("Expected 1 miss and 1 eviction, got %+v", tier.Eviction)
+			}
+		}
+	}
+}
+
+func TestSyncPoolStore_IdleEstimate(t *testing.T) {
+	store := SyncPoolBackend().NewStore(1024).(*syncPoolStore)
+	for range 3 {
+		buf := make([]byte, 1024)
+		store.Put(&buf)
+	}
+	if got := store.ApproxLen(); got != 3 {
+		t.Errorf("Expected 3 idle buffers, got %d", got)
+	}
+
+	// buffers the pool dropped on its own are forgotten at the first miss
+	store.idle.current.Add(5)
+	for store.Get() != nil {
+	}
+	if got := store.ApproxLen(); got != 0 {
+		t.Errorf("Expected the estimate reset by a miss, got %d", got)
+	}
+
+	// two GC cycles drop everything sync.Pool still held
+	buf := make([]byte, 1024)
+	store.Put(&buf)
+	store.idle.rotate()
+	if got := store.ApproxLen(); got != 1 {
+		t.Errorf("Expected the buffer kept in the victim generation, got %d", got)
+	}
+	store.idle.rotate()
+	if got := store.ApproxLen(); got != 0 {
+		t.Errorf("Expected the estimate cleared after two cycles, got %d", got)
+	}
+}
+
+func TestSyncPoolStore_IdleEstimateAfterGC(t *testing.T) {
+	store := SyncPoolBackend().NewStore(1024).(*syncPoolStore)
+	for range 3 {
+		buf := make([]byte, 1024)
+		store.Put(&buf)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for store.ApproxLen() != 0 && time.Now().Before(deadline) {
+		runtime.GC()
+	}
+	if got := store.ApproxLen(); got != 0 {
+		t.Errorf("Expected GC cycles to clear the estimate, got %d", got)
+	}
+}
@@ -0,0 +1,97 @@
+package bytepool
+
+import (
+	"math/bits"
+	"math/rand/v2"
+	"runtime"
+	"sync/atomic"
+)
+
+// statBatchSize is the number of Gets a shard accumulates before it flushes on its own
+const statBatchSize = 64
+
+// WithBatchedStats accumulates Get and Put counters in per-tier shards picked at random
+// on every call, instead of updating the shared counters on every call. Shards have no
+// goroutine affinity, concurrent callers only spread their atomic adds over more cache lines
+// A shard flushes into the shared counters every 64 Gets and whenever the counters
+// are read through Stats, Tiers, GetPoolStats, Outstanding or InUseBytes, so snapshots
+// stay exact. The soft budget check on the hot path reads the shared counters without
+// flushing and may lag by up to 64 Gets per shard
+func WithBatchedStats() Option {
+	return func(p *BytePool) {
+		p.batchedStats = true
+	}
+}
+
+// statShard holds counter deltas not yet flushed into the shared counters
+type statShard struct {
+	get   atomic.Int64
+	put   atomic.Int64
+	bytes atomic.Int64 // in-use bytes delta
+	_     cacheLinePad
+}
+
+// newStatShards returns the shards of one tier, a power of two at least GOMAXPROCS
+func newStatShards() []statShard {
+	n := 1 << bits.Len(uint(runtime.GOMAXPROCS(0)-1))
+	return make([]statShard, n)
+}
+
+// shard picks a random shard of stat with rand.Uint32()&mask. Two callers may land on the
+// same shard, its counters are atomic so that only costs contention
+func (stat *PoolStats) shard() *statShard {
+	return &stat.shards[rand.Uint32()&uint32(len(stat.shards)-1)]
+}
+
+// batchGet accumulates a Get in a shard of stat
+func (p *BytePool) batchGet(stat *PoolStats, size int) {
+	s := stat.shard()
+	s.bytes.Add(int64(size))
+	if s.get.Add(1) >= statBatchSize {
+		p.flushShard(stat, s)
+	}
+}
+
+// batchPut accumulates a Put in a shard of stat
+func (p *BytePool) batchPut(stat *PoolStats, size int) {
+	s := stat.shard()
+	s.bytes.Add(-int64(size))
+	s.put.Add(1)
+}
+
+// flushShard moves the deltas of s into the shared counters
+// Deltas added concurrently either land in this flush or stay for the next one
+func (p *BytePool) flushShard(stat *PoolStats, s *statShard) {
+	get, put, bytes := s.get.Swap(0), s.put.Swap(0), s.bytes.Swap(0)
+	if get == 0 && put == 0 && bytes == 0 {
+		return
+	}
+	if p.recording(StatsTierCounters) {
+		atomic.AddInt64(&stat.Get, get)
+		atomic.AddInt64(&stat.Put, put)
+	}
+	atomic.AddInt64(&p.totalGet, get)
+	atomic.AddInt64(&p.totalPut, put)
+	atomic.AddInt64(&p.inUseBytes, bytes)
+}
+
+// flushTier flushes every shard of stat
+func (p *BytePool) flushTier(stat *PoolStats) {
+	for i := range stat.shards {
+		p.flushShard(stat, &stat.shards[i])
+	}
+}
+
+// flushStats flushes the shards of current and retired tiers, a no-op without WithBatchedStats
+func (p *BytePool) flushStats() {
+	if !p.batchedStats {
+		return
+	}
+	st := p.state.Load()
+	for i := range st.tiers {
+		p.flushTier(st.tiers[i].stats)
+	}
+	for _, stat := range st.retired {
+		p.flushTier(stat)
+	}
+}
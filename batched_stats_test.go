@@ -0,0 +1,82 @@
+package bytepool
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBytePool_BatchedStatsFlushOnSnapshot(t *testing.T) {
+	pool := NewPools([]int{128, 1024}, WithBatchedStats())
+
+	bufs := [][]byte{pool.Get(100), pool.Get(100), pool.Get(1000)}
+	pool.Put(bufs[0])
+	if got := atomic.LoadInt64(&pool.totalGet); got != 0 {
+		t.Errorf("Expected Gets pending in shards, got %d flushed", got)
+	}
+
+	report := pool.Stats()
+	if report.TotalGet != 3 || report.TotalPut != 1 {
+		t.Errorf("Expected 3 gets and 1 put, got %d and %d", report.TotalGet, report.TotalPut)
+	}
+	if report.Tiers[0].Get != 2 || report.Tiers[0].Put != 1 || report.Tiers[1].Get != 1 {
+		t.Errorf("Expected tier counters flushed, got %+v", report.Tiers)
+	}
+	if report.InUseBytes != 128+1024 {
+		t.Errorf("Expected 1152 bytes in use, got %d", report.InUseBytes)
+	}
+
+	pool.Put(bufs[1])
+	if got := pool.Outstanding(); got != 1 {
+		t.Errorf("Expected 1 outstanding, got %d", got)
+	}
+	pool.Put(bufs[2])
+	if got := pool.InUseBytes(); got != 0 {
+		t.Errorf("Expected 0 bytes in use, got %d", got)
+	}
+	if got := pool.GetPoolStats()["total_put"]; got != int64(3) {
+		t.Errorf("Expected 3 puts, got %v", got)
+	}
+}
+
+func TestBytePool_BatchedStatsConcurrent(t *testing.T) {
+	pool := NewPools([]int{128, 1024}, WithBatchedStats())
+
+	const workers, rounds = 8, 1000
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range rounds {
+				buf := pool.Get(100 + (w+i)%2*900)
+				if i%10 != 0 {
+					pool.Put(buf)
+				}
+			}
+		}()
+	}
+	// snapshots taken while counting must not lose deltas
+	for range 10 {
+		pool.Stats()
+	}
+	wg.Wait()
+
+	report := pool.Stats()
+	if report.TotalGet != workers*rounds {
+		t.Errorf("Expected %d gets, got %d", workers*rounds, report.TotalGet)
+	}
+	if want := int64(workers * rounds * 9 / 10); report.TotalPut != want {
+		t.Errorf("Expected %d puts, got %d", want, report.TotalPut)
+	}
+	var tierGets int64
+	for _, tier := range report.Tiers {
+		tierGets += tier.Get
+	}
+	if tierGets != report.TotalGet {
+		t.Errorf("Expected tier gets to sum to %d, got %d", report.TotalGet, tierGets)
+	}
+	if want := report.TotalGet - report.TotalPut; pool.Outstanding() != want {
+		t.Errorf("Expected %d outstanding, got %d", want, pool.Outstanding())
+	}
+}
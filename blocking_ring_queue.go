@@ -0,0 +1,233 @@
+package bytepool
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var _ RingQueuer = (*BlockingRingQueue[int])(nil)
+
+var (
+	// ErrQueueTimeout is returned when a blocking queue operation times out
+	ErrQueueTimeout = errors.New("bytepool: queue operation timed out")
+	// ErrQueueClosed is returned when operating on a closed queue
+	ErrQueueClosed = errors.New("bytepool: queue is closed")
+)
+
+// BlockingRingQueue is a bounded ring queue usable as a work queue
+// Push keeps the RingQueuer overwrite semantics, while PushBlocking and PopBlocking
+// wait for space or data with an optional timeout
+type BlockingRingQueue[T any] struct {
+	mu      sync.Mutex
+	data    []T
+	size    int
+	readPos int // position of the oldest element
+	count   int
+	closed  bool
+	changed chan struct{} // closed and replaced on every state change
+}
+
+// NewBlockingRingQueue creates a new blocking ring queue with the specified size
+func NewBlockingRingQueue[T any](size int) *BlockingRingQueue[T] {
+	if size <= 0 {
+		panic("ring queue size must be positive")
+	}
+	return &BlockingRingQueue[T]{
+		data:    make([]T, size),
+		size:    size,
+		changed: make(chan struct{}),
+	}
+}
+
+// broadcast wakes all waiters, caller holds the lock
+func (q *BlockingRingQueue[T]) broadcast() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+// wait blocks until the queue changes or the deadline passes, caller holds the lock
+// Returns with the lock held
+func (q *BlockingRingQueue[T]) wait(timer *time.Timer) error {
+	changed := q.changed
+	q.mu.Unlock()
+	defer q.mu.Lock()
+
+	if timer == nil {
+		<-changed
+		return nil
+	}
+	select {
+	case <-changed:
+		return nil
+	case <-timer.C:
+		return ErrQueueTimeout
+	}
+}
+
+// newTimer returns a timer for timeout, nil means wait forever
+func newTimer(timeout time.Duration) *time.Timer {
+	if timeout <= 0 {
+		return nil
+	}
+	return time.NewTimer(timeout)
+}
+
+// push appends an element, caller holds the lock and ensured there is space
+func (q *BlockingRingQueue[T]) push(item T) {
+	q.data[(q.readPos+q.count)%q.size] = item
+	q.count++
+	q.broadcast()
+}
+
+// Push adds an element, overwriting the oldest one when the queue is full
+// Pushes to a closed queue are dropped
+func (q *BlockingRingQueue[T]) Push(item T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+	if q.count == q.size {
+		var zero T
+		q.data[q.readPos] = zero
+		q.readPos = (q.readPos + 1) % q.size
+		q.count--
+	}
+	q.push(item)
+}
+
+// PushBlocking adds an element, waiting for free space
+// A timeout of zero or less waits forever
+func (q *BlockingRingQueue[T]) PushBlocking(item T, timeout time.Duration) error {
+	timer := newTimer(timeout)
+	if timer != nil {
+		defer timer.Stop()
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for {
+		if q.closed {
+			return ErrQueueClosed
+		}
+		if q.count < q.size {
+			q.push(item)
+			return nil
+		}
+		if err := q.wait(timer); err != nil {
+			return err
+		}
+	}
+}
+
+// pop removes the oldest element, caller holds the lock and ensured it exists
+func (q *BlockingRingQueue[T]) pop() T {
+	var zero T
+	item := q.data[q.readPos]
+	q.data[q.readPos] = zero
+	q.readPos = (q.readPos + 1) % q.size
+	q.count--
+	q.broadcast()
+	return item
+}
+
+// Pop removes and returns the oldest element without waiting
+// Returns zero value and false if queue is empty
+func (q *BlockingRingQueue[T]) Pop() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.count == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.pop(), true
+}
+
+// PopBlocking removes and returns the oldest element, waiting for one to arrive
+// A timeout of zero or less waits forever. A closed queue is drained before
+// ErrQueueClosed is returned
+func (q *BlockingRingQueue[T]) PopBlocking(timeout time.Duration) (T, error) {
+	timer := newTimer(timeout)
+	if timer != nil {
+		defer timer.Stop()
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var zero T
+	for {
+		if q.count > 0 {
+			return q.pop(), nil
+		}
+		if q.closed {
+			return zero, ErrQueueClosed
+		}
+		if err := q.wait(timer); err != nil {
+			return zero, err
+		}
+	}
+}
+
+// Close wakes all waiters; further pushes fail and pops drain the remaining elements
+func (q *BlockingRingQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.closed {
+		q.closed = true
+		q.broadcast()
+	}
+}
+
+// Bytes returns all current data in the queue in order (oldest to newest)
+func (q *BlockingRingQueue[T]) Bytes() []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.count == 0 {
+		return nil
+	}
+	result := make([]T, q.count)
+	for i := range result {
+		result[i] = q.data[(q.readPos+i)%q.size]
+	}
+	return result
+}
+
+// Len returns the current number of elements
+func (q *BlockingRingQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.count
+}
+
+// Cap returns the queue capacity
+func (q *BlockingRingQueue[T]) Cap() int {
+	return q.size
+}
+
+// IsFull checks if the queue is full
+func (q *BlockingRingQueue[T]) IsFull() bool {
+	return q.Len() == q.size
+}
+
+// IsEmpty checks if the queue is empty
+func (q *BlockingRingQueue[T]) IsEmpty() bool {
+	return q.Len() == 0
+}
+
+// Clear empties the queue and wakes blocked pushers
+func (q *BlockingRingQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	clear(q.data)
+	q.readPos = 0
+	q.count = 0
+	q.broadcast()
+}
@@ -0,0 +1,103 @@
+package bytepool
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingRingQueue_Basic(t *testing.T) {
+	q := NewBlockingRingQueue[int](2)
+
+	q.Push(1)
+	q.Push(2)
+	q.Push(3) // overwrites 1, as any RingQueuer
+	if got := q.Bytes(); !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("Expected [2 3], got %v", got)
+	}
+
+	if err := q.PushBlocking(4, 10*time.Millisecond); !errors.Is(err, ErrQueueTimeout) {
+		t.Errorf("Expected ErrQueueTimeout on full queue, got %v", err)
+	}
+
+	if v, err := q.PopBlocking(time.Second); err != nil || v != 2 {
+		t.Errorf("Expected 2, got %d (%v)", v, err)
+	}
+	if v, ok := q.Pop(); !ok || v != 3 {
+		t.Errorf("Expected 3, got %d (%v)", v, ok)
+	}
+	if _, err := q.PopBlocking(10 * time.Millisecond); !errors.Is(err, ErrQueueTimeout) {
+		t.Errorf("Expected ErrQueueTimeout on empty queue, got %v", err)
+	}
+}
+
+func TestBlockingRingQueue_WorkQueue(t *testing.T) {
+	q := NewBlockingRingQueue[int](4)
+	const items = 1000
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range items {
+			if err := q.PushBlocking(i, 0); err != nil {
+				t.Errorf("PushBlocking: %v", err)
+				return
+			}
+		}
+		q.Close()
+	}()
+
+	var got []int
+	for {
+		v, err := q.PopBlocking(0)
+		if errors.Is(err, ErrQueueClosed) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, v)
+	}
+	wg.Wait()
+
+	if len(got) != items {
+		t.Fatalf("Expected %d items, got %d", items, len(got))
+	}
+	for i, v := range got {
+		if v != i {
+			t.Fatalf("Expected FIFO order, got %d at %d", v, i)
+		}
+	}
+}
+
+func TestBlockingRingQueue_CloseWakesWaiters(t *testing.T) {
+	q := NewBlockingRingQueue[int](1)
+
+	errc := make(chan error)
+	go func() {
+		_, err := q.PopBlocking(0)
+		errc <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+
+	if err := <-errc; !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Expected ErrQueueClosed, got %v", err)
+	}
+	if err := q.PushBlocking(1, 0); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Expected ErrQueueClosed on push, got %v", err)
+	}
+}
+
+func TestBlockingRingQueue_AsTracker(t *testing.T) {
+	q := NewBlockingRingQueue[int](8)
+	pool := NewPools([]int{128}, WithRingQueue(q))
+	pool.Get(42)
+
+	if v, ok := q.Pop(); !ok || v != 42 {
+		t.Errorf("Expected tracked length 42, got %d (%v)", v, ok)
+	}
+}
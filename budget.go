@@ -0,0 +1,83 @@
+package bytepool
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// WithSoftBudget throttles Gets while leased pooled buffers exceed budget bytes
+// Get waits once for delay and then proceeds, GetContext keeps waiting in delay steps
+// until leased memory drops below the budget or the context is done
+// Throttling smooths bursts instead of failing them, oversize Gets are not throttled
+func WithSoftBudget(budget int64, delay time.Duration) Option {
+	return func(p *BytePool) {
+		p.initial.SoftBudget = budget
+		p.initial.SoftDelay = delay
+	}
+}
+
+// overSoftBudget reports whether leased bytes exceed the soft budget
+func (p *BytePool) overSoftBudget() bool {
+	budget := p.state.Load().cfg.SoftBudget
+	return budget > 0 && atomic.LoadInt64(&p.inUseBytes) > budget
+}
+
+// logBudgetBreach reports a Get throttled by the soft budget
+// The event hooks only see the first breach until leased memory recovers
+func (p *BytePool) logBudgetBreach() {
+	if p.listening() && p.overBudget.CompareAndSwap(false, true) {
+		p.emit(Event{Kind: EventBudgetExceeded, Bytes: p.InUseBytes(), Limit: p.state.Load().cfg.SoftBudget})
+	}
+	if p.events == nil {
+		return
+	}
+	p.logEvent(slog.LevelWarn, eventBudgetBreach, "bytepool: soft budget exceeded",
+		slog.Int64("in_use_bytes", p.InUseBytes()), slog.Int64("budget", p.state.Load().cfg.SoftBudget))
+}
+
+// checkBudgetRecovered emits EventBudgetRecovered once leased memory is back within the budget
+func (p *BytePool) checkBudgetRecovered() {
+	if !p.overSoftBudget() && p.overBudget.CompareAndSwap(true, false) {
+		p.emit(Event{Kind: EventBudgetRecovered, Bytes: p.InUseBytes(), Limit: p.state.Load().cfg.SoftBudget})
+	}
+}
+
+// InUseBytes returns the bytes of pooled buffers currently leased
+func (p *BytePool) InUseBytes() int64 {
+	p.flushStats()
+	return max(atomic.LoadInt64(&p.inUseBytes), 0)
+}
+
+// GetContext retrieves a []byte like Get, waiting while the pool is over its soft budget
+// Returns the context error if ctx is done before the budget frees up, and ErrTooLong
+// for lengths refused by WithMaxGetLength
+// The lease is attributed to the RequestStats carried by ctx, see ContextWithStats
+func (p *BytePool) GetContext(ctx context.Context, length int) ([]byte, error) {
+	if err := p.refuse(length); err != nil {
+		return nil, err
+	}
+	if st := p.state.Load(); st.cfg.SoftBudget > 0 && length <= st.maxSize {
+		for throttled := false; p.overSoftBudget(); throttled = true {
+			if !throttled {
+				atomic.AddInt64(&p.throttled, 1)
+				p.logBudgetBreach()
+			}
+			if err := p.sleep(ctx, st.cfg.SoftDelay); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if p.faults != nil && length > 0 {
+		if err := p.faults(OpGet, length); err != nil {
+			return nil, err
+		}
+	}
+	buf := p.lease(length, nil)
+	recordGet(ctx, buf)
+	return buf, nil
+}
@@ -0,0 +1,85 @@
+package bytepool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithSoftBudget(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	pool := NewPools([]int{128, 256}, WithClock(clock), WithSoftBudget(256, time.Millisecond))
+
+	a := pool.Get(200)
+	if pool.InUseBytes() != 256 {
+		t.Errorf("Expected 256 in use bytes, got %d", pool.InUseBytes())
+	}
+	b := pool.Get(100) // at the budget, not over it
+	if pool.Stats().Throttled != 0 {
+		t.Error("Expected no throttling at the budget")
+	}
+
+	// over budget: Get waits for one delay then proceeds
+	done := make(chan []byte)
+	go func() { done <- pool.Get(100) }()
+	waitForWaiters(t, clock, 1)
+	clock.Advance(time.Millisecond)
+	c := <-done
+	if pool.Stats().Throttled != 1 {
+		t.Errorf("Expected 1 throttled get, got %d", pool.Stats().Throttled)
+	}
+
+	pool.Put(a)
+	pool.Put(b)
+	pool.Put(c)
+	if pool.InUseBytes() != 0 {
+		t.Errorf("Expected 0 in use bytes, got %d", pool.InUseBytes())
+	}
+}
+
+func TestBytePool_GetContext(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	pool := NewPools([]int{128}, WithClock(clock), WithSoftBudget(100, time.Millisecond))
+
+	held := pool.Get(128)
+
+	// cancelation aborts the wait
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error)
+	go func() {
+		_, err := pool.GetContext(ctx, 64)
+		errc <- err
+	}()
+	waitForWaiters(t, clock, 1)
+	cancel()
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	// releasing memory lets the waiter through after the next backoff step,
+	// the canceled waiter is still registered on the clock
+	bufc := make(chan []byte)
+	go func() {
+		buf, _ := pool.GetContext(context.Background(), 64)
+		bufc <- buf
+	}()
+	waitForWaiters(t, clock, 2)
+	pool.Put(held)
+	clock.Advance(time.Millisecond)
+	if buf := <-bufc; len(buf) != 64 {
+		t.Errorf("Expected 64 bytes, got %d", len(buf))
+	}
+}
+
+// waitForWaiters blocks until n goroutines are sleeping on the manual clock
+func waitForWaiters(t *testing.T, clock *ManualClock, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for clock.Waiters() < n {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for a clock waiter")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
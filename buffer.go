@@ -1,18 +1,31 @@
 package bytepool
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"runtime/trace"
 	"sync/atomic"
+	"unsafe"
 )
 
 // Buffer represents a reference-counted byte buffer that can be safely shared
 type Buffer struct {
-	buf      atomic.Pointer[[]byte] // use type-safe atomic.Pointer
-	refCount int32
-	pools    *BytePool
+	buf       atomic.Pointer[[]byte] // use type-safe atomic.Pointer
+	refCount  int32
+	saturated atomic.Bool // set once the refcount passed its limit, see saturate
+	pools     *BytePool
+	release   func() // called at refcount zero instead of returning to pools, for foreign memory
+	sealed    atomic.Bool
+	writers   int32  // outstanding RetainForWrite holders
+	sealSum   uint64 // content hash taken by Seal in debug mode
+	header    int    // header bytes in front of the payload, set by GetFramed
 }
 
 // Bytes returns the buffer data and a release function
-// The caller must call the release function when done with the data
+// The data is capped at the logical length, so appending to it or reslicing it never
+// exposes the slack of the tier. The caller must call the release function when done
 func (b *Buffer) Bytes() ([]byte, func()) {
 	b.Retain()
 
@@ -22,22 +35,156 @@ func (b *Buffer) Bytes() ([]byte, func()) {
 		return nil, func() {}
 	}
 
-	return *bufPtr, b.Release
+	data := *bufPtr
+	return data[:len(data):len(data)], b.Release
+}
+
+// Raw returns the full capacity of the backing memory and a release function, for
+// advanced users filling the buffer in place. Bytes past Len are not part of the data
+// until SetLen extends the logical length over them
+func (b *Buffer) Raw() ([]byte, func()) {
+	b.Retain()
+
+	bufPtr := b.buf.Load()
+	if bufPtr == nil {
+		return nil, func() {}
+	}
+
+	data := *bufPtr
+	return data[:cap(data)], b.Release
+}
+
+// Len returns the logical length of the buffer, 0 once released
+func (b *Buffer) Len() int {
+	if bufPtr := b.buf.Load(); bufPtr != nil {
+		return len(*bufPtr)
+	}
+	return 0
+}
+
+// SetLen sets the logical length returned by Bytes, up to the capacity shown by Raw
+// It returns ErrOutOfRange beyond the capacity, ErrSealed once sealed and ErrReleased
+// once the last reference was released
+func (b *Buffer) SetLen(n int) error {
+	if b.sealed.Load() {
+		return ErrSealed
+	}
+	for {
+		bufPtr := b.buf.Load()
+		if bufPtr == nil {
+			return ErrReleased
+		}
+		if n < 0 || n > cap(*bufPtr) {
+			return fmt.Errorf("%w: length %d, capacity %d", ErrOutOfRange, n, cap(*bufPtr))
+		}
+		data := (*bufPtr)[:n]
+		if b.buf.CompareAndSwap(bufPtr, &data) {
+			return nil
+		}
+	}
+}
+
+// CopyTo copies the buffer data into dst, which the caller owns past Release
+// It returns io.ErrShortBuffer after a partial copy when dst is too small, and
+// ErrReleased once the last reference was released
+func (b *Buffer) CopyTo(dst []byte) (int, error) {
+	data, release := b.Bytes()
+	defer release()
+	if data == nil {
+		return 0, ErrReleased
+	}
+	n := copy(dst, data)
+	if n < len(data) {
+		return n, io.ErrShortBuffer
+	}
+	return n, nil
+}
+
+// AppendTo appends a copy of the buffer data to dst and returns the extended slice
+// dst is returned unchanged once the last reference was released
+func (b *Buffer) AppendTo(dst []byte) []byte {
+	data, release := b.Bytes()
+	defer release()
+	return append(dst, data...)
+}
+
+// Split returns contiguous views over the buffer data with the given sizes, e.g. the
+// Y/U/V planes of a planar frame, and a single release function for all of them
+// Each view is capped at its own size so appending to one plane never overwrites the next
+// Returns nil and a no-op release function when a size is negative or the sizes exceed the data
+func (b *Buffer) Split(sizes ...int) ([][]byte, func()) {
+	data, release := b.Bytes()
+
+	total := 0
+	for _, size := range sizes {
+		if size < 0 {
+			release()
+			return nil, func() {}
+		}
+		total += size
+	}
+	if total > len(data) {
+		release()
+		return nil, func() {}
+	}
+
+	planes := make([][]byte, len(sizes))
+	offset := 0
+	for i, size := range sizes {
+		planes[i] = data[offset : offset+size : offset+size]
+		offset += size
+	}
+	return planes, release
+}
+
+// UnsafeString returns the buffer data as a string without copying, and a release function
+// The string aliases pooled memory, so it must not be used after release is called and
+// must not be stored in long-lived structures such as map keys that outlive the release
+func (b *Buffer) UnsafeString() (string, func()) {
+	data, release := b.Bytes()
+	if len(data) == 0 {
+		return "", release
+	}
+	return unsafe.String(&data[0], len(data)), release
 }
 
 // Release decrements the reference count and returns the buffer to pool when count reaches zero
 func (b *Buffer) Release() {
-	if atomic.AddInt32(&b.refCount, -1) == 0 {
+	if b.pools != nil && b.pools.tracing && trace.IsEnabled() {
+		defer trace.StartRegion(context.Background(), "bytepool.Release").End()
+	}
+	if b.saturated.Load() {
+		return
+	}
+	n := atomic.AddInt32(&b.refCount, -1)
+	if n < 0 {
+		if b.pools != nil && b.pools.misuse(MisuseRefCountUnderflow, b.pools.debugPolicy(), "buffer released more often than retained") {
+			b.pools.logEvent(slog.LevelWarn, eventUnderflow, "bytepool: buffer released more often than retained")
+		}
+		return
+	}
+	if n == 0 {
 		bufPtr := b.buf.Swap(nil)
-		if bufPtr != nil {
-			b.pools.Put(*bufPtr)
+		if bufPtr == nil {
+			return
+		}
+		if b.sealed.Load() && b.pools != nil && b.pools.debug {
+			b.verifySeal(*bufPtr)
 		}
+		if b.release != nil {
+			b.release()
+			return
+		}
+		b.pools.Put(*bufPtr)
 	}
 }
 
 // Retain increments the reference count
+// A count past the limit set by WithMaxRefCount saturates the buffer, see MisuseRefCountOverflow
 func (b *Buffer) Retain() {
-	atomic.AddInt32(&b.refCount, 1)
+	if n := atomic.AddInt32(&b.refCount, 1); n > b.maxRefs() {
+		b.saturate(n)
+	}
 }
 
 // NewBuffer creates a new Buffer with the given data and pool reference
@@ -49,3 +196,15 @@ func NewBuffer(data []byte, pools *BytePool) *Buffer {
 	buf.buf.Store(&data)
 	return buf
 }
+
+// NewBufferWithRelease wraps memory the pool does not own, e.g. an mmap region, a cgo
+// allocation or an arena slice, in a Buffer with a reference count of 1
+// release is called once when the count reaches zero, a nil release makes it a no-op
+func NewBufferWithRelease(data []byte, release func()) *Buffer {
+	if release == nil {
+		release = func() {}
+	}
+	buf := NewBuffer(data, nil)
+	buf.release = release
+	return buf
+}
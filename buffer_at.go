@@ -0,0 +1,51 @@
+package bytepool
+
+import (
+	"fmt"
+	"io"
+)
+
+var (
+	_ io.WriterAt = (*Buffer)(nil)
+	_ io.ReaderAt = (*Buffer)(nil)
+)
+
+// WriteAt copies p into the buffer at off, implementing io.WriterAt
+// Goroutines may fill disjoint regions concurrently, each call holds a reference so the
+// buffer cannot return to the pool mid-write. Nothing is written when the region does
+// not fit, and ErrSealed is returned once the buffer is sealed
+func (b *Buffer) WriteAt(p []byte, off int64) (int, error) {
+	data, release, err := b.RetainForWrite()
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+	if data == nil {
+		return 0, ErrReleased
+	}
+	if off < 0 || off > int64(len(data)) || int64(len(p)) > int64(len(data))-off {
+		return 0, fmt.Errorf("%w: write of %d bytes at %d, length %d", ErrOutOfRange, len(p), off, len(data))
+	}
+	return copy(data[off:], p), nil
+}
+
+// ReadAt copies the buffer content at off into p, implementing io.ReaderAt
+// It returns io.EOF when fewer than len(p) bytes remain after off
+func (b *Buffer) ReadAt(p []byte, off int64) (int, error) {
+	data, release := b.Bytes()
+	defer release()
+	if data == nil {
+		return 0, ErrReleased
+	}
+	if off < 0 {
+		return 0, fmt.Errorf("%w: read at %d", ErrOutOfRange, off)
+	}
+	if off >= int64(len(data)) {
+		return 0, io.EOF
+	}
+	n := copy(p, data[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
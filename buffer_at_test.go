@@ -0,0 +1,75 @@
+package bytepool
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestBuffer_WriteAtConcurrent(t *testing.T) {
+	pool := NewPools([]int{4096})
+	buf := pool.GetBuffer(4000)
+
+	const chunk = 500
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			part := make([]byte, chunk)
+			for j := range part {
+				part[j] = byte(i)
+			}
+			if n, err := buf.WriteAt(part, int64(i*chunk)); n != chunk || err != nil {
+				t.Errorf("Expected %d bytes written, got %d, %v", chunk, n, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got := make([]byte, 1)
+	for i := range 8 {
+		if _, err := buf.ReadAt(got, int64(i*chunk+chunk-1)); err != nil || got[0] != byte(i) {
+			t.Errorf("Expected chunk %d filled, got %d, %v", i, got[0], err)
+		}
+	}
+	buf.Release()
+}
+
+func TestBuffer_WriteAtBounds(t *testing.T) {
+	buf := NewBuffer(make([]byte, 10), nil)
+
+	if n, err := buf.WriteAt([]byte("abc"), 8); n != 0 || !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange for overflowing write, got %d, %v", n, err)
+	}
+	if _, err := buf.WriteAt([]byte("a"), -1); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange for negative offset, got %v", err)
+	}
+	if n, err := buf.WriteAt([]byte("abc"), 7); n != 3 || err != nil {
+		t.Errorf("Expected write at the end to fit, got %d, %v", n, err)
+	}
+
+	p := make([]byte, 5)
+	if n, err := buf.ReadAt(p, 7); n != 3 || err != io.EOF || string(p[:n]) != "abc" {
+		t.Errorf("Expected short read with io.EOF, got %d %q, %v", n, p[:n], err)
+	}
+	if _, err := buf.ReadAt(p, 10); err != io.EOF {
+		t.Errorf("Expected io.EOF at the end, got %v", err)
+	}
+
+	buf.Seal()
+	if _, err := buf.WriteAt([]byte("a"), 0); !errors.Is(err, ErrSealed) {
+		t.Errorf("Expected ErrSealed, got %v", err)
+	}
+}
+
+func TestBuffer_ReadAtReleased(t *testing.T) {
+	pool := NewPools([]int{128})
+	buf := pool.GetBuffer(100)
+	buf.Release()
+
+	if _, err := buf.ReadAt(make([]byte, 1), 0); !errors.Is(err, ErrReleased) {
+		t.Errorf("Expected ErrReleased, got %v", err)
+	}
+}
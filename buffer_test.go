@@ -1,7 +1,9 @@
 package bytepool
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"runtime"
 	"sync"
 	"testing"
@@ -219,3 +221,95 @@ func BenchmarkHighContention(b *testing.B) {
 	}
 	wg.Wait()
 }
+
+func TestBuffer_CopyTo(t *testing.T) {
+	pool := NewPools([]int{128})
+	buf := pool.GetBuffer(5)
+	data, release := buf.Bytes()
+	copy(data, "hello")
+	release()
+
+	dst := make([]byte, 8)
+	if n, err := buf.CopyTo(dst); n != 5 || err != nil || string(dst[:n]) != "hello" {
+		t.Errorf("Expected 5 bytes copied, got %d %q, %v", n, dst[:n], err)
+	}
+	short := make([]byte, 3)
+	if n, err := buf.CopyTo(short); n != 3 || !errors.Is(err, io.ErrShortBuffer) {
+		t.Errorf("Expected short copy with io.ErrShortBuffer, got %d, %v", n, err)
+	}
+
+	owned := buf.AppendTo([]byte("say "))
+	buf.Release()
+	if string(owned) != "say hello" {
+		t.Errorf("Expected appended copy, got %q", owned)
+	}
+	if _, err := buf.CopyTo(dst); !errors.Is(err, ErrReleased) {
+		t.Errorf("Expected ErrReleased, got %v", err)
+	}
+	if got := buf.AppendTo(nil); got != nil {
+		t.Errorf("Expected nothing appended after release, got %q", got)
+	}
+}
+
+func TestBuffer_LogicalLength(t *testing.T) {
+	pool := NewPools([]int{128})
+	buf := pool.GetBuffer(5)
+
+	data, release := buf.Bytes()
+	if len(data) != 5 || cap(data) != 5 {
+		t.Errorf("Expected view capped at the logical length, got len %d cap %d", len(data), cap(data))
+	}
+	release()
+
+	raw, release := buf.Raw()
+	if len(raw) != 128 {
+		t.Errorf("Expected raw view of the tier capacity, got %d", len(raw))
+	}
+	copy(raw, "hello, world")
+	release()
+
+	if err := buf.SetLen(12); err != nil {
+		t.Fatal(err)
+	}
+	if data, release := buf.Bytes(); string(data) != "hello, world" || buf.Len() != 12 {
+		t.Errorf("Expected extended logical length, got %q", data)
+		release()
+	} else {
+		release()
+	}
+	if err := buf.SetLen(129); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange beyond capacity, got %v", err)
+	}
+
+	buf.Seal()
+	if err := buf.SetLen(1); !errors.Is(err, ErrSealed) {
+		t.Errorf("Expected ErrSealed, got %v", err)
+	}
+	buf.Release()
+	if buf.Len() != 0 {
+		t.Errorf("Expected zero length after release, got %d", buf.Len())
+	}
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected buffer returned, got %d outstanding", pool.Outstanding())
+	}
+}
+
+func TestNewBufferWithRelease(t *testing.T) {
+	arena := make([]byte, 64)
+	released := 0
+	buf := NewBufferWithRelease(arena[:16], func() { released++ })
+
+	buf.Retain()
+	buf.Release()
+	if released != 0 {
+		t.Error("Expected release to wait for the last reference")
+	}
+	buf.Release()
+	buf.Release()
+	if released != 1 {
+		t.Errorf("Expected release called once, got %d", released)
+	}
+
+	// a nil release only drops the reference
+	NewBufferWithRelease(arena, nil).Release()
+}
@@ -0,0 +1,86 @@
+package bytepool
+
+import "testing"
+
+func TestBuffer_Split(t *testing.T) {
+	pool := NewPools(SizePowerOfTwo())
+
+	// 4x2 YUV420 frame: Y=8, U=2, V=2
+	buf := pool.GetBuffer(12)
+	planes, release := buf.Split(8, 2, 2)
+	buf.Release()
+
+	if len(planes) != 3 {
+		t.Fatalf("Expected 3 planes, got %d", len(planes))
+	}
+	for i, want := range []int{8, 2, 2} {
+		if len(planes[i]) != want || cap(planes[i]) != want {
+			t.Errorf("Expected plane %d len/cap %d, got %d/%d", i, want, len(planes[i]), cap(planes[i]))
+		}
+	}
+
+	// planes must be contiguous views over the same allocation
+	data, done := buf.Bytes()
+	planes[1][0] = 0xAB
+	if data[8] != 0xAB {
+		t.Error("Expected plane views to share storage with the buffer")
+	}
+	done()
+
+	if pool.GetPoolStats()["total_put"].(int64) != 0 {
+		t.Error("Expected buffer to stay leased until release")
+	}
+	release()
+	if pool.GetPoolStats()["total_put"].(int64) != 1 {
+		t.Error("Expected buffer to return to the pool after release")
+	}
+}
+
+func TestBuffer_SplitInvalid(t *testing.T) {
+	pool := NewPools(SizePowerOfTwo())
+	buf := pool.GetBuffer(10)
+	defer buf.Release()
+
+	if planes, release := buf.Split(8, 4); planes != nil {
+		release()
+		t.Error("Expected nil planes when sizes exceed data")
+	}
+	if planes, release := buf.Split(-1); planes != nil {
+		release()
+		t.Error("Expected nil planes for negative size")
+	}
+}
+
+func TestBuffer_UnsafeString(t *testing.T) {
+	pool := NewPools(SizePowerOfTwo())
+	buf := pool.GetBuffer(5)
+	data, done := buf.Bytes()
+	copy(data, "hello")
+	done()
+
+	s, release := buf.UnsafeString()
+	buf.Release()
+	if s != "hello" {
+		t.Errorf("Expected %q, got %q", "hello", s)
+	}
+
+	// the lookup key must not force a copy or an early return to the pool
+	m := map[string]int{"hello": 1}
+	if m[s] != 1 {
+		t.Error("Expected map lookup to match")
+	}
+	if pool.GetPoolStats()["total_put"].(int64) != 0 {
+		t.Error("Expected buffer to stay leased until release")
+	}
+	release()
+	if pool.GetPoolStats()["total_put"].(int64) != 1 {
+		t.Error("Expected buffer to return to the pool after release")
+	}
+
+	empty := NewBuffer(nil, pool)
+	if s, release := empty.UnsafeString(); s != "" {
+		t.Errorf("Expected empty string, got %q", s)
+	} else {
+		release()
+	}
+}
@@ -0,0 +1,51 @@
+// Package bytepooltest provides test helpers for code using bytepool, kept apart so the
+// bytepool package does not import testing
+//
+//	func TestHandler(t *testing.T) {
+//		pool := bytepooltest.CheckedPool(t, bytepool.SizePowerOfTwo())
+//		...
+//	}
+package bytepooltest
+
+import (
+	"io"
+	"testing"
+
+	"github.com/ixugo/bytepool"
+)
+
+// VerifyNoLeaks registers a cleanup on t that fails the test if the pool holds more
+// outstanding buffers at cleanup than it did when VerifyNoLeaks was called
+func VerifyNoLeaks(t testing.TB, pool *bytepool.BytePool) {
+	t.Helper()
+	baseline := pool.Outstanding()
+	t.Cleanup(func() {
+		t.Helper()
+		if leaked := pool.CheckLeaks(baseline); leaked > 0 {
+			t.Errorf("bytepool: %d buffer(s) not returned to the pool", leaked)
+		}
+	})
+}
+
+// CheckedPool creates a pool for use in tests that fails t if any buffer is still leased at cleanup
+func CheckedPool(t testing.TB, sizes []int, opts ...bytepool.Option) *bytepool.BytePool {
+	t.Helper()
+	pool := bytepool.NewPools(sizes, opts...)
+	VerifyNoLeaks(t, pool)
+	return pool
+}
+
+// ReplayOpLog replays the op log read from r against pool and fails t on a decode error,
+// handle misuse or broken invariant. It returns the pool statistics after the replay
+// so regression tests can compare them with the values seen in production
+func ReplayOpLog(t testing.TB, pool *bytepool.BytePool, r io.Reader) bytepool.Report {
+	t.Helper()
+	ops, err := bytepool.ReadOpLog(r)
+	if err != nil {
+		t.Fatalf("bytepool: %v", err)
+	}
+	if err := bytepool.Replay(pool, ops); err != nil {
+		t.Fatalf("bytepool: replay: %v", err)
+	}
+	return pool.Stats()
+}
@@ -0,0 +1,73 @@
+package bytepooltest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ixugo/bytepool"
+)
+
+// recordingTB captures failures so leak detection itself can be tested
+type recordingTB struct {
+	testing.TB
+	failed   bool
+	cleanups []func()
+}
+
+func (r *recordingTB) Helper()               {}
+func (r *recordingTB) Errorf(string, ...any) { r.failed = true }
+func (r *recordingTB) Cleanup(f func())      { r.cleanups = append(r.cleanups, f) }
+func (r *recordingTB) runCleanups() {
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+}
+
+func TestVerifyNoLeaks(t *testing.T) {
+	rec := &recordingTB{TB: t}
+	pool := CheckedPool(rec, []int{128, 256})
+
+	leaked := pool.Get(100)
+	pool.Put(pool.Get(200))
+	rec.runCleanups()
+	if !rec.failed {
+		t.Error("Expected leak to be reported")
+	}
+
+	pool.Put(leaked)
+	rec = &recordingTB{TB: t}
+	VerifyNoLeaks(rec, pool)
+	pool.GetBuffer(50).Release()
+	rec.runCleanups()
+	if rec.failed {
+		t.Error("Expected balanced pool to pass")
+	}
+}
+
+func TestCheckedPool(t *testing.T) {
+	pool := CheckedPool(t, bytepool.SizePowerOfTwo())
+	buf := pool.GetBuffer(1024)
+	buf.Release()
+
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected no outstanding buffers, got %d", pool.Outstanding())
+	}
+}
+
+func TestReplayOpLog(t *testing.T) {
+	log := `{"op":"get","g":1,"id":1,"len":100}
+{"op":"get_buffer","g":2,"id":2,"len":200}
+{"op":"retain","g":2,"id":2}
+
+{"op":"put","g":1,"id":1}
+{"op":"release","g":3,"id":2}
+{"op":"get","g":1,"id":1,"len":1000}
+{"op":"release","g":2,"id":2}
+`
+	pool := bytepool.NewPools([]int{128, 256})
+	report := ReplayOpLog(t, pool, strings.NewReader(log))
+
+	if report.TotalGet != 2 || report.TotalPut != 2 || report.Discarded != 1 {
+		t.Errorf("Expected 2 gets, 2 puts and 1 oversize, got %+v", report)
+	}
+}
@@ -0,0 +1,332 @@
+package bytepool
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+// errNegativeRead is returned when a reader reports a negative count
+var errNegativeRead = errors.New("bytepool: reader returned negative count from Read")
+
+// errUnreadByte is returned when UnreadByte or UnreadRune follow an incompatible operation
+var errUnreadByte = errors.New("bytepool: UnreadByte: previous operation was not a successful read")
+
+// minReadSize is the minimum free space ReadFrom keeps before each read, as in bytes.Buffer
+const minReadSize = 512
+
+// readOp records the last read so UnreadByte/UnreadRune know what to undo
+type readOp int8
+
+const (
+	opRead      readOp = -1
+	opInvalid   readOp = 0
+	opReadRune1 readOp = 1 // rune sizes 1..4
+)
+
+// PooledBytesBuffer is a drop-in replacement for bytes.Buffer whose storage comes from
+// BytePool tiers. Close returns the storage to the pool; the zero value is not usable,
+// create it with BytePool.NewBytesBuffer
+type PooledBytesBuffer struct {
+	pool     *BytePool
+	buf      []byte // contents are buf[off:len(buf)]
+	off      int
+	lastRead readOp
+}
+
+// NewBytesBuffer creates a PooledBytesBuffer with room for at least size bytes
+func (p *BytePool) NewBytesBuffer(size int) *PooledBytesBuffer {
+	b := &PooledBytesBuffer{pool: p}
+	if size > 0 {
+		b.buf = p.Get(size)[:0]
+	}
+	return b
+}
+
+// Close returns the storage to the pool and empties the buffer
+// The buffer stays usable and leases new storage on the next write
+func (b *PooledBytesBuffer) Close() error {
+	if b.buf != nil {
+		b.pool.Put(b.buf)
+	}
+	b.buf = nil
+	b.off = 0
+	b.lastRead = opInvalid
+	return nil
+}
+
+// Bytes returns the unread portion, valid until the next modification or Close
+func (b *PooledBytesBuffer) Bytes() []byte { return b.buf[b.off:] }
+
+// AvailableBuffer returns an empty slice with Available capacity for appending
+func (b *PooledBytesBuffer) AvailableBuffer() []byte { return b.buf[len(b.buf):] }
+
+// String returns the unread portion as a string
+func (b *PooledBytesBuffer) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	return string(b.buf[b.off:])
+}
+
+// Len returns the number of unread bytes
+func (b *PooledBytesBuffer) Len() int { return len(b.buf) - b.off }
+
+// Cap returns the capacity of the underlying storage
+func (b *PooledBytesBuffer) Cap() int { return cap(b.buf) }
+
+// Available returns how many bytes are unused in the storage
+func (b *PooledBytesBuffer) Available() int { return cap(b.buf) - len(b.buf) }
+
+// Truncate discards all but the first n unread bytes
+func (b *PooledBytesBuffer) Truncate(n int) {
+	if n == 0 {
+		b.Reset()
+		return
+	}
+	b.lastRead = opInvalid
+	if n < 0 || n > b.Len() {
+		panic("bytepool.PooledBytesBuffer: truncation out of range")
+	}
+	b.buf = b.buf[:b.off+n]
+}
+
+// Reset empties the buffer but keeps the storage for future writes
+func (b *PooledBytesBuffer) Reset() {
+	b.buf = b.buf[:0]
+	b.off = 0
+	b.lastRead = opInvalid
+}
+
+// grow makes room for n more bytes and returns the index where they should be written
+func (b *PooledBytesBuffer) grow(n int) int {
+	m := b.Len()
+	if m == 0 && b.off != 0 {
+		b.Reset()
+	}
+	if len(b.buf)+n <= cap(b.buf) {
+		l := len(b.buf)
+		b.buf = b.buf[:l+n]
+		return l
+	}
+	if m+n <= cap(b.buf)/2 {
+		// enough room after sliding the unread data down
+		copy(b.buf, b.buf[b.off:])
+	} else {
+		// lease a larger tier as set by WithGrowthPolicy, doubling by default
+		next := b.pool.Get(b.pool.growLength(cap(b.buf), m+n))
+		if next == nil {
+			// refused by WithMaxGetLength, grow outside the pool like an oversize Get
+			next = make([]byte, m+n)
+		}
+		copy(next, b.buf[b.off:])
+		if b.buf != nil {
+			if m > 0 {
+				b.pool.countGrowth(m)
+			}
+			b.pool.Put(b.buf)
+		}
+		b.buf = next
+	}
+	b.off = 0
+	b.buf = b.buf[:m+n]
+	return m
+}
+
+// Grow guarantees space for another n bytes without further leases
+func (b *PooledBytesBuffer) Grow(n int) {
+	if n < 0 {
+		panic("bytepool.PooledBytesBuffer.Grow: negative count")
+	}
+	m := b.grow(n)
+	b.buf = b.buf[:m]
+}
+
+// Write appends p to the buffer
+func (b *PooledBytesBuffer) Write(p []byte) (int, error) {
+	b.lastRead = opInvalid
+	m := b.grow(len(p))
+	return copy(b.buf[m:], p), nil
+}
+
+// WriteString appends s to the buffer
+func (b *PooledBytesBuffer) WriteString(s string) (int, error) {
+	b.lastRead = opInvalid
+	m := b.grow(len(s))
+	return copy(b.buf[m:], s), nil
+}
+
+// WriteByte appends c to the buffer
+func (b *PooledBytesBuffer) WriteByte(c byte) error {
+	b.lastRead = opInvalid
+	m := b.grow(1)
+	b.buf[m] = c
+	return nil
+}
+
+// WriteRune appends the UTF-8 encoding of r to the buffer
+func (b *PooledBytesBuffer) WriteRune(r rune) (int, error) {
+	if uint32(r) < utf8.RuneSelf {
+		b.WriteByte(byte(r))
+		return 1, nil
+	}
+	b.lastRead = opInvalid
+	m := b.grow(utf8.UTFMax)
+	b.buf = utf8.AppendRune(b.buf[:m], r)
+	return len(b.buf) - m, nil
+}
+
+// ReadFrom reads from r until EOF and appends the data to the buffer
+func (b *PooledBytesBuffer) ReadFrom(r io.Reader) (int64, error) {
+	b.lastRead = opInvalid
+	var total int64
+	for {
+		i := b.grow(minReadSize)
+		b.buf = b.buf[:i]
+		m, err := r.Read(b.buf[i:cap(b.buf)])
+		if m < 0 {
+			panic(errNegativeRead)
+		}
+		b.buf = b.buf[:i+m]
+		total += int64(m)
+		if err == io.EOF {
+			return total, nil
+		}
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
+// WriteTo writes the unread data to w until the buffer is drained or an error occurs
+func (b *PooledBytesBuffer) WriteTo(w io.Writer) (int64, error) {
+	b.lastRead = opInvalid
+	n := b.Len()
+	if n == 0 {
+		b.Reset()
+		return 0, nil
+	}
+	m, err := w.Write(b.buf[b.off:])
+	if m > n {
+		panic("bytepool.PooledBytesBuffer.WriteTo: invalid Write count")
+	}
+	b.off += m
+	if err != nil {
+		return int64(m), err
+	}
+	if m != n {
+		return int64(m), io.ErrShortWrite
+	}
+	b.Reset()
+	return int64(m), nil
+}
+
+// Read reads the next len(p) bytes or until the buffer is drained
+func (b *PooledBytesBuffer) Read(p []byte) (int, error) {
+	b.lastRead = opInvalid
+	if b.Len() == 0 {
+		b.Reset()
+		if len(p) == 0 {
+			return 0, nil
+		}
+		return 0, io.EOF
+	}
+	n := copy(p, b.buf[b.off:])
+	b.off += n
+	if n > 0 {
+		b.lastRead = opRead
+	}
+	return n, nil
+}
+
+// Next returns the next n unread bytes, valid until the next modification
+func (b *PooledBytesBuffer) Next(n int) []byte {
+	b.lastRead = opInvalid
+	n = min(n, b.Len())
+	data := b.buf[b.off : b.off+n]
+	b.off += n
+	if n > 0 {
+		b.lastRead = opRead
+	}
+	return data
+}
+
+// ReadByte reads and returns the next byte
+func (b *PooledBytesBuffer) ReadByte() (byte, error) {
+	if b.Len() == 0 {
+		b.Reset()
+		return 0, io.EOF
+	}
+	c := b.buf[b.off]
+	b.off++
+	b.lastRead = opRead
+	return c, nil
+}
+
+// ReadRune reads and returns the next UTF-8 encoded rune
+func (b *PooledBytesBuffer) ReadRune() (rune, int, error) {
+	if b.Len() == 0 {
+		b.Reset()
+		return 0, 0, io.EOF
+	}
+	c := b.buf[b.off]
+	if c < utf8.RuneSelf {
+		b.off++
+		b.lastRead = opReadRune1
+		return rune(c), 1, nil
+	}
+	r, n := utf8.DecodeRune(b.buf[b.off:])
+	b.off += n
+	b.lastRead = readOp(n)
+	return r, n, nil
+}
+
+// UnreadRune unreads the last rune returned by ReadRune
+func (b *PooledBytesBuffer) UnreadRune() error {
+	if b.lastRead <= opInvalid {
+		return errors.New("bytepool: UnreadRune: previous operation was not a successful ReadRune")
+	}
+	b.off -= int(b.lastRead)
+	b.lastRead = opInvalid
+	return nil
+}
+
+// UnreadByte unreads the last byte returned by the most recent successful read
+func (b *PooledBytesBuffer) UnreadByte() error {
+	if b.lastRead == opInvalid {
+		return errUnreadByte
+	}
+	b.lastRead = opInvalid
+	if b.off > 0 {
+		b.off--
+	}
+	return nil
+}
+
+// ReadBytes reads until the first occurrence of delim and returns a copy including it
+func (b *PooledBytesBuffer) ReadBytes(delim byte) ([]byte, error) {
+	slice, err := b.readSlice(delim)
+	return append([]byte(nil), slice...), err
+}
+
+// ReadString reads until the first occurrence of delim and returns a string including it
+func (b *PooledBytesBuffer) ReadString(delim byte) (string, error) {
+	slice, err := b.readSlice(delim)
+	return string(slice), err
+}
+
+// readSlice returns a view up to and including delim
+func (b *PooledBytesBuffer) readSlice(delim byte) ([]byte, error) {
+	i := bytes.IndexByte(b.buf[b.off:], delim)
+	end := b.off + i + 1
+	var err error
+	if i < 0 {
+		end = len(b.buf)
+		err = io.EOF
+	}
+	line := b.buf[b.off:end]
+	b.off = end
+	b.lastRead = opRead
+	return line, err
+}
@@ -0,0 +1,117 @@
+package bytepool
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// bytesBufferAPI is the bytes.Buffer method set PooledBytesBuffer must provide
+type bytesBufferAPI interface {
+	io.ReadWriter
+	io.ReaderFrom
+	io.WriterTo
+	io.ByteScanner
+	io.RuneScanner
+	io.StringWriter
+	Bytes() []byte
+	AvailableBuffer() []byte
+	String() string
+	Len() int
+	Cap() int
+	Available() int
+	Truncate(n int)
+	Reset()
+	Grow(n int)
+	WriteByte(c byte) error
+	WriteRune(r rune) (int, error)
+	Next(n int) []byte
+	ReadBytes(delim byte) ([]byte, error)
+	ReadString(delim byte) (string, error)
+}
+
+var (
+	_ bytesBufferAPI = (*bytes.Buffer)(nil)
+	_ bytesBufferAPI = (*PooledBytesBuffer)(nil)
+)
+
+func TestPooledBytesBuffer_MatchesBytesBuffer(t *testing.T) {
+	pool := NewPools([]int{16, 64, 256})
+	pooled := pool.NewBytesBuffer(8)
+	defer pooled.Close()
+	var std bytes.Buffer
+
+	for _, b := range []bytesBufferAPI{pooled, &std} {
+		b.WriteString("hello ")
+		b.Write([]byte("world\n"))
+		b.WriteByte('x')
+		b.WriteRune('世')
+		b.ReadFrom(strings.NewReader(strings.Repeat("abc", 100)))
+	}
+	if pooled.String() != std.String() {
+		t.Fatalf("Content mismatch:\n%q\n%q", pooled.String(), std.String())
+	}
+
+	line1, _ := pooled.ReadString('\n')
+	line2, _ := std.ReadString('\n')
+	if line1 != line2 {
+		t.Errorf("ReadString mismatch: %q vs %q", line1, line2)
+	}
+
+	c1, _ := pooled.ReadByte()
+	c2, _ := std.ReadByte()
+	r1, n1, _ := pooled.ReadRune()
+	r2, n2, _ := std.ReadRune()
+	if c1 != c2 || r1 != r2 || n1 != n2 {
+		t.Errorf("Read mismatch: %q %q %d vs %q %q %d", c1, r1, n1, c2, r2, n2)
+	}
+	if err := pooled.UnreadRune(); err != nil {
+		t.Errorf("UnreadRune: %v", err)
+	}
+	std.UnreadRune()
+
+	if !bytes.Equal(pooled.Next(5), std.Next(5)) {
+		t.Error("Next mismatch")
+	}
+	pooled.Truncate(10)
+	std.Truncate(10)
+
+	var out1, out2 bytes.Buffer
+	pooled.WriteTo(&out1)
+	std.WriteTo(&out2)
+	if out1.String() != out2.String() {
+		t.Errorf("WriteTo mismatch: %q vs %q", out1.String(), out2.String())
+	}
+	if pooled.Len() != 0 {
+		t.Errorf("Expected drained buffer, got %d bytes", pooled.Len())
+	}
+}
+
+func TestPooledBytesBuffer_Close(t *testing.T) {
+	pool := NewPools([]int{16, 64, 256})
+	b := pool.NewBytesBuffer(0)
+
+	b.WriteString(strings.Repeat("z", 100)) // grows through tiers
+	if b.Cap() != 256 {
+		t.Errorf("Expected tier capacity 256, got %d", b.Cap())
+	}
+	if pool.Outstanding() != 1 {
+		t.Errorf("Expected only the current tier to be leased, got %d", pool.Outstanding())
+	}
+
+	b.Close()
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected storage returned on Close, got %d outstanding", pool.Outstanding())
+	}
+	if b.Len() != 0 {
+		t.Error("Expected empty buffer after Close")
+	}
+
+	// reusable after close
+	b.WriteString("again")
+	if b.String() != "again" {
+		t.Errorf("Expected %q, got %q", "again", b.String())
+	}
+	b.Close()
+}
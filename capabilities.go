@@ -0,0 +1,53 @@
+package bytepool
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// modulePath is the import path used to find the bytepool version in the build info
+const modulePath = "github.com/ixugo/bytepool"
+
+// Features describes how bytepool was built, for bug reports and support tickets
+type Features struct {
+	Version   string `json:"version"` // module version, "(devel)" when built inside the module itself
+	GoVersion string `json:"go_version"`
+	GOOS      string `json:"goos"`
+	GOARCH    string `json:"goarch"`
+	Mmap      bool   `json:"mmap"` // off-heap memory: NewBufferFromMmap, WithSpill and OpenRingFile
+	Race      bool   `json:"race"` // built with the race detector, sync.Pool tiers drop items at random
+}
+
+// Capabilities reports the version and the compiled-in features of bytepool
+// Pair it with Config, which returns the effective configuration of a pool after
+// defaults and WithAutoTune were applied
+func Capabilities() Features {
+	return Features{
+		Version:   moduleVersion(),
+		GoVersion: runtime.Version(),
+		GOOS:      runtime.GOOS,
+		GOARCH:    runtime.GOARCH,
+		Mmap:      mmapSupported,
+		Race:      raceEnabled,
+	}
+}
+
+// moduleVersion returns the bytepool version recorded in the build info
+func moduleVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	if info.Main.Path == modulePath {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			if dep.Replace != nil {
+				return dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,26 @@
+package bytepool
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestCapabilities(t *testing.T) {
+	caps := Capabilities()
+	if caps.GoVersion != runtime.Version() || caps.GOOS != runtime.GOOS {
+		t.Errorf("Expected runtime details, got %+v", caps)
+	}
+	if caps.Version == "" {
+		t.Error("Expected a version")
+	}
+	if caps.Race != raceEnabled || caps.Mmap != mmapSupported {
+		t.Errorf("Expected compiled-in features, got %+v", caps)
+	}
+}
+
+func TestBytePool_ConfigAfterAutoTune(t *testing.T) {
+	pool := NewPools([]int{128}, WithAutoTune())
+	if got, want := pool.Config().TrackerSampleEvery, pool.Stats().Tuning.SampleEvery; got != want {
+		t.Errorf("Expected tuned sample rate %d, got %d", want, got)
+	}
+}
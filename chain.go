@@ -0,0 +1,207 @@
+package bytepool
+
+import (
+	"io"
+	"sync/atomic"
+)
+
+// BufferChain is a logical buffer made of several pooled segments
+type BufferChain struct {
+	pool   *BytePool
+	segs   []*Buffer
+	off    int    // bytes of the first segment already consumed by Discard or ReadN
+	length int    // unread bytes
+	peek   []byte // pooled scratch buffer of Peek calls spanning segments
+}
+
+// Len returns the total length of all unread segment data
+func (c *BufferChain) Len() int {
+	return c.length
+}
+
+// Segments returns the unread segment views in order, valid until Release
+func (c *BufferChain) Segments() [][]byte {
+	out := make([][]byte, 0, len(c.segs))
+	for i, seg := range c.segs {
+		if bufPtr := seg.buf.Load(); bufPtr != nil {
+			if i == 0 {
+				out = append(out, (*bufPtr)[c.off:])
+			} else {
+				out = append(out, *bufPtr)
+			}
+		}
+	}
+	return out
+}
+
+// WriteTo writes all segments to w in order
+func (c *BufferChain) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	for _, seg := range c.Segments() {
+		n, err := w.Write(seg)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// Release releases every segment, the chain must not be used afterwards
+func (c *BufferChain) Release() {
+	for _, seg := range c.segs {
+		seg.Release()
+	}
+	c.segs = nil
+	c.off = 0
+	c.length = 0
+	c.releasePeek()
+}
+
+// NewChain creates an empty BufferChain to be filled with Append
+func (p *BytePool) NewChain() *BufferChain {
+	return &BufferChain{pool: p}
+}
+
+// Append adds buf as the last segment, taking over the caller's reference
+func (c *BufferChain) Append(buf *Buffer) {
+	n := 0
+	if bufPtr := buf.buf.Load(); bufPtr != nil {
+		n = len(*bufPtr)
+	}
+	c.append(buf, n)
+}
+
+// WithChainConsolidation makes BufferChain.Finalize copy chains of at most threshold
+// bytes into a single tier buffer, trading one copy for less per-segment overhead
+func WithChainConsolidation(threshold int) Option {
+	return func(p *BytePool) {
+		p.consolidateThreshold = threshold
+	}
+}
+
+// Finalize consolidates the chain into a single segment when it has several segments
+// and its length is within the pool's consolidation threshold, then returns the chain
+// Chains longer than the max get length are left as they are, see WithMaxGetLength
+func (c *BufferChain) Finalize() *BufferChain {
+	p := c.pool
+	if len(c.segs) < 2 || c.length > p.consolidateThreshold || p.state.Load().refuses(c.length) {
+		return c
+	}
+
+	merged := p.GetBuffer(c.length)
+	if merged.Len() != c.length {
+		// refused by WithFaultInjection
+		merged.Release()
+		return c
+	}
+	dst := *merged.buf.Load()
+	offset := 0
+	for _, seg := range c.Segments() {
+		offset += copy(dst[offset:], seg)
+	}
+	length := c.length
+	c.Release()
+	c.append(merged, length)
+
+	atomic.AddInt64(&p.consolidations, 1)
+	atomic.AddInt64(&p.consolidatedBytes, int64(length))
+	return c
+}
+
+// WithDegradeToChain lets GetChain assemble the requested length from idle buffers of
+// smaller tiers when the fitting tier is exhausted or the pool is over its soft budget,
+// keeping streams alive during a transient large-tier famine instead of throttling
+func WithDegradeToChain() Option {
+	return func(p *BytePool) {
+		p.degradeToChain = true
+	}
+}
+
+// GetChain retrieves a BufferChain of the specified length
+// Normally the chain has a single segment from the fitting tier, see WithDegradeToChain
+// The chain is empty when the pool refuses length, see WithMaxGetLength
+func (p *BytePool) GetChain(length int) *BufferChain {
+	chain := &BufferChain{pool: p}
+	if length <= 0 {
+		return chain
+	}
+	st := p.state.Load()
+	if st.refuses(length) {
+		atomic.AddInt64(&p.refused, 1)
+		return chain
+	}
+	if p.degradeToChain && length <= st.maxSize {
+		size := st.findBestSize(length)
+		if p.overSoftBudget() || p.idleCount(size) == 0 {
+			p.degrade(chain, length, size)
+			return chain
+		}
+	}
+	chain.append(p.GetBuffer(length), length)
+	return chain
+}
+
+// degrade fills chain from idle buffers of tiers smaller than size, then leases the
+// remainder normally
+func (p *BytePool) degrade(chain *BufferChain, length, size int) {
+	remaining := length
+	sizes := p.state.Load().sizes
+	for i := len(sizes) - 1; i >= 0 && remaining > 0; i-- {
+		tier := sizes[i]
+		if tier >= size {
+			continue
+		}
+		for remaining > 0 {
+			buf := p.takeIdle(tier)
+			if buf == nil {
+				break
+			}
+			n := min(remaining, tier)
+			chain.append(NewBuffer(buf[:n], p), n)
+			remaining -= n
+		}
+	}
+	if len(chain.segs) > 0 {
+		atomic.AddInt64(&p.degraded, 1)
+	}
+	if remaining > 0 {
+		chain.append(p.GetBuffer(remaining), remaining)
+	}
+}
+
+func (c *BufferChain) append(buf *Buffer, n int) {
+	c.segs = append(c.segs, buf)
+	c.length += n
+}
+
+// idleCount returns the known or approximate idle buffers of a tier
+func (p *BytePool) idleCount(size int) int {
+	switch store := p.state.Load().tier(size).store.(type) {
+	case lener:
+		return store.Len()
+	case approxLener:
+		return store.ApproxLen()
+	}
+	return -1
+}
+
+// takeIdle leases an idle buffer of the tier, or returns nil without allocating
+func (p *BytePool) takeIdle(size int) []byte {
+	if p.frozen.Load() {
+		return nil
+	}
+	t := p.state.Load().tier(size)
+	buf := t.get()
+	if buf == nil {
+		return nil
+	}
+	p.countGet(t.stats, size)
+	if p.tracksLeases() {
+		p.recordLease(buf)
+	}
+	if p.debug {
+		p.watermark(buf)
+	}
+	return buf
+}
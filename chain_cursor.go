@@ -0,0 +1,126 @@
+package bytepool
+
+import "io"
+
+// Peek returns the next n unread bytes without consuming them, like bufio.Reader.Peek
+// Within a segment the result is a view of it, across segment boundaries the bytes are
+// copied into a pooled scratch buffer. The result is valid until the next Peek,
+// Discard, ReadN or Release. Fewer bytes are returned with io.EOF when the chain holds
+// less than n
+func (c *BufferChain) Peek(n int) ([]byte, error) {
+	if n < 0 {
+		return nil, ErrOutOfRange
+	}
+	var err error
+	if n > c.length {
+		n, err = c.length, io.EOF
+	}
+	c.releasePeek()
+	if head := c.head(); n <= len(head) {
+		return head[:n], err
+	}
+	peek, gerr := c.pool.GetE(n)
+	if gerr != nil {
+		return nil, gerr
+	}
+	c.peek = peek
+	c.gather(c.peek)
+	return c.peek, err
+}
+
+// Discard skips the next n unread bytes and releases the segments fully consumed
+// It returns the number of bytes skipped, with io.EOF when the chain held less than n
+func (c *BufferChain) Discard(n int) (int, error) {
+	if n < 0 {
+		return 0, ErrOutOfRange
+	}
+	var err error
+	if n > c.length {
+		n, err = c.length, io.EOF
+	}
+	c.releasePeek()
+	c.consume(n)
+	return n, err
+}
+
+// ReadN consumes the next n bytes and returns them with a release function the caller
+// must call when done. Bytes within one segment are returned as a view that keeps the
+// segment alive, bytes spanning segments are copied into a buffer leased from the pool
+// Returns nil and a no-op release function when the chain holds less than n bytes, or
+// when the copy is needed and the pool refuses n, see WithMaxGetLength. The chain is
+// left untouched in both cases
+func (c *BufferChain) ReadN(n int) ([]byte, func()) {
+	if n < 0 || n > c.length {
+		return nil, func() {}
+	}
+	c.releasePeek()
+	if head := c.head(); n <= len(head) {
+		seg := c.segs[0]
+		seg.Retain()
+		view := head[:n:n]
+		c.consume(n)
+		return view, seg.Release
+	}
+	buf := c.pool.GetBuffer(n)
+	if buf.Len() != n {
+		buf.Release()
+		return nil, func() {}
+	}
+	data := *buf.buf.Load()
+	c.gather(data)
+	c.consume(n)
+	return data, buf.Release
+}
+
+// head returns the unread part of the first segment
+func (c *BufferChain) head() []byte {
+	for len(c.segs) > 0 {
+		if bufPtr := c.segs[0].buf.Load(); bufPtr != nil && c.off < len(*bufPtr) {
+			return (*bufPtr)[c.off:]
+		}
+		c.dropHead()
+	}
+	return nil
+}
+
+// gather copies the next len(dst) unread bytes into dst without consuming them
+func (c *BufferChain) gather(dst []byte) {
+	offset := 0
+	for _, seg := range c.Segments() {
+		if offset == len(dst) {
+			return
+		}
+		offset += copy(dst[offset:], seg)
+	}
+}
+
+// consume advances the read position by n bytes, at most Len
+func (c *BufferChain) consume(n int) {
+	for n > 0 {
+		head := c.head()
+		if n < len(head) {
+			c.off += n
+			c.length -= n
+			return
+		}
+		n -= len(head)
+		c.length -= len(head)
+		c.dropHead()
+	}
+}
+
+// dropHead releases the first segment
+func (c *BufferChain) dropHead() {
+	c.segs[0].Release()
+	c.segs[0] = nil
+	c.segs = c.segs[1:]
+	c.off = 0
+}
+
+// releasePeek returns the scratch buffer of the last Peek
+func (c *BufferChain) releasePeek() {
+	if c.peek != nil {
+		c.pool.Put(c.peek)
+		c.peek = nil
+	}
+}
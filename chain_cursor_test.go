@@ -0,0 +1,96 @@
+package bytepool
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func newTestChain(pool *BytePool, parts ...string) *BufferChain {
+	chain := pool.NewChain()
+	for _, s := range parts {
+		buf := pool.GetBuffer(len(s))
+		data, release := buf.Bytes()
+		copy(data, s)
+		release()
+		chain.Append(buf)
+	}
+	return chain
+}
+
+func TestBufferChain_Peek(t *testing.T) {
+	pool := NewPools([]int{16, 128})
+	chain := newTestChain(pool, "GET /", "index HTTP/1.1")
+
+	head, err := chain.Peek(3)
+	if err != nil || string(head) != "GET" {
+		t.Errorf("Expected GET, got %q (%v)", head, err)
+	}
+	across, err := chain.Peek(10)
+	if err != nil || string(across) != "GET /index" {
+		t.Errorf("Expected bytes across segments, got %q (%v)", across, err)
+	}
+	if chain.Len() != 19 {
+		t.Errorf("Expected Peek not to consume, got %d bytes left", chain.Len())
+	}
+	all, err := chain.Peek(32)
+	if !errors.Is(err, io.EOF) || string(all) != "GET /index HTTP/1.1" {
+		t.Errorf("Expected all bytes with io.EOF, got %q (%v)", all, err)
+	}
+
+	chain.Release()
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected all buffers released, got %d outstanding", pool.Outstanding())
+	}
+}
+
+func TestBufferChain_Discard(t *testing.T) {
+	pool := NewPools([]int{16, 128})
+	chain := newTestChain(pool, "abc", "defg", "hi")
+
+	if n, err := chain.Discard(5); n != 5 || err != nil {
+		t.Errorf("Expected 5 bytes discarded, got %d (%v)", n, err)
+	}
+	if segs := chain.Segments(); len(segs) != 2 || string(segs[0]) != "fg" {
+		t.Errorf("Expected consumed segment released, got %q", segs)
+	}
+	if pool.Outstanding() != 2 {
+		t.Errorf("Expected 2 outstanding segments, got %d", pool.Outstanding())
+	}
+	if n, err := chain.Discard(10); n != 4 || !errors.Is(err, io.EOF) {
+		t.Errorf("Expected 4 bytes discarded with io.EOF, got %d (%v)", n, err)
+	}
+	if chain.Len() != 0 || pool.Outstanding() != 0 {
+		t.Errorf("Expected empty chain, got %d bytes and %d outstanding", chain.Len(), pool.Outstanding())
+	}
+}
+
+func TestBufferChain_ReadN(t *testing.T) {
+	pool := NewPools([]int{16, 128})
+	chain := newTestChain(pool, "len:", "0005", "hello")
+
+	tag, releaseTag := chain.ReadN(4)
+	if string(tag) != "len:" {
+		t.Errorf("Expected len:, got %q", tag)
+	}
+	// the view keeps its segment alive after the chain moves past it
+	if pool.Outstanding() != 3 {
+		t.Errorf("Expected the read segment retained, got %d outstanding", pool.Outstanding())
+	}
+	releaseTag()
+
+	body, releaseBody := chain.ReadN(9)
+	if string(body) != "0005hello" {
+		t.Errorf("Expected bytes across segments, got %q", body)
+	}
+	releaseBody()
+
+	if missing, release := chain.ReadN(1); missing != nil {
+		t.Errorf("Expected nil past the end, got %q", missing)
+		release()
+	}
+	chain.Release()
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected all buffers released, got %d outstanding", pool.Outstanding())
+	}
+}
@@ -0,0 +1,113 @@
+package bytepool
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBytePool_GetChain(t *testing.T) {
+	pool := NewPools([]int{128, 1024})
+
+	chain := pool.GetChain(600)
+	if chain.Len() != 600 || len(chain.Segments()) != 1 {
+		t.Errorf("Expected one 600 byte segment, got %d bytes in %d segments", chain.Len(), len(chain.Segments()))
+	}
+	chain.Release()
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected chain released, got %d outstanding", pool.Outstanding())
+	}
+	if empty := pool.GetChain(0); empty.Len() != 0 {
+		t.Error("Expected empty chain for zero length")
+	}
+}
+
+func TestBytePool_GetChainDegrade(t *testing.T) {
+	pool := NewPools([]int{128, 256, 1024},
+		WithBackend(FreeListBackend(8)), WithDegradeToChain())
+
+	// the 1024 tier is empty while smaller tiers have idle buffers
+	if err := pool.Reserve(256, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.Reserve(128, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	chain := pool.GetChain(700)
+	segs := chain.Segments()
+	if chain.Len() != 700 {
+		t.Fatalf("Expected 700 bytes, got %d", chain.Len())
+	}
+	var lens []int
+	for _, seg := range segs {
+		lens = append(lens, len(seg))
+	}
+	// 256 + 256 + 128 from idle tiers, remaining 60 leased normally
+	if len(lens) != 4 || lens[0] != 256 || lens[1] != 256 || lens[2] != 128 || lens[3] != 60 {
+		t.Errorf("Unexpected segment lengths %v", lens)
+	}
+	if pool.Stats().Degraded != 1 {
+		t.Errorf("Expected 1 degraded get, got %d", pool.Stats().Degraded)
+	}
+
+	for i, seg := range segs {
+		for j := range seg {
+			seg[j] = byte(i)
+		}
+	}
+	var out bytes.Buffer
+	if n, err := chain.WriteTo(&out); err != nil || n != 700 {
+		t.Errorf("Expected to write 700 bytes, got %d (%v)", n, err)
+	}
+
+	chain.Release()
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected all segments released, got %d outstanding", pool.Outstanding())
+	}
+}
+
+func TestBufferChain_Finalize(t *testing.T) {
+	pool := NewPools([]int{128, 1024}, WithChainConsolidation(512))
+
+	chain := pool.NewChain()
+	for _, s := range []string{"header|", "payload|", "trailer"} {
+		buf := pool.GetBuffer(len(s))
+		data, release := buf.Bytes()
+		copy(data, s)
+		release()
+		chain.Append(buf)
+	}
+
+	if chain.Finalize() != chain {
+		t.Error("Expected Finalize to return the chain")
+	}
+	segs := chain.Segments()
+	if len(segs) != 1 || string(segs[0]) != "header|payload|trailer" {
+		t.Errorf("Expected one consolidated segment, got %q", segs)
+	}
+	report := pool.Stats()
+	if report.Consolidations != 1 || report.ConsolidatedBytes != 22 {
+		t.Errorf("Expected 1 consolidation of 22 bytes, got %d/%d", report.Consolidations, report.ConsolidatedBytes)
+	}
+	chain.Release()
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected all buffers released, got %d outstanding", pool.Outstanding())
+	}
+}
+
+func TestBufferChain_FinalizeAboveThreshold(t *testing.T) {
+	pool := NewPools([]int{128, 1024}, WithChainConsolidation(100))
+
+	chain := pool.NewChain()
+	chain.Append(pool.GetBuffer(100))
+	chain.Append(pool.GetBuffer(100))
+	chain.Finalize()
+
+	if len(chain.Segments()) != 2 {
+		t.Error("Expected chain above threshold to keep its segments")
+	}
+	if pool.Stats().Consolidations != 0 {
+		t.Error("Expected no consolidation")
+	}
+	chain.Release()
+}
@@ -0,0 +1,147 @@
+package bytepool
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time to all time-based pool features
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// afterClock is implemented by clocks that also drive timers, used for delays
+type afterClock interface {
+	After(d time.Duration) <-chan time.Time
+}
+
+// WithClock sets the clock used for hold-time tracking, idle reaping and windowed rates
+// Tests can inject a ManualClock, embedded systems a coarse ticker based clock
+func WithClock(clock Clock) Option {
+	return func(p *BytePool) {
+		p.clock = clock
+	}
+}
+
+// now returns the current time of the pool clock
+func (p *BytePool) now() time.Time {
+	return p.clock.Now()
+}
+
+// sleep waits for d on the pool clock, returning early with the context error
+func (p *BytePool) sleep(ctx context.Context, d time.Duration) error {
+	var ch <-chan time.Time
+	if c, ok := p.clock.(afterClock); ok {
+		ch = c.After(d)
+	} else {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		ch = timer.C
+	}
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// afterFunc calls f in its own goroutine once d has passed on the pool clock and returns
+// a function cancelling the call, which may be used at most once
+func (p *BytePool) afterFunc(d time.Duration, f func()) (stop func()) {
+	c, ok := p.clock.(afterClock)
+	if !ok {
+		timer := time.AfterFunc(d, f)
+		return func() { timer.Stop() }
+	}
+	ch, cancel := c.After(d), make(chan struct{})
+	go func() {
+		select {
+		case <-ch:
+			f()
+		case <-cancel:
+		}
+	}()
+	return func() { close(cancel) }
+}
+
+// ManualClock is a Clock that only moves when advanced, for deterministic tests
+type ManualClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []manualWaiter
+}
+
+type manualWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// NewManualClock creates a manual clock starting at start
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+// Now returns the current manual time
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel that receives the time once the clock has advanced by d
+func (c *ManualClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, manualWaiter{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance moves the clock forward by d
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setLocked(c.now.Add(d))
+}
+
+// Set moves the clock to t
+func (c *ManualClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setLocked(t)
+}
+
+// setLocked moves the clock and fires due waiters, caller holds the lock
+func (c *ManualClock) setLocked(t time.Time) {
+	c.now = t
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(t) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- t
+	}
+	clear(c.waiters[len(pending):])
+	c.waiters = pending
+}
+
+// Waiters returns the number of pending After channels, useful to synchronize tests
+func (c *ManualClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
@@ -0,0 +1,37 @@
+package bytepool
+
+import (
+	"testing"
+	"time"
+)
+
+func TestManualClock(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+
+	if !clock.Now().Equal(start) {
+		t.Errorf("Expected %v, got %v", start, clock.Now())
+	}
+	clock.Advance(time.Minute)
+	if got := clock.Now().Sub(start); got != time.Minute {
+		t.Errorf("Expected clock to advance 1m, got %v", got)
+	}
+	clock.Set(start)
+	if !clock.Now().Equal(start) {
+		t.Errorf("Expected %v after Set, got %v", start, clock.Now())
+	}
+}
+
+func TestWithClock(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+
+	pool := NewPools([]int{128}, WithClock(clock))
+	if !pool.now().Equal(start) {
+		t.Errorf("Expected pool to use injected clock, got %v", pool.now())
+	}
+
+	if _, ok := NewPools([]int{128}).clock.(systemClock); !ok {
+		t.Error("Expected system clock by default")
+	}
+}
@@ -0,0 +1,70 @@
+// Command gensizes generates a Go function returning bytepool tier sizes, for use with go:generate
+//
+//	//go:generate go run github.com/ixugo/bytepool/cmd/gensizes -name SizeVideo -min 1024 -max 4194304 -growth 1.5 -o size_video.go
+//	//go:generate go run github.com/ixugo/bytepool/cmd/gensizes -name SizeSmall -min 16 -max 4096 -jemalloc -o size_small.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/ixugo/bytepool"
+)
+
+func main() {
+	var (
+		name     = flag.String("name", "Sizes", "name of the generated function")
+		pkg      = flag.String("pkg", os.Getenv("GOPACKAGE"), "package of the generated file, defaults to $GOPACKAGE")
+		minSize  = flag.Int("min", 128, "smallest tier size")
+		maxSize  = flag.Int("max", 2097152, "largest tier size")
+		growth   = flag.Float64("growth", 2, "ratio between consecutive tiers")
+		jemalloc = flag.Bool("jemalloc", false, "use jemalloc style size classes instead of -growth")
+		out      = flag.String("o", "", "output file, stdout when empty")
+	)
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("gensizes: ")
+
+	if *pkg == "" {
+		log.Fatal("-pkg is required outside go:generate")
+	}
+	var sizes []int
+	desc := fmt.Sprintf("geometric sizes from %d to %d with growth %g", *minSize, *maxSize, *growth)
+	if *jemalloc {
+		sizes = bytepool.SizeJemalloc(*minSize, *maxSize)
+		desc = fmt.Sprintf("jemalloc style size classes from %d to %d", *minSize, *maxSize)
+	} else {
+		sizes = bytepool.GenerateSizes(*minSize, *maxSize, *growth)
+	}
+
+	src, err := render(*pkg, *name, desc, strings.Join(os.Args[1:], " "), sizes)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// render produces the formatted source of a function returning sizes
+func render(pkg, name, desc, args string, sizes []int) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gensizes %s; DO NOT EDIT.\n\n", args)
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	fmt.Fprintf(&b, "// %s returns %s\n", name, desc)
+	fmt.Fprintf(&b, "func %s() []int {\n\treturn []int{\n", name)
+	for _, size := range sizes {
+		fmt.Fprintf(&b, "\t\t%d,\n", size)
+	}
+	b.WriteString("\t}\n}\n")
+	return format.Source(b.Bytes())
+}
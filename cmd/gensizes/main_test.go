@@ -0,0 +1,19 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	src, err := render("media", "SizeVideo", "test sizes", "-name SizeVideo", []int{1024, 2048})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(src)
+	for _, want := range []string{"// Code generated by gensizes", "package media", "func SizeVideo() []int {", "1024,", "2048,"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in generated source:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,347 @@
+package bytepool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+)
+
+// PoolConfig is the complete set of tiers, limits, sampling rates and policies that
+// ApplyConfig swaps as a whole. Options set the initial configuration, Config returns
+// the one in effect. It can be declared in JSON or YAML config files, see NewFromConfig;
+// in JSON soft_delay accepts a duration string like "5ms"
+type PoolConfig struct {
+	Sizes              []int         `json:"sizes" yaml:"sizes"`
+	SoftBudget         int64         `json:"soft_budget,omitempty" yaml:"soft_budget,omitempty"` // leased bytes above which Gets are throttled, 0 disables
+	SoftDelay          time.Duration `json:"soft_delay,omitempty" yaml:"soft_delay,omitempty"`   // wait per throttling step
+	MinEfficiency      float64       `json:"min_efficiency,omitempty" yaml:"min_efficiency,omitempty"`
+	TrackerSampleEvery int           `json:"tracker_sample_every,omitempty" yaml:"tracker_sample_every,omitempty"` // record one in N Gets, 0 or 1 records all
+	LatencySampleEvery int           `json:"latency_sample_every,omitempty" yaml:"latency_sample_every,omitempty"` // time one in N Gets, 0 disables
+	TierFallback       int           `json:"tier_fallback,omitempty" yaml:"tier_fallback,omitempty"`               // larger tiers tried when a tier is empty
+	Hygiene            Hygiene       `json:"hygiene,omitempty" yaml:"hygiene,omitempty"`                           // policy of tiers outside WithHygieneForRange ranges
+	PoisonByte         byte          `json:"poison_byte,omitempty" yaml:"poison_byte,omitempty"`                   // pattern used by HygienePoison
+	MaxGetLength       int           `json:"max_get_length,omitempty" yaml:"max_get_length,omitempty"`             // longer Gets are refused, 0 allows any length
+}
+
+// Option returns an option that sets the whole initial configuration to c
+// Options after it adjust single fields, e.g. NewPools(nil, cfg.Option(), WithTierFallback(1))
+func (c PoolConfig) Option() Option {
+	return func(p *BytePool) {
+		p.initial = c
+		p.initial.Sizes = slices.Clone(c.Sizes)
+	}
+}
+
+// NewFromConfig creates a pool from a declarative configuration, followed by opts for
+// settings that are not part of PoolConfig such as labels, backends or the clock
+// Returns the Validate error listing all problems instead of panicking
+func NewFromConfig(cfg PoolConfig, opts ...Option) (*BytePool, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return NewPools(nil, append([]Option{cfg.Option()}, opts...)...), nil
+}
+
+// MarshalJSON writes soft_delay as a duration string
+func (c PoolConfig) MarshalJSON() ([]byte, error) {
+	type plain PoolConfig
+	aux := struct {
+		plain
+		SoftDelay string `json:"soft_delay,omitempty"`
+	}{plain: plain(c)}
+	if c.SoftDelay != 0 {
+		aux.SoftDelay = c.SoftDelay.String()
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON reads soft_delay as a duration string or as nanoseconds
+func (c *PoolConfig) UnmarshalJSON(data []byte) error {
+	type plain PoolConfig
+	aux := struct {
+		*plain
+		SoftDelay json.RawMessage `json:"soft_delay,omitempty"`
+	}{plain: (*plain)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if len(aux.SoftDelay) == 0 {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(aux.SoftDelay, &s); err == nil {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("%w: soft_delay: %w", ErrInvalidConfig, err)
+		}
+		c.SoftDelay = d
+		return nil
+	}
+	var ns int64
+	if err := json.Unmarshal(aux.SoftDelay, &ns); err != nil {
+		return fmt.Errorf("%w: soft_delay must be a duration string or nanoseconds", ErrInvalidConfig)
+	}
+	c.SoftDelay = time.Duration(ns)
+	return nil
+}
+
+// Validate reports all problems of the configuration at once, each wrapping ErrInvalidConfig
+func (c PoolConfig) Validate() error {
+	var errs []error
+	invalid := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%w: "+format, append([]any{ErrInvalidConfig}, args...)...))
+	}
+	if len(c.Sizes) == 0 {
+		invalid("sizes is empty")
+	}
+	for _, size := range c.Sizes {
+		if size <= 0 {
+			invalid("size %d must be positive", size)
+		}
+	}
+	if c.SoftBudget < 0 {
+		invalid("soft budget %d must not be negative", c.SoftBudget)
+	}
+	if c.SoftBudget > 0 && c.SoftDelay <= 0 {
+		invalid("soft delay %v must be positive with a soft budget", c.SoftDelay)
+	}
+	if c.MinEfficiency < 0 || c.MinEfficiency > 1 {
+		invalid("min efficiency %v must be within [0, 1]", c.MinEfficiency)
+	}
+	if c.TrackerSampleEvery < 0 {
+		invalid("tracker sample rate %d must not be negative", c.TrackerSampleEvery)
+	}
+	if c.LatencySampleEvery < 0 {
+		invalid("latency sample rate %d must not be negative", c.LatencySampleEvery)
+	}
+	if c.TierFallback < 0 {
+		invalid("tier fallback %d must not be negative", c.TierFallback)
+	}
+	if c.MaxGetLength < 0 {
+		invalid("max get length %d must not be negative", c.MaxGetLength)
+	}
+	if c.Hygiene < HygieneNone || c.Hygiene > HygieneSensitive {
+		invalid("unknown hygiene %d", c.Hygiene)
+	}
+	return errors.Join(errs...)
+}
+
+// ConfigChange is one field that differs between two configurations
+type ConfigChange struct {
+	Field string
+	Old   any
+	New   any
+}
+
+// diffConfig lists the fields that differ between old and new
+func diffConfig(old, new PoolConfig) []ConfigChange {
+	var changes []ConfigChange
+	ov, nv := reflect.ValueOf(old), reflect.ValueOf(new)
+	for i := range ov.NumField() {
+		o, n := ov.Field(i).Interface(), nv.Field(i).Interface()
+		if !reflect.DeepEqual(o, n) {
+			changes = append(changes, ConfigChange{Field: ov.Type().Field(i).Name, Old: o, New: n})
+		}
+	}
+	return changes
+}
+
+// poolState is the tier layout and tunables in effect, replaced as a whole by ApplyConfig
+// so Get and Put never observe a half applied configuration
+// Tiers live in a slice ordered by size, so the hot path resolves a tier by position
+// instead of hashing its size into maps
+type poolState struct {
+	cfg     PoolConfig
+	sizes   []int // sorted, without duplicates
+	maxSize int
+	tiers   []tierState        // parallel to sizes
+	retired map[int]*PoolStats // tiers removed by ApplyConfig, their leased buffers are still counted on Put
+}
+
+// tierState holds everything the hot path needs about one tier
+type tierState struct {
+	size       int
+	store      Store
+	inline     *inlineStore // store as its concrete type when it is inline, for the hot path
+	stats      *PoolStats
+	hygiene    Hygiene       // resolved policy
+	latency    *tierLatency  // nil unless latency sampling is enabled
+	gcRotation *gcRotation   // nil unless WithGCRotationMitigation applies to the store
+	sentinel   *tierSentinel // nil unless WithGCSentinels applies to the store
+}
+
+// tierIndex returns the position of the smallest tier fitting length, len(st.tiers) if none fits
+func (st *poolState) tierIndex(length int) int {
+	for i, size := range st.sizes {
+		if size >= length {
+			return i
+		}
+	}
+	return len(st.sizes)
+}
+
+// tier returns the tier of exactly size bytes, nil if size is not a tier
+func (st *poolState) tier(size int) *tierState {
+	if i := st.tierIndex(size); i < len(st.tiers) && st.tiers[i].size == size {
+		return &st.tiers[i]
+	}
+	return nil
+}
+
+// get returns an idle buffer at full capacity, nil when the store is empty
+func (t *tierState) get() []byte {
+	if t.inline != nil {
+		return t.inline.take()
+	}
+	if bufPtr := t.store.Get(); bufPtr != nil {
+		return *bufPtr
+	}
+	return nil
+}
+
+// put stores a buffer at full capacity
+func (t *tierState) put(buf []byte) {
+	if t.inline != nil {
+		t.inline.give(buf)
+		return
+	}
+	putStore(t.store, buf)
+}
+
+// putStore boxes buf for store, kept apart so only this path moves buf to the heap
+func putStore(store Store, buf []byte) {
+	store.Put(&buf)
+}
+
+// findBestSize finds the most suitable tier based on the required length
+func (st *poolState) findBestSize(length int) int {
+	if i := st.tierIndex(length); i < len(st.tiers) {
+		return st.tiers[i].size
+	}
+	return st.maxSize
+}
+
+// tierStat returns the counters of a current or retired tier, nil for neither
+func (st *poolState) tierStat(size int) *PoolStats {
+	if t := st.tier(size); t != nil {
+		return t.stats
+	}
+	return st.retired[size]
+}
+
+// buildState creates the state for cfg, keeping the stores, counters and latency
+// histograms of the tiers prev already has
+func (p *BytePool) buildState(cfg PoolConfig, prev *poolState) *poolState {
+	sizes := slices.Compact(slices.Sorted(slices.Values(cfg.Sizes)))
+	cfg.Sizes = slices.Clone(sizes)
+	st := &poolState{
+		cfg:     cfg,
+		sizes:   sizes,
+		maxSize: sizes[len(sizes)-1],
+		tiers:   make([]tierState, len(sizes)),
+		retired: make(map[int]*PoolStats),
+	}
+	if prev != nil {
+		maps.Copy(st.retired, prev.retired)
+		for _, t := range prev.tiers {
+			st.retired[t.size] = t.stats
+		}
+	}
+
+	for i, size := range sizes {
+		t := tierState{size: size, hygiene: p.hygieneFor(size, cfg.Hygiene)}
+		var old *tierState
+		if prev != nil {
+			old = prev.tier(size)
+		}
+		if old != nil {
+			t.store = old.store
+			t.latency = old.latency
+			t.gcRotation = old.gcRotation
+			t.sentinel = old.sentinel
+		} else {
+			backend := p.backendFor(size)
+			if p.fifoReuse {
+				backend = fifoBackend(backend)
+			}
+			if p.softCache != nil && i == len(sizes)-1 {
+				backend = p.softCache
+			}
+			if p.pinned[size] {
+				backend = pinnedBackend{}
+			}
+			t.store = p.newStore(backend, size)
+			if p.gcRotationKeep > 0 {
+				t.gcRotation = newGCRotation(t.store, p.gcRotationKeep)
+			}
+			if p.gcSentinels {
+				t.sentinel = newTierSentinel(t.store, size)
+			}
+		}
+		t.inline, _ = t.store.(*inlineStore)
+		if stat, ok := st.retired[size]; ok {
+			t.stats = stat
+			delete(st.retired, size)
+		} else {
+			t.stats = &PoolStats{}
+			if p.batchedStats {
+				t.stats.shards = newStatShards()
+			}
+		}
+		if cfg.LatencySampleEvery <= 0 {
+			t.latency = nil
+		} else if t.latency == nil {
+			t.latency = newTierLatency()
+		}
+		st.tiers[i] = t
+	}
+	return st
+}
+
+// applyMu serializes ApplyConfig calls across pools, they are rare
+var applyMu sync.Mutex
+
+// ApplyConfig validates cfg and atomically replaces the tiers, limits, sampling rates and
+// policies in effect, so a configuration pushed by a config service can be applied
+// mid-traffic. Kept tiers keep their idle buffers and counters; buffers leased from a
+// removed tier are still counted when they are Put back, then dropped
+// Emits EventTierAdded and EventTierRemoved per changed tier and EventConfigApplied with
+// the changed fields. Returns the validation errors and leaves the pool untouched on error
+func (p *BytePool) ApplyConfig(cfg PoolConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	applyMu.Lock()
+	prev := p.state.Load()
+	st := p.buildState(cfg, prev)
+	p.state.Store(st)
+	applyMu.Unlock()
+
+	for _, size := range st.sizes {
+		if prev.tier(size) == nil {
+			p.emit(Event{Kind: EventTierAdded, Size: size})
+		}
+	}
+	for _, size := range prev.sizes {
+		if st.tier(size) == nil {
+			p.emit(Event{Kind: EventTierRemoved, Size: size})
+		}
+	}
+	if changes := diffConfig(prev.cfg, st.cfg); len(changes) > 0 {
+		p.emit(Event{Kind: EventConfigApplied, Changes: changes})
+	}
+	return nil
+}
+
+// Config returns the configuration in effect, including defaults, WithAutoTune
+// adjustments and the latest ApplyConfig
+func (p *BytePool) Config() PoolConfig {
+	cfg := p.state.Load().cfg
+	cfg.Sizes = slices.Clone(cfg.Sizes)
+	return cfg
+}
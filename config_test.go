@@ -0,0 +1,160 @@
+package bytepool
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBytePool_ApplyConfig(t *testing.T) {
+	var events []Event
+	pool := NewPools([]int{128, 256}, WithBackend(FreeListBackend(8)), WithEventHook(func(e Event) {
+		events = append(events, e)
+	}))
+	events = nil
+
+	_ = pool.Reserve(128, 2)
+	removed := pool.Get(200) // leased from a tier about to be removed
+
+	cfg := pool.Config()
+	cfg.Sizes = []int{512, 128}
+	cfg.SoftBudget = 4096
+	cfg.SoftDelay = time.Millisecond
+	if err := pool.ApplyConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	got := pool.Config()
+	if !slices.Equal(got.Sizes, []int{128, 512}) || got.SoftBudget != 4096 {
+		t.Errorf("Expected new config in effect, got %+v", got)
+	}
+	if tier := pool.Stats().Tiers[0]; tier.Idle != 2 {
+		t.Errorf("Expected kept tier to keep its idle buffers, got %d", tier.Idle)
+	}
+
+	// the removed tier's buffer is still counted on Put
+	pool.Put(removed)
+	if pool.Outstanding() != 0 || pool.InUseBytes() != 0 {
+		t.Errorf("Expected balanced pool after Put of a removed tier, got %d outstanding", pool.Outstanding())
+	}
+
+	var kinds []EventKind
+	for _, e := range events {
+		kinds = append(kinds, e.Kind)
+	}
+	if !slices.Equal(kinds, []EventKind{EventTierAdded, EventTierRemoved, EventConfigApplied}) {
+		t.Fatalf("Unexpected events %v", kinds)
+	}
+	var fields []string
+	for _, c := range events[2].Changes {
+		fields = append(fields, c.Field)
+	}
+	if !slices.Equal(fields, []string{"Sizes", "SoftBudget", "SoftDelay"}) {
+		t.Errorf("Unexpected changed fields %v", fields)
+	}
+}
+
+func TestBytePool_ApplyConfigInvalid(t *testing.T) {
+	pool := NewPools([]int{128})
+	err := pool.ApplyConfig(PoolConfig{Sizes: []int{-1}, SoftBudget: 100, MinEfficiency: 2})
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("Expected ErrInvalidConfig, got %v", err)
+	}
+	if n := len(err.(interface{ Unwrap() []error }).Unwrap()); n != 3 {
+		t.Errorf("Expected all 3 problems reported, got %d: %v", n, err)
+	}
+	if !slices.Equal(pool.GetAvailableSizes(), []int{128}) {
+		t.Error("Expected pool untouched after invalid config")
+	}
+}
+
+func TestBytePool_ApplyConfigConcurrent(t *testing.T) {
+	pool := NewPools([]int{128, 256})
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				pool.Put(pool.Get(i%300 + 1))
+			}
+		}()
+	}
+	for i := range 50 {
+		sizes := []int{128, 256}
+		if i%2 == 0 {
+			sizes = []int{64, 256, 1024}
+		}
+		if err := pool.ApplyConfig(PoolConfig{Sizes: sizes}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wg.Wait()
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected balanced pool, got %d outstanding", pool.Outstanding())
+	}
+}
+
+func TestNewFromConfig(t *testing.T) {
+	data := []byte(`{
+		"sizes": [4096, 1024],
+		"soft_budget": 1048576,
+		"soft_delay": "5ms",
+		"tier_fallback": 1,
+		"hygiene": "zero"
+	}`)
+	var cfg PoolConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	pool, err := NewFromConfig(cfg, WithLabels(map[string]string{"service": "edge"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := pool.Config()
+	if !slices.Equal(got.Sizes, []int{1024, 4096}) || got.SoftDelay != 5*time.Millisecond ||
+		got.TierFallback != 1 || got.Hygiene != HygieneZero || pool.Labels()["service"] != "edge" {
+		t.Errorf("Unexpected config %+v", got)
+	}
+
+	// round trip keeps a readable delay
+	out, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), `"soft_delay":"5ms"`) || !strings.Contains(string(out), `"hygiene":"zero"`) {
+		t.Errorf("Unexpected JSON %s", out)
+	}
+	var back PoolConfig
+	if err := json.Unmarshal(out, &back); err != nil || !reflect.DeepEqual(back, got) {
+		t.Errorf("Expected round trip, got %+v, %v", back, err)
+	}
+}
+
+func TestNewFromConfig_Invalid(t *testing.T) {
+	_, err := NewFromConfig(PoolConfig{SoftBudget: -1, TierFallback: -1})
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("Expected ErrInvalidConfig, got %v", err)
+	}
+	for _, want := range []string{"sizes is empty", "soft budget", "tier fallback"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in %v", want, err)
+		}
+	}
+
+	var cfg PoolConfig
+	if err := json.Unmarshal([]byte(`{"sizes":[128],"hygiene":"bleach"}`), &cfg); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Expected unknown hygiene error, got %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"sizes":[128],"soft_delay":"soon"}`), &cfg); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Expected invalid delay error, got %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"sizes":[128],"soft_delay":1000}`), &cfg); err != nil || cfg.SoftDelay != time.Microsecond {
+		t.Errorf("Expected nanosecond delay, got %v, %v", cfg.SoftDelay, err)
+	}
+}
@@ -0,0 +1,103 @@
+package bytepool
+
+import (
+	"net"
+	"sync"
+)
+
+// PooledConn is a net.Conn whose reads go through a buffer leased from the pool
+// Reads shorter than the tier are served from the buffer, ReadBuffer hands out the
+// data without copying. The leased buffer returns to the pool on Close
+type PooledConn struct {
+	net.Conn
+	pool *BytePool
+	tier int
+
+	mu     sync.Mutex
+	raw    []byte // leased read buffer, nil until the first short Read
+	r, w   int    // unread data is raw[r:w]
+	closed bool
+}
+
+// WrapConn wraps c so its reads use pooled buffers of readTier bytes
+// Close closes c and returns the leased buffer, it may be called while a Read blocks
+func (p *BytePool) WrapConn(c net.Conn, readTier int) *PooledConn {
+	if readTier <= 0 {
+		panic("read tier must be positive")
+	}
+	return &PooledConn{Conn: c, pool: p, tier: p.clampLength(readTier)}
+}
+
+// Read reads into b, through the leased buffer when b is shorter than the tier
+func (c *PooledConn) Read(b []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return 0, net.ErrClosed
+	}
+	if c.r == c.w {
+		if len(b) >= c.tier {
+			return c.Conn.Read(b)
+		}
+		if c.raw == nil {
+			c.raw = c.pool.Get(c.tier)
+		}
+		n, err := c.Conn.Read(c.raw)
+		c.r, c.w = 0, n
+		if n == 0 {
+			return 0, err
+		}
+	}
+	n := copy(b, c.raw[c.r:c.w])
+	c.r += n
+	return n, nil
+}
+
+// ReadBuffer returns the next data read from the connection as a Buffer without
+// copying it, the caller releases it when done. Data left by earlier Reads comes first
+// Like Read it may return data together with an error, the Buffer is nil without data
+func (c *PooledConn) ReadBuffer() (*Buffer, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil, net.ErrClosed
+	}
+	if c.r < c.w {
+		// hand the leased buffer over, the next short Read leases a fresh one
+		raw := c.raw
+		buf := NewBuffer(raw[c.r:c.w], c.pool)
+		buf.release = func() { c.pool.Put(raw) }
+		c.raw, c.r, c.w = nil, 0, 0
+		return buf, nil
+	}
+
+	raw := c.pool.Get(c.tier)
+	n, err := c.Conn.Read(raw)
+	if n == 0 {
+		c.pool.Put(raw)
+		return nil, err
+	}
+	buf := NewBuffer(raw[:n], c.pool)
+	buf.release = func() { c.pool.Put(raw) }
+	return buf, err
+}
+
+// Close closes the connection and returns the leased read buffer to the pool
+// Buffers handed out by ReadBuffer stay valid until released
+func (c *PooledConn) Close() error {
+	// close first so a blocked Read returns and releases the lock
+	err := c.Conn.Close()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.closed {
+		c.closed = true
+		if c.raw != nil {
+			c.pool.Put(c.raw)
+			c.raw, c.r, c.w = nil, 0, 0
+		}
+	}
+	return err
+}
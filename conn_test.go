@@ -0,0 +1,83 @@
+package bytepool
+
+import (
+	"io"
+	"net"
+	"testing"
+)
+
+func TestBytePool_WrapConn(t *testing.T) {
+	pool := NewPools([]int{64, 1024})
+	client, server := net.Pipe()
+	conn := pool.WrapConn(server, 64)
+
+	go func() {
+		client.Write([]byte("hello world"))
+		client.Write([]byte("second"))
+		client.Close()
+	}()
+
+	small := make([]byte, 5)
+	if n, err := conn.Read(small); err != nil || string(small[:n]) != "hello" {
+		t.Errorf("Expected hello, got %q, %v", small[:n], err)
+	}
+	if pool.Outstanding() != 1 {
+		t.Errorf("Expected the read buffer leased, got %d outstanding", pool.Outstanding())
+	}
+
+	// the rest of the first read is handed over without copying
+	buf, err := conn.ReadBuffer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, release := buf.Bytes()
+	if string(data) != " world" {
+		t.Errorf("Expected buffered remainder, got %q", data)
+	}
+	release()
+	buf.Release()
+
+	buf, err = conn.ReadBuffer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, release = buf.Bytes()
+	if string(data) != "second" {
+		t.Errorf("Expected second, got %q", data)
+	}
+	release()
+	buf.Release()
+
+	if buf, err := conn.ReadBuffer(); buf != nil || err != io.EOF {
+		t.Errorf("Expected io.EOF, got %v, %v", buf, err)
+	}
+	if err := conn.Close(); err != nil {
+		t.Error(err)
+	}
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected all buffers returned, got %d outstanding", pool.Outstanding())
+	}
+	if _, err := conn.Read(small); err != net.ErrClosed {
+		t.Errorf("Expected net.ErrClosed, got %v", err)
+	}
+}
+
+func TestBytePool_WrapConnCloseDuringRead(t *testing.T) {
+	pool := NewPools([]int{64})
+	client, server := net.Pipe()
+	defer client.Close()
+	conn := pool.WrapConn(server, 64)
+
+	done := make(chan error)
+	go func() {
+		_, err := conn.Read(make([]byte, 8))
+		done <- err
+	}()
+	conn.Close()
+	if err := <-done; err == nil {
+		t.Error("Expected the blocked Read to fail")
+	}
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected the read buffer returned, got %d outstanding", pool.Outstanding())
+	}
+}
@@ -0,0 +1,133 @@
+package bytepool
+
+import (
+	"errors"
+	"io"
+)
+
+// copyBufferSize matches the buffer size io.Copy allocates internally
+const copyBufferSize = 32 * 1024
+
+// errInvalidWrite means a write returned an impossible count, as in io.Copy
+var errInvalidWrite = errors.New("bytepool: invalid write result")
+
+// copyLength returns the size of copy buffers, at most the largest tier so the buffer
+// is pooled
+func (p *BytePool) copyLength() int {
+	return p.clampLength(min(copyBufferSize, p.GetMax()))
+}
+
+// Copy copies from src to dst until EOF like io.Copy, using a pooled buffer
+// instead of allocating one per call
+func (p *BytePool) Copy(dst io.Writer, src io.Reader) (int64, error) {
+	buf := p.Get(p.copyLength())
+	defer p.Put(buf)
+
+	var written int64
+	for {
+		nr, rerr := src.Read(buf)
+		if nr > 0 {
+			nw, werr := dst.Write(buf[:nr])
+			if nw < 0 || nr < nw {
+				nw = 0
+				if werr == nil {
+					werr = errInvalidWrite
+				}
+			}
+			written += int64(nw)
+			if werr != nil {
+				return written, werr
+			}
+			if nr != nw {
+				return written, io.ErrShortWrite
+			}
+		}
+		if rerr != nil {
+			if rerr == io.EOF {
+				return written, nil
+			}
+			return written, rerr
+		}
+	}
+}
+
+// pooledReader implements io.WriterTo so io.Copy from it uses a pooled buffer
+type pooledReader struct {
+	io.Reader
+	pool *BytePool
+}
+
+// WriteTo copies the remaining data to w through a pooled buffer
+func (r *pooledReader) WriteTo(w io.Writer) (int64, error) {
+	return r.pool.Copy(w, r.Reader)
+}
+
+// Reader wraps r so that io.Copy(dst, wrapped) copies through a pooled buffer
+func (p *BytePool) Reader(r io.Reader) io.Reader {
+	return &pooledReader{Reader: r, pool: p}
+}
+
+// pooledWriter implements io.ReaderFrom so io.Copy into it uses a pooled buffer
+type pooledWriter struct {
+	io.Writer
+	pool *BytePool
+}
+
+// ReadFrom copies from r until EOF through a pooled buffer
+func (w *pooledWriter) ReadFrom(r io.Reader) (int64, error) {
+	return w.pool.Copy(w.Writer, r)
+}
+
+// Writer wraps w so that io.Copy(wrapped, src) copies through a pooled buffer
+func (p *BytePool) Writer(w io.Writer) io.Writer {
+	return &pooledWriter{Writer: w, pool: p}
+}
+
+// WriteString writes s to w without converting it to a []byte, which would allocate
+// Writers implementing io.StringWriter receive s directly, others get it in chunks
+// copied through a pooled buffer
+func (p *BytePool) WriteString(w io.Writer, s string) (int, error) {
+	if sw, ok := w.(io.StringWriter); ok {
+		return sw.WriteString(s)
+	}
+	if len(s) == 0 {
+		return w.Write(nil)
+	}
+	buf := p.Get(min(len(s), p.copyLength()))
+	defer p.Put(buf)
+
+	written := 0
+	for written < len(s) {
+		n := copy(buf, s[written:])
+		nw, err := w.Write(buf[:n])
+		if nw < 0 || nw > n {
+			return written, errInvalidWrite
+		}
+		written += nw
+		if err != nil {
+			return written, err
+		}
+		if nw != n {
+			return written, io.ErrShortWrite
+		}
+	}
+	return written, nil
+}
+
+// ReadString reads exactly n bytes from r into a pooled buffer and returns them as a
+// string, the only allocation being the string itself
+// It returns io.ErrUnexpectedEOF with the bytes read when r ends early, as io.ReadFull,
+// and ErrTooLong without reading when n is above WithMaxGetLength
+func (p *BytePool) ReadString(r io.Reader, n int) (string, error) {
+	if n <= 0 {
+		return "", nil
+	}
+	buf, err := p.GetE(n)
+	if err != nil {
+		return "", err
+	}
+	defer p.Put(buf)
+
+	nr, err := io.ReadFull(r, buf)
+	return string(buf[:nr]), err
+}
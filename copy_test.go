@@ -0,0 +1,135 @@
+package bytepool
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+var (
+	_ io.WriterTo   = (*pooledReader)(nil)
+	_ io.ReaderFrom = (*pooledWriter)(nil)
+)
+
+func TestBytePool_Copy(t *testing.T) {
+	pool := NewPools(SizePowerOfTwo())
+	payload := strings.Repeat("0123456789", 10000)
+
+	var dst bytes.Buffer
+	n, err := pool.Copy(&dst, iotest.HalfReader(strings.NewReader(payload)))
+	if err != nil || n != int64(len(payload)) || dst.String() != payload {
+		t.Errorf("Copy failed: n=%d err=%v", n, err)
+	}
+	if pool.Stats().TotalGet != 1 || pool.Outstanding() != 0 {
+		t.Errorf("Expected one pooled buffer used and returned, got %+v", pool.Stats())
+	}
+}
+
+func TestBytePool_CopySmallTiers(t *testing.T) {
+	pool := NewPools([]int{128, 1024})
+	payload := strings.Repeat("0123456789", 1000)
+
+	var dst bytes.Buffer
+	if n, err := pool.Copy(&dst, strings.NewReader(payload)); err != nil || n != int64(len(payload)) {
+		t.Errorf("Copy failed: n=%d err=%v", n, err)
+	}
+	dst.Reset()
+	if n, err := pool.WriteString(struct{ io.Writer }{&dst}, payload); err != nil || n != len(payload) {
+		t.Errorf("WriteString failed: n=%d err=%v", n, err)
+	}
+	report := pool.Stats()
+	if report.Discarded != 0 || report.Tiers[1].Get != 2 || report.Tiers[1].Put != 2 {
+		t.Errorf("Expected copy buffers from the largest tier, got %d discarded and %+v", report.Discarded, report.Tiers[1])
+	}
+}
+
+func TestBytePool_ReaderWriterWrappers(t *testing.T) {
+	pool := NewPools(SizePowerOfTwo())
+	payload := strings.Repeat("x", 100000)
+
+	// io.Copy picks WriterTo on the source
+	var dst bytes.Buffer
+	if _, err := io.Copy(onlyWriter{&dst}, pool.Reader(onlyReader{strings.NewReader(payload)})); err != nil {
+		t.Fatal(err)
+	}
+	// io.Copy picks ReaderFrom on the destination
+	var dst2 bytes.Buffer
+	if _, err := io.Copy(pool.Writer(onlyWriter{&dst2}), onlyReader{strings.NewReader(payload)}); err != nil {
+		t.Fatal(err)
+	}
+
+	if dst.String() != payload || dst2.String() != payload {
+		t.Error("Expected payload to be copied")
+	}
+	if got := pool.Stats().TotalGet; got != 2 {
+		t.Errorf("Expected both copies to use pooled buffers, got %d gets", got)
+	}
+}
+
+func TestBytePool_CopyReadError(t *testing.T) {
+	pool := NewPools(SizePowerOfTwo())
+	_, err := pool.Copy(io.Discard, iotest.ErrReader(io.ErrUnexpectedEOF))
+	if err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected read error, got %v", err)
+	}
+	if pool.Outstanding() != 0 {
+		t.Error("Expected buffer returned on error")
+	}
+}
+
+// onlyReader hides any io.WriterTo of the wrapped reader
+type onlyReader struct{ io.Reader }
+
+// onlyWriter hides any io.ReaderFrom of the wrapped writer
+type onlyWriter struct{ io.Writer }
+
+// plainWriter hides the io.StringWriter implementation of its embedded writer
+type plainWriter struct {
+	w io.Writer
+}
+
+func (p plainWriter) Write(b []byte) (int, error) {
+	return p.w.Write(b)
+}
+
+func TestBytePool_WriteString(t *testing.T) {
+	pool := NewPools([]int{1024, 32768})
+	s := strings.Repeat("0123456789", 5000) // longer than one chunk
+
+	var dst bytes.Buffer
+	if n, err := pool.WriteString(plainWriter{&dst}, s); n != len(s) || err != nil {
+		t.Fatalf("Expected %d bytes written, got %d, %v", len(s), n, err)
+	}
+	if dst.String() != s {
+		t.Error("Expected the string to arrive unchanged")
+	}
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected the chunk buffer returned, got %d outstanding", pool.Outstanding())
+	}
+
+	var sb strings.Builder
+	if n, err := pool.WriteString(&sb, "direct"); n != 6 || err != nil || sb.String() != "direct" {
+		t.Errorf("Expected direct string write, got %d %q, %v", n, sb.String(), err)
+	}
+	if got := pool.Stats().TotalGet; got != 1 {
+		t.Errorf("Expected string writers to skip the pool, got %d gets", got)
+	}
+}
+
+func TestBytePool_ReadString(t *testing.T) {
+	pool := NewPools([]int{1024})
+
+	s, err := pool.ReadString(strings.NewReader("hello world"), 5)
+	if s != "hello" || err != nil {
+		t.Errorf("Expected hello, got %q, %v", s, err)
+	}
+	s, err = pool.ReadString(strings.NewReader("hi"), 5)
+	if s != "hi" || err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected short read with io.ErrUnexpectedEOF, got %q, %v", s, err)
+	}
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected buffers returned, got %d outstanding", pool.Outstanding())
+	}
+}
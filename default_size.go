@@ -2,23 +2,7 @@ package bytepool
 
 // SizePowerOfTwo returns a sequence of powers of 2 from 7 to 21
 func SizePowerOfTwo() []int {
-	return []int{
-		128,     // 2^7
-		256,     // 2^8
-		512,     // 2^9
-		1024,    // 2^10
-		2048,    // 2^11
-		4096,    // 2^12
-		8192,    // 2^13
-		16384,   // 2^14
-		32768,   // 2^15
-		65536,   // 2^16
-		131072,  // 2^17
-		262144,  // 2^18
-		524288,  // 2^19
-		1048576, // 2^20
-		2097152, // 2^21
-	}
+	return GenerateSizes(128, 2097152, 2) // 2^7 ... 2^21
 }
 
 // SizeStream returns a sequence of sizes optimized for streaming
@@ -32,3 +16,61 @@ func SizeStream() []int {
 		131072,
 	}
 }
+
+// SizeRTP returns sizes for RTP/RTCP packets over UDP
+// Assumes an Ethernet MTU of 1500, so no packet exceeds 1500 bytes including the IP and
+// UDP headers; audio packets (e.g. 20ms Opus) fit 128 or 256, WebRTC video payloads are
+// capped near 1200. Jumbo frames are larger than the 1500 limit and not pooled
+func SizeRTP() []int {
+	return []int{
+		128,
+		256,
+		512,
+		1024,
+		1500, // Ethernet MTU
+	}
+}
+
+// SizeHLSSegments returns sizes for HLS media segments and their MPEG-TS chunks
+// Assumes segments of at most 6 seconds at up to about 10 Mbps, hence the 8MB limit;
+// the smallest tier holds 7 TS packets of 188 bytes, the usual UDP/TS payload
+func SizeHLSSegments() []int {
+	return []int{
+		1316, // 7 * 188 byte TS packets
+		65536,
+		262144,
+		1048576,
+		2097152,
+		4194304,
+		8388608, // 6s at ~10 Mbps
+	}
+}
+
+// SizeWebSocket returns sizes for WebSocket frames and messages
+// Control frames carry at most 125 bytes of payload plus a 14 byte header and fit 128 + 16;
+// 4096 matches the common default read and write buffer size. Messages above the 64KB
+// limit, a frequent read limit for servers, are not pooled
+func SizeWebSocket() []int {
+	return []int{
+		144, // largest control frame with header
+		512,
+		1024,
+		4096,
+		16384,
+		65536,
+	}
+}
+
+// SizeKafkaBatch returns sizes for Kafka record batches
+// Assumes the broker defaults: producers fill batches of batch.size 16384 bytes, and
+// message.max.bytes 1048588 bounds a single batch, which is the largest tier
+func SizeKafkaBatch() []int {
+	return []int{
+		1024,
+		4096,
+		16384, // batch.size
+		65536,
+		262144,
+		1048588, // message.max.bytes
+	}
+}
@@ -0,0 +1,29 @@
+package bytepool
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestProtocolSizes(t *testing.T) {
+	for name, sizes := range map[string][]int{
+		"rtp":       SizeRTP(),
+		"hls":       SizeHLSSegments(),
+		"websocket": SizeWebSocket(),
+		"kafka":     SizeKafkaBatch(),
+	} {
+		if len(sizes) == 0 || !slices.IsSorted(sizes) || len(slices.Compact(slices.Clone(sizes))) != len(sizes) {
+			t.Errorf("%s: Expected sorted unique sizes, got %v", name, sizes)
+		}
+		pool := NewPools(sizes)
+		max := sizes[len(sizes)-1]
+		buf := pool.Get(max)
+		if cap(buf) != max {
+			t.Errorf("%s: Expected largest tier %d to be pooled, got cap %d", name, max, cap(buf))
+		}
+		pool.Put(buf)
+	}
+	if got := SizeRTP()[len(SizeRTP())-1]; got != 1500 {
+		t.Errorf("Expected RTP limit at the MTU, got %d", got)
+	}
+}
@@ -0,0 +1,77 @@
+package bytepool
+
+import (
+	"strconv"
+	"sync/atomic"
+)
+
+// DiscardReason tells why a buffer bypassed or left the pool
+type DiscardReason int
+
+const (
+	// DiscardOversizeGet counts Gets longer than the largest tier, served by make
+	DiscardOversizeGet DiscardReason = iota
+	// DiscardOversizePut counts Puts of buffers larger than the largest tier
+	DiscardOversizePut
+	// DiscardTierMismatch counts Puts whose capacity matches no tier, e.g. buffers from
+	// another allocator or resliced with a smaller capacity
+	DiscardTierMismatch
+	// DiscardFrozen counts Puts dropped while the pool is frozen
+	DiscardFrozen
+
+	numDiscardReasons
+)
+
+// String returns the name of the reason as used in stats keys
+func (r DiscardReason) String() string {
+	switch r {
+	case DiscardOversizeGet:
+		return "oversize_get"
+	case DiscardOversizePut:
+		return "oversize_put"
+	case DiscardTierMismatch:
+		return "tier_mismatch"
+	case DiscardFrozen:
+		return "frozen"
+	default:
+		return "DiscardReason(" + strconv.Itoa(int(r)) + ")"
+	}
+}
+
+// DiscardStats breaks the discarded count down by reason
+type DiscardStats struct {
+	OversizeGet  int64 `json:"oversize_get"`
+	OversizePut  int64 `json:"oversize_put"`
+	TierMismatch int64 `json:"tier_mismatch"`
+	Frozen       int64 `json:"frozen"`
+}
+
+// paddedCounter owns a cache line so counters updated by different cores don't bounce it
+type paddedCounter struct {
+	n int64
+	_ cacheLinePad
+}
+
+// discard counts a dropped buffer of the given length or capacity
+func (p *BytePool) discard(reason DiscardReason, size int) {
+	atomic.AddInt64(&p.discardedCount, 1)
+	atomic.AddInt64(&p.discardReasons[reason].n, 1)
+	if p.listening() {
+		p.emit(Event{Kind: EventDiscarded, Size: size, Reason: reason})
+	}
+}
+
+// discarded loads the discard counter of reason, restored discards included
+func (p *BytePool) discarded(reason DiscardReason) int64 {
+	return loadCounter(&p.discardReasons[reason].n, p.restoredStats().Discards[reason.String()])
+}
+
+// discardStats loads the per reason discard counters
+func (p *BytePool) discardStats() DiscardStats {
+	return DiscardStats{
+		OversizeGet:  p.discarded(DiscardOversizeGet),
+		OversizePut:  p.discarded(DiscardOversizePut),
+		TierMismatch: p.discarded(DiscardTierMismatch),
+		Frozen:       p.discarded(DiscardFrozen),
+	}
+}
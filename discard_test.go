@@ -0,0 +1,37 @@
+package bytepool
+
+import "testing"
+
+func TestBytePool_DiscardReasons(t *testing.T) {
+	pool := NewPools([]int{128, 256})
+	var reasons []DiscardReason
+	events := pool.Events(8)
+
+	pool.Get(1000)
+	pool.Put(make([]byte, 1000))
+	pool.Put(make([]byte, 200))
+	pool.PutAs(make([]byte, 100), 64)
+	pool.Freeze()
+	pool.Put(pool.Get(100))
+	pool.Thaw()
+
+	report := pool.Stats()
+	want := DiscardStats{OversizeGet: 1, OversizePut: 1, TierMismatch: 2, Frozen: 1}
+	if report.Discards != want {
+		t.Errorf("Expected %+v, got %+v", want, report.Discards)
+	}
+	if report.Discarded != 5 {
+		t.Errorf("Expected the total to sum the reasons, got %d", report.Discarded)
+	}
+	if got := pool.GetPoolStats()["discarded_tier_mismatch"]; got != int64(2) {
+		t.Errorf("Expected discarded_tier_mismatch 2, got %v", got)
+	}
+
+	pool.StopEvents(events)
+	for e := range events {
+		reasons = append(reasons, e.Reason)
+	}
+	if len(reasons) != 5 || reasons[0] != DiscardOversizeGet || reasons[4] != DiscardFrozen {
+		t.Errorf("Expected discard events with reasons, got %v", reasons)
+	}
+}
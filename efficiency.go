@@ -0,0 +1,39 @@
+package bytepool
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// WithMinEfficiency counts Gets whose requested length divided by the tier size is below
+// ratio, e.g. Get(3) served from a 128 byte tier, surfacing allocations that should use a
+// smaller tier or no pool at all. In debug mode such Gets panic instead
+func WithMinEfficiency(ratio float64) Option {
+	return func(p *BytePool) {
+		p.initial.MinEfficiency = ratio
+	}
+}
+
+// WithDebug enables debug mode, turning misuse that is only counted in production into panics
+// Buffers leased in debug mode are watermarked, so a Put into a different pool panics
+func WithDebug() Option {
+	return func(p *BytePool) {
+		p.debug = true
+	}
+}
+
+// checkEfficiency records a Get of length served from the given tier size
+func (p *BytePool) checkEfficiency(length, size int, ratio float64) {
+	if float64(length) >= ratio*float64(size) {
+		return
+	}
+	atomic.AddInt64(&p.inefficientGets, 1)
+	if p.debug {
+		panic(fmt.Sprintf("bytepool: Get(%d) from tier %d is below min efficiency %.2f", length, size, ratio))
+	}
+}
+
+// GetInefficientCount returns the number of Gets below the minimum efficiency
+func (p *BytePool) GetInefficientCount() int64 {
+	return loadCounter(&p.inefficientGets, p.restoredStats().Inefficient)
+}
@@ -0,0 +1,33 @@
+package bytepool
+
+import "testing"
+
+func TestWithMinEfficiency(t *testing.T) {
+	pool := NewPools([]int{128, 1024}, WithMinEfficiency(0.25))
+
+	pool.Put(pool.Get(3))   // 3/128 below threshold
+	pool.Put(pool.Get(100)) // 100/128 fine
+	pool.Put(pool.Get(129)) // 129/1024 below threshold
+	pool.Put(pool.Get(4096))
+
+	if got := pool.GetInefficientCount(); got != 2 {
+		t.Errorf("Expected 2 inefficient gets, got %d", got)
+	}
+	if got := pool.Stats().Inefficient; got != 2 {
+		t.Errorf("Expected report to include 2 inefficient gets, got %d", got)
+	}
+	if got := pool.GetPoolStats()["inefficient_get"].(int64); got != 2 {
+		t.Errorf("Expected inefficient_get 2, got %d", got)
+	}
+}
+
+func TestWithMinEfficiencyDebug(t *testing.T) {
+	pool := NewPools([]int{128}, WithMinEfficiency(0.5), WithDebug())
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic in debug mode")
+		}
+	}()
+	pool.Get(3)
+}
@@ -0,0 +1,28 @@
+package bytepool
+
+import "errors"
+
+var (
+	// ErrInvalidTier is returned when a size does not match any configured tier
+	ErrInvalidTier = errors.New("bytepool: invalid tier")
+	// ErrInvalidCount is returned when a count is not positive
+	ErrInvalidCount = errors.New("bytepool: count must be positive")
+	// ErrOversize is returned when a length exceeds the largest tier
+	ErrOversize = errors.New("bytepool: length exceeds largest tier")
+	// ErrInvalidConfig is wrapped by every problem reported by PoolConfig.Validate
+	ErrInvalidConfig = errors.New("bytepool: invalid config")
+	// ErrSealed is returned when writing to a Buffer after Seal
+	ErrSealed = errors.New("bytepool: buffer is sealed")
+	// ErrReleased is returned when accessing a Buffer after its last reference was released
+	ErrReleased = errors.New("bytepool: buffer is released")
+	// ErrOutOfRange is returned when an offset or region lies outside a Buffer
+	ErrOutOfRange = errors.New("bytepool: offset out of range")
+	// ErrTooLong is returned when a Get length exceeds the limit set by WithMaxGetLength
+	ErrTooLong = errors.New("bytepool: length exceeds max get length")
+	// ErrSelfTestFailed is returned when RunSelfTest measured values below the requested minimum
+	ErrSelfTestFailed = errors.New("bytepool: self test failed")
+	// ErrMisuse wraps the misuse recorded under MisuseError, see MisuseErr
+	ErrMisuse = errors.New("bytepool: misuse")
+	// ErrNotTracked is returned by DumpOutstanding when leases are not tracked
+	ErrNotTracked = errors.New("bytepool: leases are not tracked")
+)
@@ -0,0 +1,130 @@
+package bytepool
+
+import (
+	"slices"
+	"sync/atomic"
+)
+
+// EventKind classifies pool lifecycle and threshold events
+type EventKind int
+
+const (
+	// EventPoolCreated is emitted once NewPools has built all tiers
+	EventPoolCreated EventKind = iota
+	// EventTierAdded is emitted for each tier the pool serves
+	EventTierAdded
+	// EventBudgetExceeded is emitted when a Get is first throttled by the soft budget
+	EventBudgetExceeded
+	// EventBudgetRecovered is emitted when leased memory drops back to the soft budget
+	EventBudgetRecovered
+	// EventLeakDetected is emitted when CheckLeaks finds buffers not returned to the pool
+	EventLeakDetected
+	// EventTierRemoved is emitted for each tier dropped by ApplyConfig
+	EventTierRemoved
+	// EventConfigApplied is emitted when ApplyConfig changed the configuration
+	EventConfigApplied
+	// EventDiscarded is emitted for every oversize Get and every Put the pool drops
+	EventDiscarded
+)
+
+// String returns the name of the event kind
+func (k EventKind) String() string {
+	switch k {
+	case EventPoolCreated:
+		return "pool_created"
+	case EventTierAdded:
+		return "tier_added"
+	case EventBudgetExceeded:
+		return "budget_exceeded"
+	case EventBudgetRecovered:
+		return "budget_recovered"
+	case EventLeakDetected:
+		return "leak_detected"
+	case EventTierRemoved:
+		return "tier_removed"
+	case EventConfigApplied:
+		return "config_applied"
+	case EventDiscarded:
+		return "discarded"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes a pool lifecycle or threshold event
+type Event struct {
+	Kind    EventKind
+	Pool    *BytePool
+	Size    int            // tier size for tier events, length or capacity for EventDiscarded
+	Count   int64          // leaked buffers for EventLeakDetected
+	Bytes   int64          // leased bytes for budget and leak events
+	Limit   int64          // soft budget for budget events
+	Changes []ConfigChange // changed fields for EventConfigApplied
+	Reason  DiscardReason  // why the buffer was dropped for EventDiscarded
+}
+
+// WithEventHook calls fn synchronously for every lifecycle and threshold event
+// Most events are rare, EventDiscarded fires per dropped buffer; fn runs on the Get
+// and Put paths and must not block
+// Multiple hooks are called in the order they were added
+func WithEventHook(fn func(Event)) Option {
+	return func(p *BytePool) {
+		if fn != nil {
+			p.hooks = append(p.hooks, fn)
+		}
+	}
+}
+
+// emit delivers e to all event hooks and subscribers
+func (p *BytePool) emit(e Event) {
+	e.Pool = p
+	for _, fn := range p.hooks {
+		fn(e)
+	}
+	if p.subsCount.Load() == 0 {
+		return
+	}
+	p.subsMu.RLock()
+	defer p.subsMu.RUnlock()
+	for _, ch := range p.subs {
+		select {
+		case ch <- e:
+		default:
+			atomic.AddInt64(&p.eventsDropped, 1)
+		}
+	}
+}
+
+// listening reports whether any hook or subscriber receives events
+func (p *BytePool) listening() bool {
+	return len(p.hooks) > 0 || p.subsCount.Load() > 0
+}
+
+// Events returns a channel streaming the pool events, e.g. for a live dashboard
+// Events never block the pool: when the channel buffer is full they are dropped and
+// counted in Report.EventsDropped. StopEvents unsubscribes and closes the channel
+func (p *BytePool) Events(buffer int) <-chan Event {
+	if buffer < 0 {
+		panic("events buffer must not be negative")
+	}
+	ch := make(chan Event, buffer)
+	p.subsMu.Lock()
+	p.subs = append(p.subs, ch)
+	p.subsCount.Store(int32(len(p.subs)))
+	p.subsMu.Unlock()
+	return ch
+}
+
+// StopEvents unsubscribes a channel returned by Events and closes it
+func (p *BytePool) StopEvents(ch <-chan Event) {
+	p.subsMu.Lock()
+	defer p.subsMu.Unlock()
+	for i, sub := range p.subs {
+		if sub == ch {
+			p.subs = slices.Delete(p.subs, i, i+1)
+			p.subsCount.Store(int32(len(p.subs)))
+			close(sub)
+			return
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package bytepool
+
+import (
+	"testing"
+)
+
+func TestWithEventHook(t *testing.T) {
+	var kinds []EventKind
+	pool := NewPools([]int{128, 256}, WithEventHook(func(e Event) {
+		if e.Pool == nil {
+			t.Error("Expected event to carry the pool")
+		}
+		kinds = append(kinds, e.Kind)
+	}))
+
+	pool.Get(100)
+	pool.CheckLeaks(0)
+
+	want := []EventKind{EventTierAdded, EventTierAdded, EventPoolCreated, EventLeakDetected}
+	if len(kinds) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, kinds)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Errorf("Expected %v at %d, got %v", want[i], i, kinds[i])
+		}
+	}
+}
+
+func TestEventKind_String(t *testing.T) {
+	if EventBudgetExceeded.String() != "budget_exceeded" || EventKind(99).String() != "unknown" {
+		t.Error("Unexpected event kind names")
+	}
+}
+
+func TestBytePool_Events(t *testing.T) {
+	pool := NewPools([]int{128})
+	events := pool.Events(4)
+
+	pool.Get(1000)
+	pool.Put(make([]byte, 2048))
+	pool.Put(make([]byte, 4096))
+	pool.Put(make([]byte, 4096))
+	pool.Put(make([]byte, 4096)) // dropped, the channel is full
+
+	want := []int{1000, 2048, 4096, 4096}
+	for _, size := range want {
+		e := <-events
+		if e.Kind != EventDiscarded || e.Size != size || e.Pool != pool {
+			t.Errorf("Expected discard of %d, got %v %d", size, e.Kind, e.Size)
+		}
+	}
+	if got := pool.Stats().EventsDropped; got != 1 {
+		t.Errorf("Expected 1 dropped event, got %d", got)
+	}
+
+	pool.StopEvents(events)
+	if _, ok := <-events; ok {
+		t.Error("Expected the channel to be closed")
+	}
+	pool.Get(1000) // no subscriber left
+	if got := pool.Stats().EventsDropped; got != 1 {
+		t.Errorf("Expected no more dropped events, got %d", got)
+	}
+}
@@ -0,0 +1,24 @@
+package bytepool
+
+import "sync/atomic"
+
+// WithTierFallback lets a Get whose tier is empty take an idle buffer from up to levels
+// larger tiers before allocating, trimmed to the requested length
+// It trades some memory waste for fewer allocations while message sizes shift during a
+// burst. The buffer returns to the larger tier on Put, which also holds its statistics
+func WithTierFallback(levels int) Option {
+	return func(p *BytePool) {
+		p.initial.TierFallback = max(levels, 0)
+	}
+}
+
+// takeLarger takes an idle buffer from one of the next larger tiers, nil if all are empty
+func (p *BytePool) takeLarger(st *poolState, i, length int) []byte {
+	for _, larger := range st.tiers[i+1 : min(i+1+st.cfg.TierFallback, len(st.tiers))] {
+		if buf := larger.get(); buf != nil {
+			atomic.AddInt64(&p.tierFallbacks, 1)
+			return buf[:length]
+		}
+	}
+	return nil
+}
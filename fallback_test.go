@@ -0,0 +1,43 @@
+package bytepool
+
+import (
+	"testing"
+)
+
+func TestWithTierFallback(t *testing.T) {
+	pool := NewPools([]int{128, 256, 512, 1024}, WithBackend(FreeListBackend(8)), WithTierFallback(2))
+
+	// only the 512 tier has an idle buffer
+	if err := pool.Reserve(512, 1); err != nil {
+		t.Fatal(err)
+	}
+	buf := pool.Get(100)
+	if len(buf) != 100 || cap(buf) != 512 {
+		t.Errorf("Expected 100 bytes from the 512 tier, got len %d cap %d", len(buf), cap(buf))
+	}
+	r := pool.Stats()
+	if r.TierFallbacks != 1 || r.Tiers[2].Get != 1 || r.Tiers[0].Get != 0 {
+		t.Errorf("Expected the Get counted on the 512 tier, got %+v", r)
+	}
+	pool.Put(buf)
+	if pool.Outstanding() != 0 || pool.Stats().Tiers[2].Idle != 1 {
+		t.Error("Expected buffer back in the 512 tier")
+	}
+
+	// 1024 is three levels above 128, beyond the fallback range
+	pool.Get(512)
+	if err := pool.Reserve(1024, 1); err != nil {
+		t.Fatal(err)
+	}
+	if buf := pool.Get(100); cap(buf) != 128 {
+		t.Errorf("Expected fresh allocation beyond the fallback range, got cap %d", cap(buf))
+	}
+}
+
+func TestWithTierFallback_Disabled(t *testing.T) {
+	pool := NewPools([]int{128, 256}, WithBackend(FreeListBackend(8)))
+	_ = pool.Reserve(256, 1)
+	if buf := pool.Get(100); cap(buf) != 128 {
+		t.Errorf("Expected exact tier without fallback, got cap %d", cap(buf))
+	}
+}
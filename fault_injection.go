@@ -0,0 +1,15 @@
+package bytepool
+
+// WithFaultInjection calls hook before every Get with OpGet and the requested length, and
+// before every Put with OpPut and the buffer capacity, so tests can exercise the fallback
+// paths of an application without reaching into the pool
+// A Get fault of ErrTooLong refuses the Get like WithMaxGetLength, ErrOversize serves it
+// outside the pool like an oversize length, and any other error makes the stores look
+// exhausted so a fresh buffer is allocated. GetContext returns the fault instead
+// A Put fault drops the buffer instead of pooling it, as a full store would
+// A hook that sleeps before returning nil simulates slow allocation. Not meant for production
+func WithFaultInjection(hook func(op OpKind, size int) error) Option {
+	return func(p *BytePool) {
+		p.faults = hook
+	}
+}
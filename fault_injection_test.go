@@ -0,0 +1,67 @@
+package bytepool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"unsafe"
+)
+
+func TestBytePool_FaultInjection(t *testing.T) {
+	errExhausted := errors.New("exhausted")
+	var fault error
+	var ops []OpKind
+	pool := NewPools([]int{128}, WithFaultInjection(func(op OpKind, size int) error {
+		ops = append(ops, op)
+		if op == OpGet {
+			return fault
+		}
+		return nil
+	}))
+
+	idle := pool.Get(100)
+	pool.Put(idle)
+
+	fault = errExhausted
+	buf := pool.Get(100)
+	if buf == nil || unsafe.SliceData(buf) == unsafe.SliceData(idle) || cap(buf) != 128 {
+		t.Error("Expected a fresh tier buffer while exhausted")
+	}
+	pool.Put(buf)
+
+	fault = ErrTooLong
+	if buf := pool.Get(100); buf != nil {
+		t.Errorf("Expected a refused Get, got %d bytes", len(buf))
+	}
+	fault = ErrOversize
+	if buf := pool.Get(100); cap(buf) != 100 {
+		t.Errorf("Expected an unpooled buffer, got capacity %d", cap(buf))
+	}
+	fault = errExhausted
+	if _, err := pool.GetContext(context.Background(), 100); err != errExhausted {
+		t.Errorf("Expected the fault from GetContext, got %v", err)
+	}
+
+	report := pool.Stats()
+	if report.Refused != 1 || report.Discards.OversizeGet != 1 || report.Misuses.Oversize != 0 {
+		t.Errorf("Expected one refusal and one oversize Get without misuse, got %+v", report)
+	}
+	if len(ops) != 7 {
+		t.Errorf("Expected 7 hook calls, got %v", ops)
+	}
+}
+
+func TestBytePool_FaultInjectionPut(t *testing.T) {
+	pool := NewPools([]int{128}, WithBackend(FreeListBackend(4)), WithFaultInjection(func(op OpKind, size int) error {
+		if op == OpPut {
+			return errors.New("store full")
+		}
+		return nil
+	}))
+
+	pool.Put(pool.Get(100))
+	report := pool.Stats()
+	if report.TotalPut != 1 || report.Tiers[0].Idle != 0 {
+		t.Errorf("Expected the Put counted and the buffer dropped, got %d puts and %d idle", report.TotalPut, report.Tiers[0].Idle)
+	}
+}
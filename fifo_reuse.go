@@ -0,0 +1,25 @@
+package bytepool
+
+// defaultFIFOIdle bounds the free list of tiers switched to FIFO from a non free list backend
+const defaultFIFOIdle = 256
+
+// WithFIFOReuse hands out idle buffers in the order they were returned, so a released
+// buffer waits as long as possible before it is leased again. Combined with WithZeroOnPut
+// or WithPoisonOnPut in staging, code still using a buffer after release reads cleared or
+// poisoned data instead of silently sharing it with the next owner
+// Free list backends keep their bound and switch to EvictFIFO, tiers on other backends
+// get a FIFO free list of 256 buffers. WithSoftCache and WithPinnedTiers take precedence
+func WithFIFOReuse() Option {
+	return func(p *BytePool) {
+		p.fifoReuse = true
+	}
+}
+
+// fifoBackend returns b with FIFO reuse order
+func fifoBackend(b Backend) Backend {
+	if fl, ok := b.(freeListBackend); ok {
+		fl.policy = EvictFIFO
+		return fl
+	}
+	return freeListBackend{maxIdle: defaultFIFOIdle, policy: EvictFIFO}
+}
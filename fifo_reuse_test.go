@@ -0,0 +1,31 @@
+package bytepool
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestBytePool_FIFOReuse(t *testing.T) {
+	pool := NewPools([]int{128, 1024}, WithFIFOReuse(), WithBackendForRange(1024, 1024, FreeListBackend(2)))
+
+	bufs := [][]byte{pool.Get(100), pool.Get(100), pool.Get(100)}
+	for _, buf := range bufs {
+		pool.Put(buf)
+	}
+	for i, want := range bufs {
+		got := pool.Get(100)
+		if unsafe.SliceData(got) != unsafe.SliceData(want) {
+			t.Errorf("Expected buffer %d reused in return order", i)
+		}
+	}
+
+	st := pool.state.Load()
+	small := st.tier(128).store.(*freeListStore)
+	if len(small.items) != defaultFIFOIdle || small.policy != EvictFIFO {
+		t.Errorf("Expected default FIFO free list, got %d items with %s", len(small.items), small.policy)
+	}
+	large := st.tier(1024).store.(*freeListStore)
+	if len(large.items) != 2 || large.policy != EvictFIFO {
+		t.Errorf("Expected the free list bound kept, got %d items with %s", len(large.items), large.policy)
+	}
+}
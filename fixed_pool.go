@@ -0,0 +1,69 @@
+package bytepool
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// FixedPool pools byte arrays of a fixed length, A is the array type, e.g. [1500]byte
+// for MTU sized packet reads. Array pointers are stored without the *[]byte boxing the
+// tiered pool needs, so Get and Put never allocate once the pool is warm
+type FixedPool[A any] struct {
+	p        sync.Pool
+	size     int
+	get, put atomic.Int64
+}
+
+// NewFixedPool creates a pool of A, which must be a byte array type
+func NewFixedPool[A any]() *FixedPool[A] {
+	t := reflect.TypeFor[A]()
+	if t.Kind() != reflect.Array || t.Elem().Kind() != reflect.Uint8 {
+		panic("fixed pool element must be a byte array, got " + t.String())
+	}
+	return &FixedPool[A]{size: t.Len()}
+}
+
+// Get returns an array from the pool, a new zeroed one when the pool is empty
+// The content of a reused array is whatever its previous holder left
+func (f *FixedPool[A]) Get() *A {
+	f.get.Add(1)
+	if v := f.p.Get(); v != nil {
+		return v.(*A)
+	}
+	return new(A)
+}
+
+// Put returns an array to the pool, nil is ignored
+func (f *FixedPool[A]) Put(a *A) {
+	if a == nil {
+		return
+	}
+	f.put.Add(1)
+	f.p.Put(a)
+}
+
+// Size returns the array length
+func (f *FixedPool[A]) Size() int {
+	return f.size
+}
+
+// Outstanding returns the number of arrays currently leased
+func (f *FixedPool[A]) Outstanding() int64 {
+	return f.get.Load() - f.put.Load()
+}
+
+// Bytes returns a slice over the whole array without copying
+func (f *FixedPool[A]) Bytes(a *A) []byte {
+	return unsafe.Slice((*byte)(unsafe.Pointer(a)), f.size)
+}
+
+// FromBytes returns the array backing buf, which must come from Bytes of this pool
+// It panics when the capacity of buf does not match the array length
+func (f *FixedPool[A]) FromBytes(buf []byte) *A {
+	if cap(buf) != f.size {
+		panic("buffer capacity does not match the fixed pool size")
+	}
+	return (*A)(unsafe.Pointer(unsafe.SliceData(buf)))
+}
@@ -0,0 +1,64 @@
+package bytepool
+
+import "testing"
+
+func TestFixedPool(t *testing.T) {
+	pool := NewFixedPool[[1500]byte]()
+	if pool.Size() != 1500 {
+		t.Errorf("Expected size 1500, got %d", pool.Size())
+	}
+
+	a := pool.Get()
+	buf := pool.Bytes(a)
+	if len(buf) != 1500 || &buf[0] != &a[0] {
+		t.Fatal("Expected Bytes to alias the array")
+	}
+	n := copy(buf, "packet")
+	if got := pool.FromBytes(buf[:n]); got != a {
+		t.Error("Expected FromBytes to return the backing array")
+	}
+	if pool.Outstanding() != 1 {
+		t.Errorf("Expected 1 outstanding, got %d", pool.Outstanding())
+	}
+	pool.Put(a)
+	pool.Put(nil)
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected 0 outstanding, got %d", pool.Outstanding())
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		pool.Put(pool.Get())
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, got %v", allocs)
+	}
+}
+
+func TestFixedPool_Invalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for a non byte array type")
+		}
+	}()
+	NewFixedPool[[4]int]()
+}
+
+func TestFixedPool_FromBytesMismatch(t *testing.T) {
+	pool := NewFixedPool[[64]byte]()
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for a foreign buffer")
+		}
+	}()
+	pool.FromBytes(make([]byte, 10))
+}
+
+// BenchmarkFixedPool 测试定长数组池的 Get/Put 性能
+func BenchmarkFixedPool(b *testing.B) {
+	pool := NewFixedPool[[1500]byte]()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pool.Put(pool.Get())
+	}
+}
@@ -0,0 +1,33 @@
+package bytepool
+
+// GetFramed leases a Buffer holding headerLen header bytes followed by payloadLen payload
+// bytes in one contiguous region, and returns it with the payload view
+// Encoders of length-prefixed protocols write the payload first, then call Finalize to
+// fill in the header and obtain the wire view without copying the payload
+// Returns nil, nil when the pool refuses the frame length, see WithMaxGetLength
+func (p *BytePool) GetFramed(payloadLen, headerLen int) (*Buffer, []byte) {
+	if headerLen < 0 {
+		panic("header length must not be negative")
+	}
+	buf := p.GetBuffer(headerLen + payloadLen)
+	data := *buf.buf.Load()
+	if len(data) < headerLen {
+		buf.Release()
+		return nil, nil
+	}
+	buf.header = headerLen
+	return buf, data[headerLen:]
+}
+
+// Finalize passes the header region to writeHeader and returns the wire view, the header
+// followed by the payload. The view is valid until the buffer is released
+// For buffers not created by GetFramed the header is empty and the view is the whole data
+func (b *Buffer) Finalize(writeHeader func(hdr []byte)) []byte {
+	bufPtr := b.buf.Load()
+	if bufPtr == nil {
+		return nil
+	}
+	data := *bufPtr
+	writeHeader(data[:b.header:b.header])
+	return data
+}
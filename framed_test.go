@@ -0,0 +1,52 @@
+package bytepool
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestBytePool_GetFramed(t *testing.T) {
+	pool := NewPools([]int{128})
+
+	buf, payload := pool.GetFramed(10, 4)
+	if len(payload) != 10 {
+		t.Fatalf("Expected payload length 10, got %d", len(payload))
+	}
+	copy(payload, "0123456789")
+
+	wire := buf.Finalize(func(hdr []byte) {
+		binary.BigEndian.PutUint32(hdr, uint32(len(payload)))
+	})
+	if len(wire) != 14 {
+		t.Fatalf("Expected wire length 14, got %d", len(wire))
+	}
+	if n := binary.BigEndian.Uint32(wire); n != 10 {
+		t.Errorf("Expected length prefix 10, got %d", n)
+	}
+	if string(wire[4:]) != "0123456789" {
+		t.Errorf("Expected payload after header, got %q", wire[4:])
+	}
+	if &wire[4] != &payload[0] {
+		t.Error("Expected wire view to share the payload memory")
+	}
+
+	buf.Release()
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected buffer returned, got %d outstanding", pool.Outstanding())
+	}
+	if buf.Finalize(func([]byte) {}) != nil {
+		t.Error("Expected nil wire view after release")
+	}
+}
+
+func TestBuffer_FinalizeWithoutHeader(t *testing.T) {
+	buf := NewBuffer([]byte("data"), nil)
+	wire := buf.Finalize(func(hdr []byte) {
+		if len(hdr) != 0 {
+			t.Errorf("Expected empty header, got %d bytes", len(hdr))
+		}
+	})
+	if string(wire) != "data" {
+		t.Errorf("Expected whole data, got %q", wire)
+	}
+}
@@ -0,0 +1,20 @@
+package bytepool
+
+// Freeze stops the pool from handing out or storing idle buffers and returns a snapshot
+// of its state. While frozen, Get allocates fresh buffers and Put drops them, both still
+// counted in the statistics, so the idle occupancy stays exactly as captured
+// Operations already in flight when Freeze is called may still complete against the stores
+func (p *BytePool) Freeze() Report {
+	p.frozen.Store(true)
+	return p.Stats()
+}
+
+// Thaw resumes normal pooling after Freeze
+func (p *BytePool) Thaw() {
+	p.frozen.Store(false)
+}
+
+// Frozen reports whether the pool is frozen
+func (p *BytePool) Frozen() bool {
+	return p.frozen.Load()
+}
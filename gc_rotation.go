@@ -0,0 +1,57 @@
+package bytepool
+
+import (
+	"sync/atomic"
+	"weak"
+)
+
+// WithGCRotationMitigation keeps up to keep idle buffers per sync.Pool tier alive across
+// GC cycles. sync.Pool moves its content to a victim cache at each GC and drops it at
+// the next one, so a pool idle for two cycles starts over with allocations. After every
+// GC the buffers waiting in the victim cache are moved back to the primary generation,
+// which lets one generation survive each rotation; TierStats.SurvivedGC counts them
+// Tiers backed by other backends already hold their buffers and are not affected
+func WithGCRotationMitigation(keep int) Option {
+	if keep <= 0 {
+		panic("gc rotation keep count must be positive")
+	}
+	return func(p *BytePool) {
+		p.gcRotationKeep = keep
+	}
+}
+
+// gcRotation re-pools the idle buffers of a sync.Pool backed store after each GC
+type gcRotation struct {
+	store    Store
+	keep     int
+	survived atomic.Int64
+}
+
+// newGCRotation arms the rotation of store when it is backed by sync.Pool, nil otherwise
+func newGCRotation(store Store, keep int) *gcRotation {
+	switch store.(type) {
+	case *syncPoolStore, *inlineStore:
+	default:
+		return nil
+	}
+	r := &gcRotation{store: store, keep: keep}
+	watchGC(weak.Make(r), (*gcRotation).rotate)
+	return r
+}
+
+// rotate takes up to keep buffers out of the store, draining the victim cache, and
+// puts them back into the primary generation
+func (r *gcRotation) rotate() {
+	held := make([]*[]byte, 0, r.keep)
+	for range r.keep {
+		buf := r.store.Get()
+		if buf == nil {
+			break
+		}
+		held = append(held, buf)
+	}
+	for _, buf := range held {
+		r.store.Put(buf)
+	}
+	r.survived.Add(int64(len(held)))
+}
@@ -0,0 +1,51 @@
+package bytepool
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestBytePool_GCRotationMitigation(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool drops items at random under the race detector")
+	}
+	pool := NewPools([]int{64, 4096}, WithGCRotationMitigation(4),
+		WithBackendForRange(4096, 4096, FreeListBackend(4)))
+
+	if pool.state.Load().tier(64).gcRotation == nil {
+		t.Fatal("Expected rotation for the sync.Pool tier")
+	}
+	if pool.state.Load().tier(4096).gcRotation != nil {
+		t.Error("Expected no rotation for the free list tier")
+	}
+
+	// defeat the per-P cache so Get after GC has to reach the re-pooled buffers
+	bufs := make([][]byte, 4)
+	for i := range bufs {
+		bufs[i] = pool.Get(64)
+	}
+	for _, buf := range bufs {
+		pool.Put(buf)
+	}
+
+	// without rotation, two GC cycles empty a sync.Pool
+	deadline := time.Now().Add(2 * time.Second)
+	for pool.rotationSurvived(64) < 8 && time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+	if got := pool.rotationSurvived(64); got < 8 {
+		t.Fatalf("Expected buffers to survive several GC cycles, got %d", got)
+	}
+}
+
+// rotationSurvived returns the SurvivedGC counter of a tier
+func (p *BytePool) rotationSurvived(size int) int64 {
+	for s, tier := range p.Tiers() {
+		if s == size {
+			return tier.SurvivedGC
+		}
+	}
+	return 0
+}
@@ -0,0 +1,71 @@
+package bytepool
+
+import (
+	"sync/atomic"
+	"weak"
+)
+
+// WithGCSentinels plants a sentinel buffer in every sync.Pool backed tier and checks it
+// after each GC cycle. sync.Pool drops its content after two cycles without use, so a
+// collected sentinel means the tier was drained and the next Gets allocate; the signal
+// explains latency spikes right after GC. TierStats.SurvivedLastGC reports whether the
+// tier kept its idle buffers through the last cycle and GCDrains how often it did not
+// The sentinel is an ordinary idle buffer and may be leased; one leased and dropped
+// without Put reads as a drain
+func WithGCSentinels() Option {
+	return func(p *BytePool) {
+		p.gcSentinels = true
+	}
+}
+
+// tierSentinel tracks a buffer planted in a sync.Pool backed store
+type tierSentinel struct {
+	store    Store
+	size     int
+	planted  weak.Pointer[byte] // backing array of the sentinel, only touched by check
+	survived atomic.Bool
+	checked  atomic.Bool // at least one GC cycle ran since the sentinel was armed
+	drains   atomic.Int64
+}
+
+// newTierSentinel arms a sentinel in store when it is backed by sync.Pool, nil otherwise
+func newTierSentinel(store Store, size int) *tierSentinel {
+	switch store.(type) {
+	case *syncPoolStore, *inlineStore:
+	default:
+		return nil
+	}
+	s := &tierSentinel{store: store, size: size}
+	s.plant()
+	watchGC(weak.Make(s), (*tierSentinel).check)
+	return s
+}
+
+// plant puts a fresh sentinel buffer into the store
+func (s *tierSentinel) plant() {
+	buf := make([]byte, s.size)
+	s.planted = weak.Make(&buf[0])
+	s.store.Put(&buf)
+}
+
+// check runs after every GC cycle, replanting the sentinel when it was collected
+func (s *tierSentinel) check() {
+	s.checked.Store(true)
+	if s.planted.Value() != nil {
+		s.survived.Store(true)
+		return
+	}
+	s.survived.Store(false)
+	s.drains.Add(1)
+	s.plant()
+}
+
+// fill adds the sentinel signal to tier stats
+func (s *tierSentinel) fill(tier *TierStats) {
+	if !s.checked.Load() {
+		return
+	}
+	survived := s.survived.Load()
+	tier.SurvivedLastGC = &survived
+	tier.GCDrains = s.drains.Load()
+}
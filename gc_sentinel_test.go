@@ -0,0 +1,34 @@
+package bytepool
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestBytePool_GCSentinels(t *testing.T) {
+	pool := NewPools([]int{128, 1024}, WithGCSentinels(),
+		WithBackendForRange(1024, 1024, FreeListBackend(4)))
+
+	if tier := pool.Stats().Tiers[0]; tier.SurvivedLastGC != nil {
+		t.Errorf("Expected no signal before the first GC, got %v", *tier.SurvivedLastGC)
+	}
+
+	// an idle sync.Pool is drained every second cycle
+	deadline := time.Now().Add(2 * time.Second)
+	var tier TierStats
+	for time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+		if tier = pool.Stats().Tiers[0]; tier.GCDrains > 0 {
+			break
+		}
+	}
+	if tier.GCDrains == 0 || tier.SurvivedLastGC == nil {
+		t.Fatalf("Expected the idle tier drained by GC, got %+v", tier)
+	}
+
+	if free := pool.Stats().Tiers[1]; free.SurvivedLastGC != nil || free.GCDrains != 0 {
+		t.Errorf("Expected no sentinel in free list tiers, got %+v", free)
+	}
+}
@@ -0,0 +1,59 @@
+package bytepool
+
+import "math"
+
+// sizeAlign is the alignment of generated tier sizes
+const sizeAlign = 8
+
+// GenerateSizes returns a geometric progression of tier sizes from minSize to maxSize, each size
+// growth times the previous one rounded up to a multiple of 8, e.g. growth 2 yields powers
+// of two and 1.25 a finer progression with less internal fragmentation
+// maxSize is always the last size. Panics if minSize is not positive, maxSize is below minSize or growth <= 1
+func GenerateSizes(minSize, maxSize int, growth float64) []int {
+	if minSize <= 0 || maxSize < minSize {
+		panic("sizes range must be positive and ordered")
+	}
+	if growth <= 1 || math.IsNaN(growth) || math.IsInf(growth, 0) {
+		panic("sizes growth must be greater than 1")
+	}
+	var sizes []int
+	for size := minSize; size < maxSize; {
+		sizes = append(sizes, size)
+		next := alignUp(int(math.Ceil(float64(size)*growth)), sizeAlign)
+		if next <= size {
+			next = size + sizeAlign
+		}
+		size = next
+	}
+	return append(sizes, maxSize)
+}
+
+// SizeJemalloc returns jemalloc style size classes between minSize and maxSize: multiples of 16 up
+// to 128, then four evenly spaced classes per doubling, e.g. 160, 192, 224, 256, 320, ...
+// Sizes below minSize are skipped, maxSize is always the last size
+func SizeJemalloc(minSize, maxSize int) []int {
+	if minSize <= 0 || maxSize < minSize {
+		panic("sizes range must be positive and ordered")
+	}
+	var sizes []int
+	add := func(size int) {
+		if size >= minSize && size < maxSize {
+			sizes = append(sizes, size)
+		}
+	}
+	for size := 16; size <= 128 && size < maxSize; size += 16 {
+		add(size)
+	}
+	for group := 128; group < maxSize; group *= 2 {
+		step := group / 4
+		for i := 1; i <= 4; i++ {
+			add(group + i*step)
+		}
+	}
+	return append(sizes, maxSize)
+}
+
+// alignUp rounds n up to a multiple of align
+func alignUp(n, align int) int {
+	return (n + align - 1) / align * align
+}
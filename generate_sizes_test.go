@@ -0,0 +1,54 @@
+package bytepool
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGenerateSizes(t *testing.T) {
+	want := []int{128, 256, 512, 1024, 2048, 4096, 8192, 16384, 32768, 65536, 131072, 262144, 524288, 1048576, 2097152}
+	if got := SizePowerOfTwo(); !slices.Equal(got, want) {
+		t.Errorf("Expected powers of two, got %v", got)
+	}
+
+	got := GenerateSizes(100, 1000, 1.5)
+	if got[0] != 100 || got[len(got)-1] != 1000 || !slices.IsSorted(got) {
+		t.Errorf("Unexpected progression %v", got)
+	}
+	for _, size := range got[1 : len(got)-1] {
+		if size%sizeAlign != 0 {
+			t.Errorf("Expected aligned size, got %d", size)
+		}
+	}
+
+	// a growth too small to advance still makes progress
+	if got := GenerateSizes(8, 64, 1.01); !slices.Equal(got, []int{8, 16, 24, 32, 40, 48, 56, 64}) {
+		t.Errorf("Unexpected fine progression %v", got)
+	}
+}
+
+func TestGenerateSizes_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		min, max int
+		growth   float64
+	}{{0, 10, 2}, {10, 5, 2}, {10, 100, 1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected panic for %+v", tc)
+				}
+			}()
+			GenerateSizes(tc.min, tc.max, tc.growth)
+		}()
+	}
+}
+
+func TestSizeJemalloc(t *testing.T) {
+	want := []int{16, 32, 48, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384, 448, 512}
+	if got := SizeJemalloc(16, 512); !slices.Equal(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if got := SizeJemalloc(100, 300); !slices.Equal(got, []int{112, 128, 160, 192, 224, 256, 300}) {
+		t.Errorf("Unexpected clipped classes %v", got)
+	}
+}
@@ -0,0 +1,22 @@
+package bytepool
+
+import "fmt"
+
+// GetMax returns the largest length the pool can serve from its tiers
+func (p *BytePool) GetMax() int {
+	return p.state.Load().maxSize
+}
+
+// GetUpTo retrieves a []byte like Get but never allocates beyond the largest tier
+// Returns ErrOversize for longer lengths, so framing code can treat a frame larger
+// than the pool maximum as a protocol error instead of silently allocating it, and
+// ErrTooLong for lengths refused by WithMaxGetLength
+func (p *BytePool) GetUpTo(length int) ([]byte, error) {
+	if err := p.refuse(length); err != nil {
+		return nil, err
+	}
+	if maxSize := p.GetMax(); length > maxSize {
+		return nil, fmt.Errorf("%w: %d > %d", ErrOversize, length, maxSize)
+	}
+	return p.Get(length), nil
+}
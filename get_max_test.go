@@ -0,0 +1,27 @@
+package bytepool
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBytePool_GetUpTo(t *testing.T) {
+	pool := NewPools([]int{128, 1024})
+	if pool.GetMax() != 1024 {
+		t.Errorf("Expected max 1024, got %d", pool.GetMax())
+	}
+
+	buf, err := pool.GetUpTo(1024)
+	if err != nil || len(buf) != 1024 {
+		t.Fatalf("Expected 1024 byte buffer, got %d, %v", len(buf), err)
+	}
+	pool.Put(buf)
+
+	buf, err = pool.GetUpTo(1025)
+	if !errors.Is(err, ErrOversize) || buf != nil {
+		t.Errorf("Expected ErrOversize, got %v", err)
+	}
+	if pool.GetDiscardedCount() != 0 || pool.Stats().TrackerLen != 1 {
+		t.Error("Expected rejected length to neither allocate nor be tracked")
+	}
+}
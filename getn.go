@@ -0,0 +1,39 @@
+package bytepool
+
+import "sync"
+
+// GetN retrieves one buffer per requested length, e.g. header, payload and trailer of a
+// frame, with a single release function returning all of them to the pool
+// It is all-or-nothing: if any length is invalid or refused, see WithMaxGetLength, the
+// buffers already leased go back to the pool and nil is returned
+func (p *BytePool) GetN(lengths []int) ([][]byte, func()) {
+	if len(lengths) == 0 {
+		return nil, func() {}
+	}
+	for _, length := range lengths {
+		if length <= 0 {
+			return nil, func() {}
+		}
+	}
+
+	bufs := make([][]byte, len(lengths))
+	for i, length := range lengths {
+		buf, err := p.GetE(length)
+		if err != nil {
+			for _, leased := range bufs[:i] {
+				p.Put(leased)
+			}
+			return nil, func() {}
+		}
+		bufs[i] = buf
+	}
+
+	var once sync.Once
+	return bufs, func() {
+		once.Do(func() {
+			for _, buf := range bufs {
+				p.Put(buf)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package bytepool
+
+import "testing"
+
+func TestBytePool_GetN(t *testing.T) {
+	pool := NewPools([]int{128, 256, 1024})
+
+	bufs, release := pool.GetN([]int{16, 1000, 4})
+	if len(bufs) != 3 {
+		t.Fatalf("Expected 3 buffers, got %d", len(bufs))
+	}
+	for i, want := range []int{16, 1000, 4} {
+		if len(bufs[i]) != want {
+			t.Errorf("Expected buffer %d length %d, got %d", i, want, len(bufs[i]))
+		}
+	}
+
+	release()
+	release() // must be idempotent
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected all buffers returned, got %d outstanding", pool.Outstanding())
+	}
+	if put := pool.GetPoolStats()["total_put"].(int64); put != 3 {
+		t.Errorf("Expected total_put 3, got %d", put)
+	}
+}
+
+func TestBytePool_GetNAllOrNothing(t *testing.T) {
+	pool := NewPools([]int{128})
+
+	bufs, release := pool.GetN([]int{16, 0, 32})
+	release()
+	if bufs != nil {
+		t.Error("Expected nil when any length is invalid")
+	}
+	if pool.GetPoolStats()["total_get"].(int64) != 0 {
+		t.Error("Expected no buffer to be leased")
+	}
+}
+
+func TestBytePool_GetNRefused(t *testing.T) {
+	pool := NewPools([]int{128, 1024}, WithMaxGetLength(512))
+
+	bufs, release := pool.GetN([]int{16, 100, 1000})
+	release()
+	if bufs != nil {
+		t.Error("Expected nil when any length is refused")
+	}
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected leased buffers returned, got %d outstanding", pool.Outstanding())
+	}
+	if report := pool.Stats(); report.Refused != 1 {
+		t.Errorf("Expected 1 refused Get, got %d", report.Refused)
+	}
+}
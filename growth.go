@@ -0,0 +1,73 @@
+package bytepool
+
+import (
+	"strconv"
+	"sync/atomic"
+)
+
+// GrowthPolicy selects the capacity a PooledBytesBuffer leases when it outgrows its storage
+type GrowthPolicy int
+
+const (
+	// GrowDouble leases at least twice the current capacity, the default
+	GrowDouble GrowthPolicy = iota
+	// GrowExact leases the tier that just fits the data, the least memory and the most copies
+	GrowExact
+	// GrowNextTier leases one tier above the tier fitting the data, so the next appends
+	// have headroom without skipping tiers. Past the largest tier it doubles like GrowDouble
+	GrowNextTier
+	// GrowOneAndHalf leases at least 1.5 times the current capacity
+	GrowOneAndHalf
+)
+
+// String returns the name of the policy
+func (g GrowthPolicy) String() string {
+	switch g {
+	case GrowDouble:
+		return "double"
+	case GrowExact:
+		return "exact"
+	case GrowNextTier:
+		return "next_tier"
+	case GrowOneAndHalf:
+		return "1.5x"
+	default:
+		return "GrowthPolicy(" + strconv.Itoa(int(g)) + ")"
+	}
+}
+
+// WithGrowthPolicy sets how PooledBytesBuffer grows across tiers, e.g. GrowNextTier keeps
+// append-heavy serialization from skipping tiers and GrowExact from over-leasing
+// Growth copies and the bytes they moved are reported in Stats
+func WithGrowthPolicy(policy GrowthPolicy) Option {
+	if policy < GrowDouble || policy > GrowOneAndHalf {
+		panic("unknown growth policy")
+	}
+	return func(p *BytePool) {
+		p.growth = policy
+	}
+}
+
+// growLength returns the length to lease for need bytes when the storage holds capacity
+// Headroom never goes past the max get length, need itself may
+func (p *BytePool) growLength(capacity, need int) int {
+	length := max(2*capacity, need)
+	switch p.growth {
+	case GrowExact:
+		length = need
+	case GrowNextTier:
+		st := p.state.Load()
+		if i := st.tierIndex(need) + 1; i < len(st.sizes) {
+			length = st.sizes[i]
+		}
+	case GrowOneAndHalf:
+		length = max(capacity+capacity/2, need)
+	}
+	return max(p.clampLength(length), need)
+}
+
+// countGrowth records a growth that copied n bytes into larger storage
+func (p *BytePool) countGrowth(n int) {
+	atomic.AddInt64(&p.growthCopies, 1)
+	atomic.AddInt64(&p.growthCopiedBytes, int64(n))
+}
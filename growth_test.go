@@ -0,0 +1,56 @@
+package bytepool
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBytePool_GrowthPolicy(t *testing.T) {
+	sizes := []int{64, 96, 128, 192, 256, 384, 512, 1024}
+	tests := []struct {
+		policy GrowthPolicy
+		caps   []int // capacity after each 40-byte write
+		copies int64
+	}{
+		{GrowDouble, []int{64, 128, 128, 256, 256, 256}, 2},
+		{GrowExact, []int{64, 96, 128, 192, 256, 256}, 4},
+		{GrowNextTier, []int{64, 128, 128, 256, 256, 256}, 2},
+		{GrowOneAndHalf, []int{64, 96, 192, 192, 384, 384}, 3},
+	}
+	chunk := bytes.Repeat([]byte{'x'}, 40)
+	for _, tt := range tests {
+		pool := NewPools(sizes, WithGrowthPolicy(tt.policy))
+		b := pool.NewBytesBuffer(64)
+		for i, want := range tt.caps {
+			b.Write(chunk)
+			if b.Cap() != want {
+				t.Errorf("%s: expected capacity %d after write %d, got %d", tt.policy, want, i+1, b.Cap())
+			}
+		}
+		if got := b.Len(); got != 40*len(tt.caps) || !bytes.Equal(b.Bytes(), bytes.Repeat(chunk, len(tt.caps))) {
+			t.Errorf("%s: expected data kept across growth, got %d bytes", tt.policy, got)
+		}
+		b.Close()
+
+		report := pool.Stats()
+		if report.GrowthCopies != tt.copies {
+			t.Errorf("%s: expected %d growth copies, got %d", tt.policy, tt.copies, report.GrowthCopies)
+		}
+		if pool.Outstanding() != 0 {
+			t.Errorf("%s: expected storage returned, got %d outstanding", tt.policy, pool.Outstanding())
+		}
+	}
+}
+
+func TestBytePool_GrowthOfEmptyBuffer(t *testing.T) {
+	pool := NewPools([]int{64, 128, 256})
+	b := pool.NewBytesBuffer(64)
+	b.Grow(200) // nothing to move
+	b.Write([]byte("x"))
+	b.Close()
+
+	if report := pool.Stats(); report.GrowthCopies != 0 || report.GrowthCopiedBytes != 0 {
+		t.Errorf("Expected no growth copies for an empty buffer, got %d of %d bytes",
+			report.GrowthCopies, report.GrowthCopiedBytes)
+	}
+}
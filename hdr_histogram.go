@@ -0,0 +1,245 @@
+package bytepool
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+)
+
+// HdrHistogram V2 encoding cookies, the 0x10 bit marks 8 byte LEB128 words as in the
+// reference Java implementation; decoders mask it off
+const (
+	hdrEncodingCookie           = 0x1c849303 | 0x10
+	hdrCompressedEncodingCookie = 0x1c849304 | 0x10
+)
+
+// SizeHistogram is an HdrHistogram-compatible histogram of requested lengths
+// Its encoding can be decoded and merged by standard HdrHistogram tooling
+type SizeHistogram struct {
+	highest    int64
+	sigFigs    int32
+	subHalfMag int32 // subBucketHalfCountMagnitude
+	subHalf    int32 // subBucketHalfCount
+	subMask    int64 // subBucketMask
+	counts     []int64
+	total      int64
+	maxValue   int64
+}
+
+// NewSizeHistogram creates a histogram tracking values from 1 to highest with the given
+// number of significant decimal digits (1 to 5)
+func NewSizeHistogram(highest int64, sigFigs int) *SizeHistogram {
+	if sigFigs < 1 || sigFigs > 5 {
+		panic("significant figures must be between 1 and 5")
+	}
+	highest = max(highest, 2)
+	largestSingleUnit := 2 * int64(math.Pow10(sigFigs))
+	subMag := int32(math.Ceil(math.Log2(float64(largestSingleUnit))))
+	subHalfMag := max(subMag, 1) - 1
+	subCount := int64(1) << (subHalfMag + 1)
+
+	buckets := int32(1)
+	for smallest := subCount; smallest <= highest; smallest <<= 1 {
+		if smallest > math.MaxInt64/2 {
+			buckets++
+			break
+		}
+		buckets++
+	}
+
+	return &SizeHistogram{
+		highest:    highest,
+		sigFigs:    int32(sigFigs),
+		subHalfMag: subHalfMag,
+		subHalf:    int32(subCount / 2),
+		subMask:    subCount - 1,
+		counts:     make([]int64, (int64(buckets)+1)*(subCount/2)),
+	}
+}
+
+// bucketIndex returns the HdrHistogram bucket of v
+func (h *SizeHistogram) bucketIndex(v int64) int32 {
+	pow2Ceiling := int32(64 - bits.LeadingZeros64(uint64(v|h.subMask)))
+	return pow2Ceiling - (h.subHalfMag + 1)
+}
+
+// countsIndex returns the index of v in the counts array
+func (h *SizeHistogram) countsIndex(v int64) int {
+	bucket := h.bucketIndex(v)
+	sub := int32(v >> bucket)
+	return int((bucket+1)<<h.subHalfMag) + int(sub-h.subHalf)
+}
+
+// valueFromIndex returns the lowest value recorded at counts index i
+func (h *SizeHistogram) valueFromIndex(i int) int64 {
+	bucket := int32(i>>h.subHalfMag) - 1
+	sub := int32(i)&(h.subHalf-1) + h.subHalf
+	if bucket < 0 {
+		sub -= h.subHalf
+		bucket = 0
+	}
+	return int64(sub) << bucket
+}
+
+// highestEquivalentValue returns the largest value sharing v's counts slot
+func (h *SizeHistogram) highestEquivalentValue(v int64) int64 {
+	bucket := h.bucketIndex(v)
+	sub := v >> bucket
+	if sub >= int64(h.subHalf)*2 {
+		bucket++
+	}
+	return h.valueFromIndex(h.countsIndex(v)) + (int64(1) << bucket) - 1
+}
+
+// Record adds a value, values outside [1, highest] are clamped
+func (h *SizeHistogram) Record(v int64) {
+	v = min(max(v, 1), h.highest)
+	h.counts[h.countsIndex(v)]++
+	h.total++
+	h.maxValue = max(h.maxValue, v)
+}
+
+// TotalCount returns the number of recorded values
+func (h *SizeHistogram) TotalCount() int64 {
+	return h.total
+}
+
+// ValueAtPercentile returns the value below which the given percentage of values fall
+func (h *SizeHistogram) ValueAtPercentile(percentile float64) int64 {
+	if h.total == 0 {
+		return 0
+	}
+	percentile = min(max(percentile, 0), 100)
+	target := max(int64(percentile/100*float64(h.total)+0.5), 1)
+	var cumulative int64
+	for i, count := range h.counts {
+		cumulative += count
+		if cumulative >= target {
+			return h.highestEquivalentValue(h.valueFromIndex(i))
+		}
+	}
+	return 0
+}
+
+// Encode returns the compressed HdrHistogram V2 binary encoding
+func (h *SizeHistogram) Encode() ([]byte, error) {
+	var payload bytes.Buffer
+	limit := 0
+	if h.total > 0 {
+		limit = h.countsIndex(h.maxValue) + 1
+	}
+	for i := 0; i < limit; {
+		count := h.counts[i]
+		i++
+		if count == 0 {
+			zeros := int64(1)
+			for i < limit && h.counts[i] == 0 {
+				zeros++
+				i++
+			}
+			if zeros > 1 {
+				count = -zeros
+			}
+		}
+		putZigZag(&payload, count)
+	}
+
+	var raw bytes.Buffer
+	_ = binary.Write(&raw, binary.BigEndian, int32(hdrEncodingCookie))
+	_ = binary.Write(&raw, binary.BigEndian, int32(payload.Len()))
+	_ = binary.Write(&raw, binary.BigEndian, int32(0)) // normalizing index offset
+	_ = binary.Write(&raw, binary.BigEndian, h.sigFigs)
+	_ = binary.Write(&raw, binary.BigEndian, int64(1)) // lowest discernible value
+	_ = binary.Write(&raw, binary.BigEndian, h.highest)
+	_ = binary.Write(&raw, binary.BigEndian, float64(1)) // integer to double conversion ratio
+	raw.Write(payload.Bytes())
+
+	var compressed bytes.Buffer
+	zw, err := zlib.NewWriterLevel(&compressed, zlib.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := zw.Write(raw.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 8, 8+compressed.Len())
+	binary.BigEndian.PutUint32(out[0:4], uint32(hdrCompressedEncodingCookie))
+	binary.BigEndian.PutUint32(out[4:8], uint32(compressed.Len()))
+	return append(out, compressed.Bytes()...), nil
+}
+
+// EncodeBase64 returns the base64 form of Encode used in HdrHistogram log files
+func (h *SizeHistogram) EncodeBase64() (string, error) {
+	data, err := h.Encode()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// putZigZag writes v as a ZigZag LEB128 word, the ninth byte carries 8 bits as in HdrHistogram
+func putZigZag(w *bytes.Buffer, v int64) {
+	u := uint64((v << 1) ^ (v >> 63))
+	for i := 0; i < 8; i++ {
+		if u < 0x80 {
+			w.WriteByte(byte(u))
+			return
+		}
+		w.WriteByte(byte(u&0x7F) | 0x80)
+		u >>= 7
+	}
+	w.WriteByte(byte(u))
+}
+
+// hdrPercentiles are the percentiles written by WritePercentiles
+var hdrPercentiles = []float64{0, 50, 75, 90, 95, 99, 99.9, 100}
+
+// WritePercentiles writes a percentile table in the HdrHistogram text format
+// (Value, Percentile, TotalCount, 1/(1-Percentile)) parseable by HdrHistogram plotters
+func (h *SizeHistogram) WritePercentiles(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "%12s %14s %10s %14s\n\n", "Value", "Percentile", "TotalCount", "1/(1-Percentile)"); err != nil {
+		return err
+	}
+	for _, percentile := range hdrPercentiles {
+		value := h.ValueAtPercentile(percentile)
+		var count int64
+		if h.total > 0 {
+			for i := 0; i <= h.countsIndex(max(value, 1)); i++ {
+				count += h.counts[i]
+			}
+		}
+		fraction := percentile / 100
+		if fraction < 1 {
+			_, err := fmt.Fprintf(w, "%12.3f %2.12f %10d %14.2f\n", float64(value), fraction, count, 1/(1-fraction))
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%12.3f %2.12f %10d\n", float64(value), fraction, count); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "#[Max     = %12.3f, Total count    = %12d]\n", float64(h.maxValue), h.total)
+	return err
+}
+
+// LengthHistogram returns a histogram of the lengths currently held by the tracker
+// Values are tracked up to the largest tier with 3 significant digits, larger
+// requests are clamped into the top slot
+func (p *BytePool) LengthHistogram() *SizeHistogram {
+	h := NewSizeHistogram(int64(p.GetMax()), 3)
+	for _, length := range p.tracker().Bytes() {
+		h.Record(int64(length))
+	}
+	return h
+}
@@ -0,0 +1,140 @@
+package bytepool
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"strings"
+	"testing"
+)
+
+// decodeHdr decodes a compressed V2 histogram into its counts array
+func decodeHdr(t *testing.T, data []byte) (sigFigs int32, highest int64, counts []int64) {
+	t.Helper()
+	if cookie := binary.BigEndian.Uint32(data[0:4]); cookie&^0xf0 != 0x1c849304 {
+		t.Fatalf("Unexpected compressed cookie %#x", cookie)
+	}
+	length := binary.BigEndian.Uint32(data[4:8])
+	zr, err := zlib.NewReader(bytes.NewReader(data[8 : 8+length]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cookie := binary.BigEndian.Uint32(raw[0:4]); cookie&^0xf0 != 0x1c849303 {
+		t.Fatalf("Unexpected cookie %#x", cookie)
+	}
+	payloadLen := binary.BigEndian.Uint32(raw[4:8])
+	sigFigs = int32(binary.BigEndian.Uint32(raw[12:16]))
+	highest = int64(binary.BigEndian.Uint64(raw[24:32]))
+
+	payload := raw[40 : 40+payloadLen]
+	for len(payload) > 0 {
+		var u uint64
+		var shift uint
+		for i := 0; ; i++ {
+			b := payload[0]
+			payload = payload[1:]
+			if i == 8 {
+				u |= uint64(b) << shift
+				break
+			}
+			u |= uint64(b&0x7F) << shift
+			shift += 7
+			if b < 0x80 {
+				break
+			}
+		}
+		v := int64(u>>1) ^ -int64(u&1)
+		if v < 0 {
+			counts = append(counts, make([]int64, -v)...)
+			continue
+		}
+		counts = append(counts, v)
+	}
+	return sigFigs, highest, counts
+}
+
+func TestSizeHistogram_Encode(t *testing.T) {
+	h := NewSizeHistogram(2097152, 3)
+	values := []int64{100, 100, 1500, 4096, 1048576}
+	for _, v := range values {
+		h.Record(v)
+	}
+
+	data, err := h.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigFigs, highest, counts := decodeHdr(t, data)
+	if sigFigs != 3 || highest != 2097152 {
+		t.Errorf("Unexpected header: sigfigs %d highest %d", sigFigs, highest)
+	}
+
+	// values below 2048 map to their own slot with 3 significant digits
+	if counts[100] != 2 || counts[1500] != 1 {
+		t.Errorf("Unexpected low counts: [100]=%d [1500]=%d", counts[100], counts[1500])
+	}
+	var total int64
+	for _, c := range counts {
+		total += c
+	}
+	if total != int64(len(values)) {
+		t.Errorf("Expected %d decoded values, got %d", len(values), total)
+	}
+	if len(counts) != h.countsIndex(1048576)+1 {
+		t.Errorf("Expected counts to end at the max value, got %d", len(counts))
+	}
+
+	s, err := h.EncodeBase64()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded, _ := base64.StdEncoding.DecodeString(s); !bytes.Equal(decoded, data) {
+		t.Error("Expected base64 form of the binary encoding")
+	}
+}
+
+func TestSizeHistogram_Percentiles(t *testing.T) {
+	h := NewSizeHistogram(1<<20, 3)
+	for v := int64(1); v <= 1000; v++ {
+		h.Record(v)
+	}
+
+	if got := h.ValueAtPercentile(50); got != 500 {
+		t.Errorf("Expected p50 500, got %d", got)
+	}
+	if got := h.ValueAtPercentile(99); got != 990 {
+		t.Errorf("Expected p99 990, got %d", got)
+	}
+	if got := h.ValueAtPercentile(100); got != 1000 {
+		t.Errorf("Expected p100 1000, got %d", got)
+	}
+
+	var out bytes.Buffer
+	if err := h.WritePercentiles(&out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Percentile") || !strings.Contains(out.String(), "Total count    =         1000") {
+		t.Errorf("Unexpected percentile output:\n%s", out.String())
+	}
+}
+
+func TestBytePool_LengthHistogram(t *testing.T) {
+	pool := NewPools(SizePowerOfTwo())
+	for _, length := range []int{64, 64, 3000, 70000} {
+		pool.Put(pool.Get(length))
+	}
+
+	h := pool.LengthHistogram()
+	if h.TotalCount() != 4 {
+		t.Errorf("Expected 4 values, got %d", h.TotalCount())
+	}
+	if got := h.ValueAtPercentile(50); got != 64 {
+		t.Errorf("Expected p50 64, got %d", got)
+	}
+}
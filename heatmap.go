@@ -0,0 +1,124 @@
+package bytepool
+
+import (
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// heatmapCheckEvery is the mean number of Gets between two minute rollover checks
+const heatmapCheckEvery = 256
+
+// Heatmap is a matrix of Gets per tier and minute, ready for heatmap rendering
+type Heatmap struct {
+	Start  time.Time `json:"start"`  // start of the minute of the first row
+	Sizes  []int     `json:"sizes"`  // tier of each column
+	Counts [][]int64 `json:"counts"` // Counts[minute][tier], oldest first, the last row is the current minute
+}
+
+// WithHeatmap keeps per-tier Get counts for each of the last minutes, see BytePool.Heatmap
+// The counts are taken from the tier counters at minute rollovers, which are checked on
+// about one in 256 Gets and on every Heatmap call. Gets between the last check of a minute
+// and the first check of the next are counted in the earlier minute
+func WithHeatmap(minutes int) Option {
+	if minutes <= 0 {
+		panic("heatmap minutes must be positive")
+	}
+	return func(p *BytePool) {
+		p.heat = &heatmap{rows: make([]heatRow, minutes)}
+	}
+}
+
+// heatmap is a ring of per-minute rows indexed by minute modulo the ring length
+type heatmap struct {
+	mu      sync.Mutex
+	rows    []heatRow
+	started bool
+	minute  int64         // minute being accumulated, in minutes since the Unix epoch
+	last    map[int]int64 // cumulative tier Gets at the previous check
+}
+
+// index returns the ring slot of minute, clocks before the epoch included
+func (h *heatmap) index(minute int64) int {
+	n := int64(len(h.rows))
+	return int((minute%n + n) % n)
+}
+
+// heatRow holds the Gets per tier size of one minute
+type heatRow struct {
+	minute int64
+	counts map[int]int64
+}
+
+// maybeAdvanceHeatmap checks for a minute rollover on a random sample of Gets
+func (p *BytePool) maybeAdvanceHeatmap() {
+	if rand.Uint32N(heatmapCheckEvery) != 0 || !p.heat.mu.TryLock() {
+		return
+	}
+	defer p.heat.mu.Unlock()
+	p.advanceHeatmap()
+}
+
+// advanceHeatmap moves the Gets since the previous check into the current minute and
+// starts a new minute when the clock moved on, the caller holds the heatmap lock
+func (p *BytePool) advanceHeatmap() {
+	h := p.heat
+	minute := p.now().Unix() / 60
+	p.flushStats()
+	st := p.state.Load()
+	gets := make(map[int]int64, len(st.tiers))
+	for i := range st.tiers {
+		gets[st.tiers[i].size] = atomic.LoadInt64(&st.tiers[i].stats.Get)
+	}
+	if !h.started {
+		h.started, h.minute, h.last = true, minute, gets
+		return
+	}
+
+	row := &h.rows[h.index(h.minute)]
+	if row.counts == nil || row.minute != h.minute {
+		*row = heatRow{minute: h.minute, counts: make(map[int]int64, len(gets))}
+	}
+	for size, n := range gets {
+		if d := n - h.last[size]; d > 0 {
+			row.counts[size] += d
+		}
+	}
+	h.last = gets
+	if minute > h.minute {
+		h.minute = minute
+	}
+}
+
+// Heatmap returns the Gets per tier for each minute of the window set by WithHeatmap,
+// minutes without Gets are zero rows. Returns an empty Heatmap without WithHeatmap
+// The counts need StatsTierCounters, see SetStatsLevel
+func (p *BytePool) Heatmap() Heatmap {
+	if p.heat == nil {
+		return Heatmap{}
+	}
+	h := p.heat
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	p.advanceHeatmap()
+
+	sizes := p.GetAvailableSizes()
+	n := int64(len(h.rows))
+	first := h.minute - n + 1
+	hm := Heatmap{
+		Start:  time.Unix(first*60, 0),
+		Sizes:  sizes,
+		Counts: make([][]int64, n),
+	}
+	for i := range hm.Counts {
+		counts := make([]int64, len(sizes))
+		if row := h.rows[h.index(first+int64(i))]; row.minute == first+int64(i) {
+			for j, size := range sizes {
+				counts[j] = row.counts[size]
+			}
+		}
+		hm.Counts[i] = counts
+	}
+	return hm
+}
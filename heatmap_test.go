@@ -0,0 +1,53 @@
+package bytepool
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBytePool_Heatmap(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC)
+	clock := NewManualClock(start)
+	pool := NewPools([]int{128, 1024}, WithHeatmap(3), WithClock(clock))
+
+	for range 5 {
+		pool.Put(pool.Get(100))
+	}
+	pool.Put(pool.Get(1000))
+	clock.Advance(time.Minute)
+	pool.Heatmap() // rolls the first minute over
+	for range 2 {
+		pool.Put(pool.Get(1000))
+	}
+
+	hm := pool.Heatmap()
+	if want := start.Truncate(time.Minute).Add(-time.Minute); !hm.Start.Equal(want) {
+		t.Errorf("Expected start %v, got %v", want, hm.Start)
+	}
+	if len(hm.Sizes) != 2 || hm.Sizes[0] != 128 || hm.Sizes[1] != 1024 {
+		t.Errorf("Expected tier columns, got %v", hm.Sizes)
+	}
+	want := [][]int64{{0, 0}, {5, 1}, {0, 2}}
+	for i := range want {
+		for j := range want[i] {
+			if hm.Counts[i][j] != want[i][j] {
+				t.Errorf("Expected counts %v, got %v", want, hm.Counts)
+				return
+			}
+		}
+	}
+
+	// minutes without Gets leave zero rows and old minutes drop out of the window
+	clock.Advance(2 * time.Minute)
+	hm = pool.Heatmap()
+	if hm.Counts[0][1] != 2 || hm.Counts[1][1] != 0 || hm.Counts[2][1] != 0 {
+		t.Errorf("Expected the window to slide, got %v", hm.Counts)
+	}
+}
+
+func TestBytePool_HeatmapDisabled(t *testing.T) {
+	pool := NewPools([]int{128})
+	if hm := pool.Heatmap(); hm.Counts != nil {
+		t.Errorf("Expected an empty heatmap, got %v", hm)
+	}
+}
@@ -0,0 +1,127 @@
+package bytepool
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+)
+
+// Hygiene is the policy applied to a buffer's content before it returns to the pool
+type Hygiene int
+
+const (
+	// HygieneNone keeps buffer content as is, the cheapest option
+	HygieneNone Hygiene = iota
+	// HygieneZero clears buffer content
+	HygieneZero
+	// HygienePoison fills buffer content with the poison pattern set by WithPoisonOnPut
+	HygienePoison
+	// HygieneSensitive wipes buffer content with the functions set by WithClearFuncs,
+	// or clears it when none are set, and counts every wipe in Report.Wiped
+	HygieneSensitive
+)
+
+// String returns the name of the policy
+func (h Hygiene) String() string {
+	switch h {
+	case HygieneNone:
+		return "none"
+	case HygieneZero:
+		return "zero"
+	case HygienePoison:
+		return "poison"
+	case HygieneSensitive:
+		return "sensitive"
+	default:
+		return "Hygiene(" + strconv.Itoa(int(h)) + ")"
+	}
+}
+
+// MarshalText encodes the policy by name, for config files
+func (h Hygiene) MarshalText() ([]byte, error) {
+	if h < HygieneNone || h > HygieneSensitive {
+		return nil, fmt.Errorf("%w: unknown hygiene %d", ErrInvalidConfig, int(h))
+	}
+	return []byte(h.String()), nil
+}
+
+// UnmarshalText decodes a policy name: none, zero, poison or sensitive
+func (h *Hygiene) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "none", "":
+		*h = HygieneNone
+	case "zero":
+		*h = HygieneZero
+	case "poison":
+		*h = HygienePoison
+	case "sensitive":
+		*h = HygieneSensitive
+	default:
+		return fmt.Errorf("%w: unknown hygiene %q", ErrInvalidConfig, text)
+	}
+	return nil
+}
+
+// hygieneRange assigns a hygiene policy to the tiers within [min, max]
+type hygieneRange struct {
+	min, max int
+	hygiene  Hygiene
+}
+
+// WithHygieneForRange applies hygiene to tiers whose size is within [min, max], e.g. zero
+// small control-message tiers while leaving multi-megabyte media tiers untouched
+// When ranges overlap, the one added last wins; other tiers follow WithZeroOnPut
+// and WithPoisonOnPut
+func WithHygieneForRange(min, max int, hygiene Hygiene) Option {
+	return func(p *BytePool) {
+		p.hygieneRanges = append(p.hygieneRanges, hygieneRange{min: min, max: max, hygiene: hygiene})
+	}
+}
+
+// hygieneFor resolves the policy of the given tier size, def applies outside all ranges
+func (p *BytePool) hygieneFor(size int, def Hygiene) Hygiene {
+	for i := len(p.hygieneRanges) - 1; i >= 0; i-- {
+		r := p.hygieneRanges[i]
+		if size >= r.min && size <= r.max {
+			return r.hygiene
+		}
+	}
+	return def
+}
+
+// applyHygiene runs the policy of tier t over buf
+func (p *BytePool) applyHygiene(t *tierState, buf []byte, pattern byte) {
+	switch t.hygiene {
+	case HygieneZero:
+		clear(buf)
+	case HygienePoison:
+		poison(buf, pattern)
+	case HygieneSensitive:
+		p.wipe(buf)
+	}
+}
+
+// WithClearFuncs sets the functions wiping buffers of tiers with HygieneSensitive before
+// they are reused, applied in order, e.g. a memguard wipe followed by a verification that
+// panics on leftover bytes. Mark tiers sensitive with WithHygieneForRange or PoolConfig
+func WithClearFuncs(funcs ...func([]byte)) Option {
+	for _, f := range funcs {
+		if f == nil {
+			panic("clear func must not be nil")
+		}
+	}
+	return func(p *BytePool) {
+		p.clearFuncs = append(p.clearFuncs, funcs...)
+	}
+}
+
+// wipe runs the clear functions over buf, clearing it when none are set
+func (p *BytePool) wipe(buf []byte) {
+	if len(p.clearFuncs) == 0 {
+		clear(buf)
+	}
+	for _, f := range p.clearFuncs {
+		f(buf)
+	}
+	atomic.AddInt64(&p.wiped, 1)
+}
@@ -0,0 +1,75 @@
+package bytepool
+
+import "testing"
+
+func TestWithHygieneForRange(t *testing.T) {
+	pool := NewPools([]int{128, 1024, 2097152},
+		WithZeroOnPut(),
+		WithPoisonOnPut(0xDE),
+		WithHygieneForRange(0, 128, HygieneZero),
+		WithHygieneForRange(1<<20, 1<<30, HygieneNone),
+	)
+
+	want := map[int]Hygiene{128: HygieneZero, 1024: HygienePoison, 2097152: HygieneNone}
+	for size, h := range want {
+		if got := pool.state.Load().tier(size).hygiene; got != h {
+			t.Errorf("Tier %d: expected hygiene %d, got %d", size, h, got)
+		}
+	}
+}
+
+func TestHygieneApply(t *testing.T) {
+	pool := NewPools([]int{128, 256},
+		WithBackend(FreeListBackend(1)),
+		WithHygieneForRange(128, 128, HygieneZero))
+
+	small := pool.Get(128)
+	large := pool.Get(256)
+	small[0], large[0] = 1, 1
+	pool.Put(small)
+	pool.Put(large)
+
+	if small[0] != 0 {
+		t.Error("Expected zeroed small tier")
+	}
+	if large[0] != 1 {
+		t.Error("Expected untouched large tier")
+	}
+}
+
+func TestWithClearFuncs(t *testing.T) {
+	var calls []string
+	pool := NewPools([]int{128, 256},
+		WithBackend(FreeListBackend(1)),
+		WithHygieneForRange(128, 128, HygieneSensitive),
+		WithClearFuncs(
+			func(b []byte) { calls = append(calls, "wipe"); clear(b) },
+			func(b []byte) { calls = append(calls, "verify") },
+		))
+
+	secret := pool.Get(100)
+	copy(secret, "secret")
+	pool.Put(secret)
+	pool.Put(pool.Get(200))
+
+	if secret[0] != 0 || len(calls) != 2 || calls[0] != "wipe" || calls[1] != "verify" {
+		t.Errorf("Expected the clear funcs applied in order to the sensitive tier, got %v", calls)
+	}
+	if wiped := pool.Stats().Wiped; wiped != 1 {
+		t.Errorf("Expected 1 wiped buffer, got %d", wiped)
+	}
+
+	// without clear funcs sensitive tiers are cleared
+	plain := NewPools([]int{128}, WithBackend(FreeListBackend(1)), WithHygieneForRange(0, 128, HygieneSensitive))
+	buf := plain.Get(100)
+	buf[0] = 1
+	plain.Put(buf)
+	if buf[0] != 0 {
+		t.Error("Expected sensitive tier cleared by default")
+	}
+
+	var h Hygiene
+	if err := h.UnmarshalText([]byte("sensitive")); err != nil || h != HygieneSensitive {
+		t.Errorf("Expected sensitive to decode, got %v (%v)", h, err)
+	}
+}
@@ -0,0 +1,100 @@
+package bytepool
+
+import (
+	"sync"
+	"weak"
+)
+
+// SizeSmall returns the sub-128B tiers served by inline array stores, for metadata blobs
+// that would otherwise skip the pool or waste a 128 byte slot each
+// Combine it with another preset, e.g. append(SizeSmall(), SizePowerOfTwo()...)
+func SizeSmall() []int {
+	return []int{16, 32, 48, 64, 96}
+}
+
+// inlineStore keeps idle buffers of a small tier as fixed size array pointers in a
+// sync.Pool. An array pointer fits an interface without allocating, unlike the *[]byte
+// boxing other stores need, so Get and Put of these tiers do not allocate
+type inlineStore struct {
+	p     sync.Pool
+	idle  idleEstimate
+	box   func(buf []byte) any
+	unbox func(v any) []byte
+}
+
+// newInlineStore returns the inline store for size, nil when size has no array class
+func newInlineStore(size int) *inlineStore {
+	s := newInlineArrayStore(size)
+	if s != nil {
+		watchGC(weak.Make(s), func(s *inlineStore) { s.idle.rotate() })
+	}
+	return s
+}
+
+// newInlineArrayStore returns the store boxing arrays of size, nil for other sizes
+func newInlineArrayStore(size int) *inlineStore {
+	switch size {
+	case 16:
+		return &inlineStore{
+			box:   func(b []byte) any { return (*[16]byte)(b) },
+			unbox: func(v any) []byte { return v.(*[16]byte)[:] },
+		}
+	case 32:
+		return &inlineStore{
+			box:   func(b []byte) any { return (*[32]byte)(b) },
+			unbox: func(v any) []byte { return v.(*[32]byte)[:] },
+		}
+	case 48:
+		return &inlineStore{
+			box:   func(b []byte) any { return (*[48]byte)(b) },
+			unbox: func(v any) []byte { return v.(*[48]byte)[:] },
+		}
+	case 64:
+		return &inlineStore{
+			box:   func(b []byte) any { return (*[64]byte)(b) },
+			unbox: func(v any) []byte { return v.(*[64]byte)[:] },
+		}
+	case 96:
+		return &inlineStore{
+			box:   func(b []byte) any { return (*[96]byte)(b) },
+			unbox: func(v any) []byte { return v.(*[96]byte)[:] },
+		}
+	}
+	return nil
+}
+
+// take returns an idle buffer at full capacity, nil when the store is empty
+func (s *inlineStore) take() []byte {
+	v := s.p.Get()
+	if v == nil {
+		s.idle.miss()
+		return nil
+	}
+	s.idle.hit()
+	return s.unbox(v)
+}
+
+// give stores buf, which must be at full tier capacity
+func (s *inlineStore) give(buf []byte) {
+	s.idle.put()
+	s.p.Put(s.box(buf))
+}
+
+// Get implements Store for callers outside the hot path
+func (s *inlineStore) Get() *[]byte {
+	buf := s.take()
+	if buf == nil {
+		return nil
+	}
+	return &buf
+}
+
+// Put implements Store for callers outside the hot path
+func (s *inlineStore) Put(buf *[]byte) {
+	s.give(*buf)
+}
+
+// ApproxLen returns the approximate number of idle buffers
+func (s *inlineStore) ApproxLen() int {
+	return s.idle.len()
+}
@@ -0,0 +1,81 @@
+package bytepool
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestBytePool_InlineTiers(t *testing.T) {
+	pool := NewPools(append(SizeSmall(), 128))
+
+	for _, size := range SizeSmall() {
+		if pool.state.Load().tier(size).inline == nil {
+			t.Errorf("Expected inline store for tier %d", size)
+		}
+	}
+	if pool.state.Load().tier(128).inline != nil {
+		t.Error("Expected boxed store for tier 128")
+	}
+
+	buf := pool.Get(24)
+	if len(buf) != 24 || cap(buf) != 32 {
+		t.Fatalf("Expected len 24 cap 32, got len %d cap %d", len(buf), cap(buf))
+	}
+	pool.Put(buf)
+	if report := pool.Stats(); report.TotalPut != 1 || report.Tiers[1].Idle != 1 {
+		t.Errorf("Expected the buffer back in tier 32, got %+v", report.Tiers[1])
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		pool.Put(pool.Get(90))
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations for inline tiers, got %v", allocs)
+	}
+}
+
+func TestBytePool_InlineTiersCustomBackend(t *testing.T) {
+	pool := NewPools([]int{32, 64}, WithBackend(FreeListBackend(2)))
+	if pool.state.Load().tier(32).inline != nil {
+		t.Error("Expected the configured backend to be kept")
+	}
+	pool.Put(pool.Get(20))
+	if got := pool.Get(20); cap(got) != 32 {
+		t.Errorf("Expected cap 32, got %d", cap(got))
+	}
+}
+
+func TestInlineStore_IdleEstimate(t *testing.T) {
+	store := newInlineStore(32)
+	store.give(make([]byte, 32))
+	store.give(make([]byte, 32))
+	store.idle.current.Add(5) // buffers the pool dropped on its own
+	if got := store.ApproxLen(); got != 7 {
+		t.Fatalf("Expected 7 counted, got %d", got)
+	}
+	for store.take() != nil {
+	}
+	if got := store.ApproxLen(); got != 0 {
+		t.Errorf("Expected the estimate reset by a miss, got %d", got)
+	}
+
+	store.give(make([]byte, 32))
+	deadline := time.Now().Add(5 * time.Second)
+	for store.ApproxLen() != 0 && time.Now().Before(deadline) {
+		runtime.GC()
+	}
+	if got := store.ApproxLen(); got != 0 {
+		t.Errorf("Expected GC cycles to clear the estimate, got %d", got)
+	}
+}
+
+// BenchmarkBytePoolGetInline 测试小对象内联层的 Get/Put 性能
+func BenchmarkBytePoolGetInline(b *testing.B) {
+	pool := NewPools(append(SizeSmall(), SizePowerOfTwo()...))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pool.Put(pool.Get(60))
+	}
+}
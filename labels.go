@@ -0,0 +1,40 @@
+package bytepool
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// WithLabels attaches static labels to the pool, e.g. service and listener names
+// Labels are included in GetPoolStats, Stats and the expvar name, so multiple pools
+// aggregate correctly in centralized monitoring
+func WithLabels(labels map[string]string) Option {
+	return func(p *BytePool) {
+		p.labels = maps.Clone(labels)
+	}
+}
+
+// Labels returns a copy of the pool labels
+func (p *BytePool) Labels() map[string]string {
+	return maps.Clone(p.labels)
+}
+
+// labelSuffix renders the labels as {k1=v1,k2=v2} sorted by key, empty without labels
+func (p *BytePool) labelSuffix() string {
+	if len(p.labels) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, k := range slices.Sorted(maps.Keys(p.labels)) {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(k)
+		sb.WriteByte('=')
+		sb.WriteString(p.labels[k])
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
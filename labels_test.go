@@ -0,0 +1,33 @@
+package bytepool
+
+import (
+	"expvar"
+	"testing"
+)
+
+func TestWithLabels(t *testing.T) {
+	labels := map[string]string{"service": "media", "listener": "rtmp"}
+	pool := NewPools([]int{128}, WithLabels(labels))
+	labels["service"] = "changed" // pool must keep its own copy
+
+	got := pool.GetPoolStats()["labels"].(map[string]string)
+	if got["service"] != "media" || got["listener"] != "rtmp" {
+		t.Errorf("Unexpected labels in stats: %v", got)
+	}
+	if pool.Stats().Labels["listener"] != "rtmp" {
+		t.Errorf("Expected labels in report, got %v", pool.Stats().Labels)
+	}
+
+	// expvar names are process-global, publish once so the test can be rerun with -count
+	const name = "labels_test_pool_stats{listener=rtmp,service=media}"
+	if expvar.Get(name) == nil {
+		pool.Expvar("labels_test_")
+	}
+	if expvar.Get(name) == nil {
+		t.Error("Expected labelled expvar name")
+	}
+
+	if _, ok := NewPools([]int{128}).GetPoolStats()["labels"]; ok {
+		t.Error("Expected no labels entry for unlabelled pool")
+	}
+}
@@ -0,0 +1,41 @@
+package bytepool
+
+import (
+	"testing"
+)
+
+func TestWithGetLatency(t *testing.T) {
+	pool := NewPools([]int{128, 256}, WithGetLatency(1), WithBackend(FreeListBackend(8)))
+
+	buf := pool.Get(100) // empty store, fallback allocation
+	pool.Put(buf)
+	pool.Put(pool.Get(100)) // store hit
+
+	tier := pool.Stats().Tiers[0]
+	if tier.HitSamples != 1 || tier.MissSamples != 1 {
+		t.Errorf("Expected 1 hit and 1 miss sample, got %d and %d", tier.HitSamples, tier.MissSamples)
+	}
+	if tier.HitP99 <= 0 || tier.MissP99 <= 0 {
+		t.Errorf("Expected positive p99 latencies, got %v and %v", tier.HitP99, tier.MissP99)
+	}
+	if other := pool.Stats().Tiers[1]; other.HitSamples != 0 || other.MissSamples != 0 {
+		t.Error("Expected untouched tier without samples")
+	}
+}
+
+func TestWithGetLatency_Disabled(t *testing.T) {
+	pool := NewPools([]int{128})
+	pool.Put(pool.Get(100))
+	if pool.state.Load().tiers[0].latency != nil || pool.Stats().Tiers[0].MissSamples != 0 {
+		t.Error("Expected no latency tracking by default")
+	}
+}
+
+// BenchmarkGetLatencySampled 测试采样计时对 Get/Put 的开销
+func BenchmarkGetLatencySampled(b *testing.B) {
+	pool := NewPools(SizePowerOfTwo(), WithGetLatency(64))
+	b.ReportAllocs()
+	for b.Loop() {
+		pool.Put(pool.Get(1000))
+	}
+}
@@ -0,0 +1,22 @@
+package bytepool
+
+import "sync/atomic"
+
+// Outstanding returns the number of pooled buffers currently leased
+// It is the difference between pooled gets and puts, so oversize allocations are not included
+func (p *BytePool) Outstanding() int64 {
+	p.flushStats()
+	return atomic.LoadInt64(&p.totalGet) - atomic.LoadInt64(&p.totalPut)
+}
+
+// CheckLeaks returns the number of outstanding buffers above baseline, a value taken
+// from Outstanding earlier, and emits EventLeakDetected when there are any
+// Tests use it through bytepooltest.VerifyNoLeaks
+func (p *BytePool) CheckLeaks(baseline int64) int64 {
+	leaked := p.Outstanding() - baseline
+	if leaked <= 0 {
+		return 0
+	}
+	p.emit(Event{Kind: EventLeakDetected, Count: leaked, Bytes: p.InUseBytes()})
+	return leaked
+}
@@ -0,0 +1,27 @@
+package bytepool
+
+import "testing"
+
+func TestBytePool_CheckLeaks(t *testing.T) {
+	var leaks []Event
+	pool := NewPools([]int{128, 256}, WithEventHook(func(e Event) {
+		if e.Kind == EventLeakDetected {
+			leaks = append(leaks, e)
+		}
+	}))
+
+	baseline := pool.Outstanding()
+	leaked := pool.Get(100)
+	pool.Put(pool.Get(200))
+	if got := pool.CheckLeaks(baseline); got != 1 {
+		t.Errorf("Expected 1 leaked buffer, got %d", got)
+	}
+	if len(leaks) != 1 || leaks[0].Count != 1 || leaks[0].Bytes != 128 {
+		t.Errorf("Expected one leak event for 128 bytes, got %+v", leaks)
+	}
+
+	pool.Put(leaked)
+	if got := pool.CheckLeaks(baseline); got != 0 || len(leaks) != 1 {
+		t.Errorf("Expected a balanced pool, got %d leaked", got)
+	}
+}
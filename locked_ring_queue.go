@@ -12,9 +12,10 @@ var _ RingQueuer = (*LockedRingQueue[int])(nil)
 type LockedRingQueue[T any] struct {
 	data     []T
 	size     int
-	writePos int // current write position
-	readPos  int // current read position (oldest data)
-	count    int // current number of elements
+	writePos int    // current write position
+	readPos  int    // current read position (oldest data)
+	count    int    // current number of elements
+	seq      uint64 // total number of pushed elements, never rolls back
 	mu       sync.RWMutex
 }
 
@@ -40,6 +41,7 @@ func (lrq *LockedRingQueue[T]) Push(item T) {
 
 	lrq.data[lrq.writePos] = item
 	lrq.writePos = (lrq.writePos + 1) % lrq.size
+	lrq.seq++
 
 	if lrq.count < lrq.size {
 		lrq.count++
@@ -100,6 +102,41 @@ func (lrq *LockedRingQueue[T]) Bytes() []T {
 	return result
 }
 
+// Last returns up to n most recent elements in order (oldest to newest)
+func (lrq *LockedRingQueue[T]) Last(n int) []T {
+	lrq.mu.RLock()
+	defer lrq.mu.RUnlock()
+
+	count := min(max(n, 0), lrq.count)
+	return lrq.window(lrq.count-count, lrq.count)
+}
+
+// Since returns the elements pushed at or after sequence pos and the sequence to pass
+// to the next call, so scrapers only read new samples. Elements already overwritten or
+// popped are skipped; a pos beyond the current sequence restarts from the oldest
+func (lrq *LockedRingQueue[T]) Since(pos uint64) ([]T, uint64) {
+	lrq.mu.RLock()
+	defer lrq.mu.RUnlock()
+
+	oldest := lrq.seq - uint64(lrq.count)
+	if pos > lrq.seq || pos < oldest {
+		pos = oldest
+	}
+	return lrq.window(int(pos-oldest), lrq.count), lrq.seq
+}
+
+// window copies the elements at offsets [from, to) from the oldest, caller holds the lock
+func (lrq *LockedRingQueue[T]) window(from, to int) []T {
+	if from >= to {
+		return nil
+	}
+	result := make([]T, to-from)
+	for i := range result {
+		result[i] = lrq.data[(lrq.readPos+from+i)%lrq.size]
+	}
+	return result
+}
+
 // Len returns the current number of elements
 func (lrq *LockedRingQueue[T]) Len() int {
 	lrq.mu.RLock()
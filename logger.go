@@ -0,0 +1,141 @@
+package bytepool
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// DefaultLogInterval is the minimum time between two log records of the same event class
+const DefaultLogInterval = 10 * time.Second
+
+// maxOversizeLengths bounds how many distinct oversize lengths are remembered
+const maxOversizeLengths = 1024
+
+// event classes reported through the logger
+const (
+	eventOversizeGet  = "oversize_get"
+	eventBudgetBreach = "budget_exceeded"
+	eventForeignPut   = "foreign_put"
+	eventDoublePut    = "double_put"
+	eventUnderflow    = "refcount_underflow"
+	eventPutAnomaly   = "put_anomaly"
+	eventOverflow     = "refcount_overflow"
+)
+
+// eventLogger reports notable pool events to slog at bounded rates
+type eventLogger struct {
+	logger *slog.Logger
+
+	mu       sync.Mutex
+	classes  map[string]*eventRate
+	oversize map[int]struct{} // oversize lengths already reported
+}
+
+// eventRate is the rate limiter state of one event class
+type eventRate struct {
+	next       time.Time
+	suppressed int64 // events dropped since the last record
+}
+
+// WithLogger reports notable events to logger, e.g. the first oversize Get of each length,
+// soft budget breaches and Puts of buffers that match no tier
+// Each event class is logged at most once per interval, see WithLogInterval, and the number
+// of suppressed events is attached to the next record. Without a logger the pool is silent
+func WithLogger(logger *slog.Logger) Option {
+	return func(p *BytePool) {
+		if logger == nil {
+			p.events = nil
+			return
+		}
+		p.events = &eventLogger{
+			logger:   logger,
+			classes:  make(map[string]*eventRate),
+			oversize: make(map[int]struct{}),
+		}
+	}
+}
+
+// WithLogInterval sets the minimum time between two records of the same event class
+// It only has an effect together with WithLogger, zero or negative keeps DefaultLogInterval
+func WithLogInterval(interval time.Duration) Option {
+	return func(p *BytePool) {
+		p.logInterval = interval
+	}
+}
+
+// allow reports whether an event of class may be logged at now
+// Returns the number of events suppressed since the previous record
+func (l *eventLogger) allow(class string, now time.Time, interval time.Duration) (bool, int64) {
+	rate, ok := l.classes[class]
+	if !ok {
+		rate = &eventRate{}
+		l.classes[class] = rate
+	}
+	if now.Before(rate.next) {
+		rate.suppressed++
+		return false, 0
+	}
+	suppressed := rate.suppressed
+	rate.next = now.Add(interval)
+	rate.suppressed = 0
+	return true, suppressed
+}
+
+// logRate returns the configured log interval
+func (p *BytePool) logRate() time.Duration {
+	if p.logInterval <= 0 {
+		return DefaultLogInterval
+	}
+	return p.logInterval
+}
+
+// logEvent writes a record for class unless it is rate limited
+func (p *BytePool) logEvent(level slog.Level, class, msg string, attrs ...slog.Attr) {
+	l := p.events
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	ok, suppressed := l.allow(class, p.now(), p.logRate())
+	l.mu.Unlock()
+	if ok {
+		p.writeEvent(level, class, msg, suppressed, attrs...)
+	}
+}
+
+// writeEvent writes a record for class with the pool labels attached
+func (p *BytePool) writeEvent(level slog.Level, class, msg string, suppressed int64, attrs ...slog.Attr) {
+	attrs = append(attrs, slog.String("event", class))
+	if suppressed > 0 {
+		attrs = append(attrs, slog.Int64("suppressed", suppressed))
+	}
+	for k, v := range p.labels {
+		attrs = append(attrs, slog.String(k, v))
+	}
+	p.events.logger.LogAttrs(context.Background(), level, msg, attrs...)
+}
+
+// logOversizeGet reports the first oversize Get of each length
+func (p *BytePool) logOversizeGet(length int) {
+	l := p.events
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	if _, seen := l.oversize[length]; seen {
+		l.mu.Unlock()
+		return
+	}
+	ok, suppressed := l.allow(eventOversizeGet, p.now(), p.logRate())
+	// a rate limited length stays unseen so it is reported later
+	if ok && len(l.oversize) < maxOversizeLengths {
+		l.oversize[length] = struct{}{}
+	}
+	l.mu.Unlock()
+	if ok {
+		p.writeEvent(slog.LevelWarn, eventOversizeGet, "bytepool: get exceeds largest tier", suppressed,
+			slog.Int("length", length), slog.Int("max_size", p.GetMax()))
+	}
+}
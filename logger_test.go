@@ -0,0 +1,119 @@
+package bytepool
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordHandler collects slog records for inspection
+type recordHandler struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *recordHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h *recordHandler) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h *recordHandler) WithGroup(string) slog.Handler            { return h }
+
+func (h *recordHandler) Handle(_ context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, r.Clone())
+	return nil
+}
+
+// events returns the event attribute of each record
+func (h *recordHandler) events() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var events []string
+	for _, r := range h.records {
+		r.Attrs(func(a slog.Attr) bool {
+			if a.Key == "event" {
+				events = append(events, a.Value.String())
+			}
+			return true
+		})
+	}
+	return events
+}
+
+// attr returns the value of key in the i-th record
+func (h *recordHandler) attr(i int, key string) (slog.Value, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var v slog.Value
+	found := false
+	h.records[i].Attrs(func(a slog.Attr) bool {
+		if a.Key == key {
+			v, found = a.Value, true
+			return false
+		}
+		return true
+	})
+	return v, found
+}
+
+func TestWithLogger_OversizeGet(t *testing.T) {
+	h := &recordHandler{}
+	clock := NewManualClock(time.Unix(0, 0))
+	pool := NewPools([]int{128}, WithClock(clock), WithLogger(slog.New(h)), WithLabels(map[string]string{"listener": "rtmp"}))
+
+	pool.Get(1000)
+	pool.Get(1000) // same length is reported once
+	if got := h.events(); len(got) != 1 || got[0] != eventOversizeGet {
+		t.Fatalf("Expected one oversize record, got %v", got)
+	}
+	if v, ok := h.attr(0, "listener"); !ok || v.String() != "rtmp" {
+		t.Error("Expected labels attached to the record")
+	}
+
+	// a new length within the interval is rate limited, and reported once the interval passed
+	pool.Get(2000)
+	if got := len(h.events()); got != 1 {
+		t.Errorf("Expected rate limited record, got %d records", got)
+	}
+	clock.Advance(DefaultLogInterval)
+	pool.Get(2000)
+	if got := len(h.events()); got != 2 {
+		t.Fatalf("Expected 2 records, got %d", got)
+	}
+	if v, ok := h.attr(1, "suppressed"); !ok || v.Int64() != 1 {
+		t.Errorf("Expected 1 suppressed event, got %v", v)
+	}
+}
+
+func TestWithLogger_BudgetAndForeignPut(t *testing.T) {
+	h := &recordHandler{}
+	clock := NewManualClock(time.Unix(0, 0))
+	pool := NewPools([]int{128}, WithClock(clock), WithLogger(slog.New(h)),
+		WithLogInterval(time.Minute), WithSoftBudget(100, time.Millisecond))
+
+	held := pool.Get(128)
+	done := make(chan []byte)
+	go func() { done <- pool.Get(10) }()
+	waitForWaiters(t, clock, 1)
+	clock.Advance(time.Millisecond)
+	pool.Put(<-done)
+	pool.Put(held)
+
+	pool.Put(make([]byte, 100))
+	pool.Put(make([]byte, 100))
+
+	got := h.events()
+	if len(got) != 2 || got[0] != eventBudgetBreach || got[1] != eventForeignPut {
+		t.Errorf("Expected budget and foreign put records, got %v", got)
+	}
+}
+
+func TestWithLogger_Silent(t *testing.T) {
+	pool := NewPools([]int{128})
+	pool.Get(1000)
+	pool.Put(make([]byte, 100))
+	if pool.events != nil {
+		t.Error("Expected no logger by default")
+	}
+}
@@ -0,0 +1,102 @@
+package bytepool
+
+// LoopCache is a per event loop buffer cache in front of a BytePool, for event-loop
+// frameworks such as gnet, netpoll or evio that allocate on every read event
+// Each loop goroutine owns one cache, so Get and Put take no locks or atomics on a hit
+// Buffers held by the cache still count as leased in the pool statistics until Flush
+//
+//	cache := pool.NewLoopCache(64)
+//	get, put := cache.Funcs()          // plain hooks, e.g. for evio/gnet style codecs
+//	buf := cache.Malloc(n)             // mcache style Malloc/Free, as used by netpoll
+//	cache.Free(buf)
+//
+// It is not safe for concurrent use
+type LoopCache struct {
+	pool    *BytePool
+	perTier int
+	free    map[int][][]byte // idle buffers by capacity
+	hits    int64
+	misses  int64
+}
+
+// NewLoopCache creates a cache keeping up to perTier idle buffers of each tier
+func (p *BytePool) NewLoopCache(perTier int) *LoopCache {
+	if perTier <= 0 {
+		panic("loop cache size must be positive")
+	}
+	return &LoopCache{pool: p, perTier: perTier, free: make(map[int][][]byte)}
+}
+
+// Get retrieves a []byte of the given length, from the loop cache when possible
+func (c *LoopCache) Get(length int) []byte {
+	st := c.pool.state.Load()
+	if length > 0 && length <= st.maxSize {
+		size := st.findBestSize(length)
+		if stack := c.free[size]; len(stack) > 0 {
+			buf := stack[len(stack)-1]
+			c.free[size] = stack[:len(stack)-1]
+			c.hits++
+			return buf[:length]
+		}
+	}
+	c.misses++
+	return c.pool.Get(length)
+}
+
+// Put keeps buf in the loop cache, or returns it to the pool when the tier is full
+func (c *LoopCache) Put(buf []byte) {
+	capacity := cap(buf)
+	st := c.pool.state.Load()
+	t := st.tier(capacity)
+	if t == nil || len(c.free[capacity]) >= c.perTier {
+		c.pool.Put(buf)
+		return
+	}
+	buf = buf[:capacity]
+	c.pool.applyHygiene(t, buf, st.cfg.PoisonByte)
+	c.free[capacity] = append(c.free[capacity], buf)
+}
+
+// Malloc returns a buffer of length size, or of capacity[0] capacity when given,
+// matching the mcache allocator signature
+func (c *LoopCache) Malloc(size int, capacity ...int) []byte {
+	n := size
+	if len(capacity) > 0 && capacity[0] > size {
+		n = capacity[0]
+	}
+	buf := c.Get(n)
+	if buf == nil {
+		// refused by WithMaxGetLength
+		return nil
+	}
+	return buf[:size]
+}
+
+// Free returns a buffer obtained from Malloc
+func (c *LoopCache) Free(buf []byte) {
+	c.Put(buf)
+}
+
+// Funcs returns a Get/Put func pair bound to the cache
+func (c *LoopCache) Funcs() (get func(size int) []byte, put func(buf []byte)) {
+	return c.Get, c.Put
+}
+
+// Flush returns all cached buffers to the pool, e.g. when the loop shuts down
+func (c *LoopCache) Flush() {
+	for size, stack := range c.free {
+		for _, buf := range stack {
+			c.pool.Put(buf)
+		}
+		delete(c.free, size)
+	}
+}
+
+// HitRatio returns the share of Gets served by the loop cache
+func (c *LoopCache) HitRatio() float64 {
+	total := c.hits + c.misses
+	if total == 0 {
+		return 0
+	}
+	return float64(c.hits) / float64(total)
+}
@@ -0,0 +1,75 @@
+package bytepool
+
+import (
+	"testing"
+)
+
+// mcacheAllocator mirrors the Malloc/Free allocator shape of netpoll's mcache
+type mcacheAllocator interface {
+	Malloc(size int, capacity ...int) []byte
+	Free(buf []byte)
+}
+
+var _ mcacheAllocator = (*LoopCache)(nil)
+
+func TestLoopCache(t *testing.T) {
+	pool := NewPools([]int{128, 1024}, WithZeroOnPut())
+	cache := pool.NewLoopCache(2)
+
+	buf := cache.Get(100)
+	copy(buf, "secret")
+	cache.Put(buf)
+	again := cache.Get(50)
+	if &again[0] != &buf[0] || len(again) != 50 {
+		t.Error("Expected the cached buffer to be reused")
+	}
+	if again[0] != 0 {
+		t.Error("Expected pool hygiene applied by the cache")
+	}
+	if cache.HitRatio() != 0.5 {
+		t.Errorf("Expected hit ratio 0.5, got %v", cache.HitRatio())
+	}
+
+	// the cache keeps at most perTier buffers per tier
+	bufs := [][]byte{again, cache.Get(100), cache.Get(100)}
+	for _, b := range bufs {
+		cache.Put(b)
+	}
+	if len(cache.free[128]) != 2 || pool.Outstanding() != 2 {
+		t.Errorf("Expected 2 cached and 2 outstanding, got %d and %d", len(cache.free[128]), pool.Outstanding())
+	}
+	cache.Flush()
+	if pool.Outstanding() != 0 || len(cache.free) != 0 {
+		t.Errorf("Expected Flush to return everything, got %d outstanding", pool.Outstanding())
+	}
+}
+
+func TestLoopCache_Malloc(t *testing.T) {
+	pool := NewPools([]int{128, 1024})
+	cache := pool.NewLoopCache(4)
+
+	var alloc mcacheAllocator = cache
+	buf := alloc.Malloc(10, 500)
+	if len(buf) != 10 || cap(buf) != 1024 {
+		t.Errorf("Expected len 10 cap 1024, got %d and %d", len(buf), cap(buf))
+	}
+	alloc.Free(buf)
+
+	get, put := cache.Funcs()
+	b := get(1024)
+	put(b)
+	put(make([]byte, 10)) // foreign buffers go to the pool, which drops them
+	cache.Flush()
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected balanced pool, got %d", pool.Outstanding())
+	}
+}
+
+// BenchmarkLoopCache 测试事件循环本地缓存的 Get/Put
+func BenchmarkLoopCache(b *testing.B) {
+	cache := NewPools(SizePowerOfTwo()).NewLoopCache(64)
+	b.ReportAllocs()
+	for b.Loop() {
+		cache.Put(cache.Get(4096))
+	}
+}
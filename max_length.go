@@ -0,0 +1,54 @@
+package bytepool
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// WithMaxGetLength refuses Gets longer than n instead of allocating them, so a length
+// field controlled by an attacker cannot turn into a huge make call
+// Get returns nil for such lengths, GetE returns ErrTooLong
+func WithMaxGetLength(n int) Option {
+	if n <= 0 {
+		panic("max get length must be positive")
+	}
+	return func(p *BytePool) {
+		p.initial.MaxGetLength = n
+	}
+}
+
+// refuses reports whether a Get of length exceeds the configured maximum
+func (st *poolState) refuses(length int) bool {
+	return st.cfg.MaxGetLength > 0 && length > st.cfg.MaxGetLength
+}
+
+// refuse counts and returns ErrTooLong when the pool refuses length, nil otherwise
+func (p *BytePool) refuse(length int) error {
+	if st := p.state.Load(); st.refuses(length) {
+		atomic.AddInt64(&p.refused, 1)
+		return fmt.Errorf("%w: %d > %d", ErrTooLong, length, st.cfg.MaxGetLength)
+	}
+	return nil
+}
+
+// GetE is like Get but reports a refused length as ErrTooLong instead of returning nil
+func (p *BytePool) GetE(length int) ([]byte, error) {
+	if err := p.refuse(length); err != nil {
+		return nil, err
+	}
+	buf := p.Get(length)
+	if buf == nil && length > 0 {
+		// refused by WithFaultInjection
+		return nil, fmt.Errorf("%w: %d", ErrTooLong, length)
+	}
+	return buf, nil
+}
+
+// clampLength caps the size of an internal scratch buffer at the max get length, so
+// helpers choosing their own sizes are never refused
+func (p *BytePool) clampLength(n int) int {
+	if limit := p.state.Load().cfg.MaxGetLength; limit > 0 {
+		return min(n, limit)
+	}
+	return n
+}
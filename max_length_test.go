@@ -0,0 +1,252 @@
+package bytepool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestWithMaxGetLength(t *testing.T) {
+	pool := NewPools([]int{128, 1024}, WithMaxGetLength(4096))
+
+	if buf := pool.Get(1 << 30); buf != nil {
+		t.Errorf("Expected refused Get to return nil, got %d bytes", len(buf))
+	}
+	if buf := pool.Get(2000); len(buf) != 2000 {
+		t.Errorf("Expected oversize Get below the limit to allocate, got %d bytes", len(buf))
+	}
+	if _, err := pool.GetE(5000); !errors.Is(err, ErrTooLong) {
+		t.Errorf("Expected ErrTooLong, got %v", err)
+	}
+	buf, err := pool.GetE(100)
+	if len(buf) != 100 || err != nil {
+		t.Errorf("Expected 100 bytes, got %d, %v", len(buf), err)
+	}
+
+	report := pool.Stats()
+	if report.Refused != 2 || report.Discarded != 1 {
+		t.Errorf("Expected 2 refused and 1 discarded, got %d and %d", report.Refused, report.Discarded)
+	}
+
+	if err := pool.ApplyConfig(PoolConfig{Sizes: []int{128}, MaxGetLength: 64}); err != nil {
+		t.Fatal(err)
+	}
+	if pool.Get(100) != nil {
+		t.Error("Expected the applied limit to refuse pooled lengths too")
+	}
+	if err := (PoolConfig{Sizes: []int{128}, MaxGetLength: -1}).Validate(); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Expected invalid config, got %v", err)
+	}
+}
+
+// limitedPool returns a pool refusing Gets above 100 bytes, below every helper's own size
+func limitedPool() *BytePool {
+	return NewPools([]int{128, 1024, 65536}, WithMaxGetLength(100))
+}
+
+func TestWithMaxGetLength_Copy(t *testing.T) {
+	pool := limitedPool()
+	payload := strings.Repeat("x", 1000)
+	var dst bytes.Buffer
+	if n, err := pool.Copy(&dst, strings.NewReader(payload)); n != 1000 || err != nil || dst.String() != payload {
+		t.Errorf("Expected 1000 bytes copied, got %d, %v", n, err)
+	}
+	dst.Reset()
+	if n, err := pool.WriteString(struct{ io.Writer }{&dst}, payload); n != 1000 || err != nil || dst.String() != payload {
+		t.Errorf("Expected 1000 bytes written, got %d, %v", n, err)
+	}
+	if _, err := pool.ReadString(strings.NewReader(payload), 200); !errors.Is(err, ErrTooLong) {
+		t.Errorf("Expected ErrTooLong, got %v", err)
+	}
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected no leased buffers, got %d", pool.Outstanding())
+	}
+}
+
+func TestWithMaxGetLength_RegionAlloc(t *testing.T) {
+	pool := limitedPool()
+	region := pool.Region()
+	if buf := region.Alloc(200); buf != nil {
+		t.Errorf("Expected a refused allocation, got %d bytes", len(buf))
+	}
+	if buf := region.Alloc(60); len(buf) != 60 {
+		t.Errorf("Expected 60 bytes from a clamped block, got %d", len(buf))
+	}
+	region.Reset()
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected the blocks returned, got %d outstanding", pool.Outstanding())
+	}
+}
+
+func TestWithMaxGetLength_GetFramed(t *testing.T) {
+	pool := limitedPool()
+	if buf, payload := pool.GetFramed(200, 4); buf != nil || payload != nil {
+		t.Error("Expected a refused frame")
+	}
+	buf, payload := pool.GetFramed(90, 4)
+	if len(payload) != 90 {
+		t.Errorf("Expected 90 payload bytes, got %d", len(payload))
+	}
+	buf.Release()
+}
+
+func TestWithMaxGetLength_GetStrided(t *testing.T) {
+	pool := limitedPool()
+	if buf, stride := pool.GetStrided(4, 60, 64); buf != nil || stride != 64 {
+		t.Errorf("Expected a refused nil buffer with stride 64, got %v, %d", buf, stride)
+	}
+	buf, _ := pool.GetStrided(1, 60, 16)
+	if buf == nil || buf.Len() != 64 {
+		t.Error("Expected a strided buffer within the limit")
+	}
+	buf.Release()
+}
+
+func TestWithMaxGetLength_Pipe(t *testing.T) {
+	pool := limitedPool()
+	w, r := pool.Pipe(1000)
+	payload := strings.Repeat("y", 300)
+	go func() {
+		w.Write([]byte(payload))
+		w.Close()
+	}()
+	got, err := io.ReadAll(r)
+	if err != nil || string(got) != payload {
+		t.Errorf("Expected the payload through clamped chunks, got %d bytes, %v", len(got), err)
+	}
+}
+
+func TestWithMaxGetLength_ByteBuffer(t *testing.T) {
+	pool := limitedPool()
+	bb := NewByteBufferPool(pool).Get()
+	bb.Write(bytes.Repeat([]byte{'z'}, 60))
+	bb.Write(bytes.Repeat([]byte{'z'}, 60))
+	if len(bb.B) != 120 {
+		t.Errorf("Expected 120 bytes, got %d", len(bb.B))
+	}
+	if report := pool.Stats(); report.Refused != 0 {
+		t.Errorf("Expected no refused Gets, got %d", report.Refused)
+	}
+}
+
+func TestWithMaxGetLength_PooledBytesBuffer(t *testing.T) {
+	pool := limitedPool()
+	b := pool.NewBytesBuffer(50)
+	b.Write(bytes.Repeat([]byte{'z'}, 300))
+	if b.Len() != 300 {
+		t.Errorf("Expected 300 bytes, got %d", b.Len())
+	}
+	b.Close()
+}
+
+func TestWithMaxGetLength_LoopCacheMalloc(t *testing.T) {
+	cache := limitedPool().NewLoopCache(4)
+	if buf := cache.Malloc(10, 200); buf != nil {
+		t.Errorf("Expected a refused Malloc, got %d bytes", len(buf))
+	}
+	if buf := cache.Malloc(10, 50); len(buf) != 10 || cap(buf) != 128 {
+		t.Errorf("Expected 10 bytes of a 128 tier, got %d/%d", len(buf), cap(buf))
+	}
+}
+
+func TestWithMaxGetLength_Chain(t *testing.T) {
+	pool := limitedPool()
+	if chain := pool.GetChain(200); chain.Len() != 0 {
+		t.Errorf("Expected an empty chain, got %d bytes", chain.Len())
+	}
+	chain := pool.NewChain()
+	for range 3 {
+		chain.Append(pool.GetBuffer(80))
+	}
+	if _, err := chain.Peek(200); !errors.Is(err, ErrTooLong) {
+		t.Errorf("Expected ErrTooLong, got %v", err)
+	}
+	chain.Release()
+}
+
+func TestWithMaxGetLength_TransferTo(t *testing.T) {
+	src := NewPools([]int{256})
+	buf := src.Get(200)
+	buf[0] = 7
+	out := src.TransferTo(limitedPool(), buf)
+	if len(out) != 200 || out[0] != 7 {
+		t.Errorf("Expected the data kept, got %d bytes", len(out))
+	}
+}
+
+func TestWithMaxGetLength_WrapConn(t *testing.T) {
+	pool := limitedPool()
+	client, server := net.Pipe()
+	conn := pool.WrapConn(server, 1000)
+	go func() {
+		client.Write([]byte("hello"))
+		client.Close()
+	}()
+	buf, err := conn.ReadBuffer()
+	if err != nil || buf == nil || buf.Len() != 5 {
+		t.Fatalf("Expected 5 bytes, got %v, %v", buf, err)
+	}
+	buf.Release()
+	conn.Close()
+}
+
+func TestWithMaxGetLength_ChainReadN(t *testing.T) {
+	pool := limitedPool()
+	chain := pool.NewChain()
+	for range 3 {
+		chain.Append(pool.GetBuffer(80))
+	}
+	if data, release := chain.ReadN(200); data != nil {
+		release()
+		t.Errorf("Expected a refused ReadN, got %d bytes", len(data))
+	}
+	if chain.Len() != 240 {
+		t.Errorf("Expected the chain untouched, got %d bytes", chain.Len())
+	}
+	data, release := chain.ReadN(90)
+	if len(data) != 90 {
+		t.Errorf("Expected 90 bytes across segments, got %d", len(data))
+	}
+	release()
+	chain.Release()
+}
+
+func TestWithMaxGetLength_ChainFinalize(t *testing.T) {
+	pool := NewPools([]int{128, 1024, 65536}, WithMaxGetLength(100), WithChainConsolidation(4096))
+	chain := pool.NewChain()
+	for i := range 3 {
+		seg := pool.GetBuffer(80)
+		data, done := seg.Bytes()
+		data[0] = byte(i + 1)
+		done()
+		chain.Append(seg)
+	}
+	chain.Finalize()
+	if chain.Len() != 240 || len(chain.Segments()) != 3 || chain.Segments()[2][0] != 3 {
+		t.Errorf("Expected the chain kept in 3 segments, got %d bytes in %d", chain.Len(), len(chain.Segments()))
+	}
+	if report := pool.Stats(); report.Consolidations != 0 {
+		t.Errorf("Expected no consolidation, got %d", report.Consolidations)
+	}
+	chain.Release()
+}
+
+func TestWithMaxGetLength_GetContextGetUpTo(t *testing.T) {
+	pool := limitedPool()
+	if buf, err := pool.GetContext(context.Background(), 200); buf != nil || !errors.Is(err, ErrTooLong) {
+		t.Errorf("Expected ErrTooLong from GetContext, got %v", err)
+	}
+	if buf, err := pool.GetUpTo(200); buf != nil || !errors.Is(err, ErrTooLong) {
+		t.Errorf("Expected ErrTooLong from GetUpTo, got %v", err)
+	}
+	if buf, err := pool.GetUpTo(90); len(buf) != 90 || err != nil {
+		t.Errorf("Expected 90 bytes, got %d, %v", len(buf), err)
+	}
+	if report := pool.Stats(); report.Refused != 2 {
+		t.Errorf("Expected 2 refused Gets, got %d", report.Refused)
+	}
+}
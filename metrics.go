@@ -0,0 +1,128 @@
+package bytepool
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"strings"
+)
+
+// MetricDescription describes a pool metric in the runtime/metrics naming convention
+// Names have the form /bytepool/<name>:<unit> and are stable across releases, a
+// renamed field in Report does not change them
+type MetricDescription struct {
+	Name        string `json:"name"`
+	Unit        string `json:"unit"` // the part of Name after the colon
+	Description string `json:"description"`
+	Cumulative  bool   `json:"cumulative"` // monotonically increasing counter, otherwise a gauge
+}
+
+// MetricSample is the value of a metric at the time of sampling
+type MetricSample struct {
+	Name  string `json:"name"`
+	Value int64  `json:"value"`
+}
+
+type metricDef struct {
+	name        string
+	description string
+	cumulative  bool
+	value       func(*Report) int64
+}
+
+// metricDefs is append-only, existing names must never change
+var metricDefs = []metricDef{
+	{"/bytepool/heap-held:bytes", "Bytes held by the pool, leased plus idle", false,
+		func(r *Report) int64 { return r.HeldBytes }},
+	{"/bytepool/heap-in-use:bytes", "Bytes held by leased buffers", false,
+		func(r *Report) int64 { return r.InUseBytes }},
+	{"/bytepool/heap-idle:bytes", "Bytes held by idle buffers, approximate for sync.Pool backends", false,
+		func(r *Report) int64 { return r.IdleBytes }},
+	{"/bytepool/tiers:tiers", "Number of size tiers", false,
+		func(r *Report) int64 { return int64(len(r.Tiers)) }},
+	{"/bytepool/gets:calls", "Get calls served by a tier", true,
+		func(r *Report) int64 { return r.TotalGet }},
+	{"/bytepool/puts:calls", "Put calls returning a buffer to a tier", true,
+		func(r *Report) int64 { return r.TotalPut }},
+	{"/bytepool/discards:buffers", "Buffers not pooled, for any reason", true,
+		func(r *Report) int64 { return r.Discarded }},
+	{"/bytepool/inefficient-gets:calls", "Gets using little of the tier size", true,
+		func(r *Report) int64 { return r.Inefficient }},
+	{"/bytepool/throttled:calls", "Gets throttled by the budget", true,
+		func(r *Report) int64 { return r.Throttled }},
+	{"/bytepool/degraded:calls", "GetChain calls served by smaller tiers", true,
+		func(r *Report) int64 { return r.Degraded }},
+	{"/bytepool/tier-fallbacks:calls", "Gets served by a larger tier", true,
+		func(r *Report) int64 { return r.TierFallbacks }},
+	{"/bytepool/spilled:calls", "GetBuffer calls served from a temp file", true,
+		func(r *Report) int64 { return r.Spilled }},
+	{"/bytepool/spilled:bytes", "Bytes served from temp files", true,
+		func(r *Report) int64 { return r.SpilledBytes }},
+	{"/bytepool/spilled-in-use:bytes", "Spilled bytes not yet released", false,
+		func(r *Report) int64 { return r.SpilledInUse }},
+	{"/bytepool/refused:calls", "Gets above MaxGetLength", true,
+		func(r *Report) int64 { return r.Refused }},
+	{"/bytepool/retains-expired:references", "RetainFor references released by their deadline", true,
+		func(r *Report) int64 { return r.RetainsExpired }},
+	{"/bytepool/events-dropped:events", "Events not delivered to a full Events channel", true,
+		func(r *Report) int64 { return r.EventsDropped }},
+}
+
+// MetricsSchema returns the descriptions of all metrics published by Metrics, Expvar
+// and MetricsHandler
+func MetricsSchema() []MetricDescription {
+	out := make([]MetricDescription, 0, len(metricDefs))
+	for _, def := range metricDefs {
+		out = append(out, MetricDescription{
+			Name:        def.name,
+			Unit:        def.name[strings.LastIndexByte(def.name, ':')+1:],
+			Description: def.description,
+			Cumulative:  def.cumulative,
+		})
+	}
+	return out
+}
+
+// Metrics samples all metrics in MetricsSchema order
+func (p *BytePool) Metrics() []MetricSample {
+	report := p.Stats()
+	out := make([]MetricSample, 0, len(metricDefs))
+	for _, def := range metricDefs {
+		out = append(out, MetricSample{Name: def.name, Value: def.value(&report)})
+	}
+	return out
+}
+
+// metricsMap returns the metrics keyed by name
+func (p *BytePool) metricsMap() map[string]int64 {
+	samples := p.Metrics()
+	out := make(map[string]int64, len(samples))
+	for _, s := range samples {
+		out[s.Name] = s.Value
+	}
+	return out
+}
+
+// MetricsHandler returns a debug handler serving the metrics as a JSON object keyed by
+// metric name, or the schema with ?schema=1
+func (p *BytePool) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var v any
+		if r.URL.Query().Get("schema") != "" {
+			v = MetricsSchema()
+		} else {
+			v = p.metricsMap()
+		}
+		if err := json.NewEncoder(w).Encode(v); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// publishMetrics publishes the metrics to expvar keyed by metric name
+func (p *BytePool) publishMetrics(prefix string) {
+	expvar.Publish(prefix+"metrics"+p.labelSuffix(), expvar.Func(func() any {
+		return p.metricsMap()
+	}))
+}
@@ -0,0 +1,70 @@
+package bytepool
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestMetricsSchema(t *testing.T) {
+	// the runtime/metrics name format
+	valid := regexp.MustCompile(`^/bytepool/[a-z-]+:[a-z]+$`)
+	seen := make(map[string]bool)
+	for _, desc := range MetricsSchema() {
+		if !valid.MatchString(desc.Name) {
+			t.Errorf("Expected runtime/metrics style name, got %q", desc.Name)
+		}
+		if seen[desc.Name] {
+			t.Errorf("Expected unique name, got %q twice", desc.Name)
+		}
+		seen[desc.Name] = true
+		if desc.Unit == "" || desc.Description == "" {
+			t.Errorf("Expected unit and description for %q", desc.Name)
+		}
+	}
+	if !seen["/bytepool/heap-held:bytes"] {
+		t.Error("Expected /bytepool/heap-held:bytes in the schema")
+	}
+}
+
+func TestBytePool_Metrics(t *testing.T) {
+	pool := NewPools([]int{128, 1024})
+	buf := pool.Get(100)
+
+	samples := pool.Metrics()
+	if len(samples) != len(MetricsSchema()) {
+		t.Fatalf("Expected a sample per metric, got %d", len(samples))
+	}
+	values := pool.metricsMap()
+	if values["/bytepool/heap-in-use:bytes"] != 128 || values["/bytepool/gets:calls"] != 1 {
+		t.Errorf("Unexpected metrics: %v", values)
+	}
+	pool.Put(buf)
+
+	rec := httptest.NewRecorder()
+	pool.MetricsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/debug/bytepool", nil))
+	var served map[string]int64
+	if err := json.Unmarshal(rec.Body.Bytes(), &served); err != nil {
+		t.Fatal(err)
+	}
+	if served["/bytepool/puts:calls"] != 1 || served["/bytepool/tiers:tiers"] != 2 {
+		t.Errorf("Unexpected served metrics: %v", served)
+	}
+
+	rec = httptest.NewRecorder()
+	pool.MetricsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/debug/bytepool?schema=1", nil))
+	var schema []MetricDescription
+	if err := json.Unmarshal(rec.Body.Bytes(), &schema); err != nil || len(schema) != len(MetricsSchema()) {
+		t.Errorf("Expected the schema, got %d entries (%v)", len(schema), err)
+	}
+
+	// expvar names are process-global, publish once so the test can be rerun with -count
+	if expvar.Get("metrics_test_metrics") == nil {
+		pool.Expvar("metrics_test_")
+	}
+	if expvar.Get("metrics_test_metrics") == nil {
+		t.Error("Expected metrics published to expvar")
+	}
+}
@@ -0,0 +1,170 @@
+package bytepool
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+)
+
+// MisusePolicy is the reaction of a pool to a misuse condition
+type MisusePolicy int
+
+const (
+	// MisuseDefault keeps the built-in reaction of each condition, see MisuseKind
+	MisuseDefault MisusePolicy = iota
+	// MisusePanic panics with a description naming the pool, for fail-fast staging builds
+	MisusePanic
+	// MisuseError records the misuse as an error wrapping ErrMisuse, returned by MisuseErr
+	MisuseError
+	// MisuseLog reports the misuse through the logger set by WithLogger, rate limited
+	MisuseLog
+	// MisuseIgnore only counts the misuse in Report.Misuses
+	MisuseIgnore
+)
+
+// String returns the name of the policy
+func (m MisusePolicy) String() string {
+	switch m {
+	case MisuseDefault:
+		return "default"
+	case MisusePanic:
+		return "panic"
+	case MisuseError:
+		return "error"
+	case MisuseLog:
+		return "log"
+	case MisuseIgnore:
+		return "ignore"
+	default:
+		return "MisusePolicy(" + strconv.Itoa(int(m)) + ")"
+	}
+}
+
+// MisuseKind is a misuse condition detected by the pool
+type MisuseKind int
+
+const (
+	// MisuseDoublePut is a Put of a buffer already returned and not leased since, detected
+	// in debug mode and with StatsHoldTimes; the second Put is dropped. Panics in debug
+	// mode by default, ignored otherwise
+	MisuseDoublePut MisuseKind = iota
+	// MisuseForeignPut is a Put of a buffer matching no tier, logged by default, or of a
+	// buffer leased from another debug pool, which panics by default
+	MisuseForeignPut
+	// MisuseRefCountUnderflow is a Buffer released more often than retained. Panics in
+	// debug mode by default, ignored otherwise
+	MisuseRefCountUnderflow
+	// MisuseOversize is a Get above the largest tier, logged by default, or a Put of such a
+	// buffer, ignored by default
+	MisuseOversize
+	// MisuseRefCountOverflow is a Buffer retained past its limit, see WithMaxRefCount. The
+	// buffer saturates and is never pooled again. Panics in debug mode by default, logged
+	// otherwise
+	MisuseRefCountOverflow
+
+	numMisuseKinds
+)
+
+// String returns the name of the condition
+func (k MisuseKind) String() string {
+	switch k {
+	case MisuseDoublePut:
+		return "double_put"
+	case MisuseForeignPut:
+		return "foreign_put"
+	case MisuseRefCountUnderflow:
+		return "refcount_underflow"
+	case MisuseOversize:
+		return "oversize"
+	case MisuseRefCountOverflow:
+		return "refcount_overflow"
+	default:
+		return "MisuseKind(" + strconv.Itoa(int(k)) + ")"
+	}
+}
+
+// MisuseStats counts the detected misuse by condition
+type MisuseStats struct {
+	DoublePut         int64 `json:"double_put"`
+	ForeignPut        int64 `json:"foreign_put"`
+	RefCountUnderflow int64 `json:"refcount_underflow"`
+	Oversize          int64 `json:"oversize"`
+	RefCountOverflow  int64 `json:"refcount_overflow"`
+}
+
+// WithMisusePolicy applies policy to the given misuse conditions, or to all of them when
+// none are given, e.g. MisusePanic in staging and MisuseLog in production
+// Every detected misuse is counted in Report.Misuses whatever the policy
+func WithMisusePolicy(policy MisusePolicy, kinds ...MisuseKind) Option {
+	if policy < MisuseDefault || policy > MisuseIgnore {
+		panic("unknown misuse policy")
+	}
+	for _, kind := range kinds {
+		if kind < 0 || kind >= numMisuseKinds {
+			panic("unknown misuse kind")
+		}
+	}
+	return func(p *BytePool) {
+		if len(kinds) == 0 {
+			for kind := range p.misusePolicies {
+				p.misusePolicies[kind] = policy
+			}
+			return
+		}
+		for _, kind := range kinds {
+			p.misusePolicies[kind] = policy
+		}
+	}
+}
+
+// MisuseErr returns the latest misuse recorded under MisuseError, nil if none
+func (p *BytePool) MisuseErr() error {
+	if err := p.misuseErr.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+// debugPolicy is the default reaction to conditions that are only fatal in debug mode
+func (p *BytePool) debugPolicy() MisusePolicy {
+	if p.debug {
+		return MisusePanic
+	}
+	return MisuseIgnore
+}
+
+// misuse counts a misuse of kind and applies its policy, def when none is configured
+// It reports whether the caller should log the misuse
+func (p *BytePool) misuse(kind MisuseKind, def MisusePolicy, format string, args ...any) bool {
+	atomic.AddInt64(&p.misuses[kind], 1)
+	policy := p.misusePolicies[kind]
+	if policy == MisuseDefault {
+		policy = def
+	}
+	switch policy {
+	case MisusePanic:
+		panic(fmt.Sprintf("bytepool: "+format+" into %s", append(args, p.describe())...))
+	case MisuseError:
+		err := fmt.Errorf("%w: %s: "+format+" into %s", append([]any{ErrMisuse, kind}, append(args, p.describe())...)...)
+		p.misuseErr.Store(&err)
+	case MisuseLog:
+		return true
+	}
+	return false
+}
+
+// misused loads the misuse counter of kind, restored misuse included
+func (p *BytePool) misused(kind MisuseKind) int64 {
+	return loadCounter(&p.misuses[kind], p.restoredStats().Misuses[kind.String()])
+}
+
+// misuseStats returns the misuse counters
+func (p *BytePool) misuseStats() MisuseStats {
+	return MisuseStats{
+		DoublePut:         p.misused(MisuseDoublePut),
+		ForeignPut:        p.misused(MisuseForeignPut),
+		RefCountUnderflow: p.misused(MisuseRefCountUnderflow),
+		Oversize:          p.misused(MisuseOversize),
+		RefCountOverflow:  p.misused(MisuseRefCountOverflow),
+	}
+}
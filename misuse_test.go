@@ -0,0 +1,120 @@
+package bytepool
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestMisuse_DoublePutPanicsInDebug(t *testing.T) {
+	pool := NewPools([]int{128}, WithDebug())
+	buf := pool.Get(100)
+	pool.Put(buf)
+	defer func() {
+		msg, _ := recover().(string)
+		if !strings.Contains(msg, "put twice") {
+			t.Errorf("Expected double put panic, got %q", msg)
+		}
+	}()
+	pool.Put(buf)
+}
+
+func TestWithMisusePolicy_Error(t *testing.T) {
+	pool := NewPools([]int{128}, WithDebug(), WithBackend(FreeListBackend(4)), WithMisusePolicy(MisuseError))
+	buf := pool.Get(100)
+	pool.Put(buf)
+	pool.Put(buf)
+
+	if err := pool.MisuseErr(); !errors.Is(err, ErrMisuse) || !strings.Contains(err.Error(), "double_put") {
+		t.Errorf("Expected double put error, got %v", err)
+	}
+	// the second Put was dropped, so the buffer is handed out once
+	a, b := pool.Get(100), pool.Get(100)
+	if unsafe.SliceData(a) == unsafe.SliceData(b) {
+		t.Error("Expected the double put buffer pooled once")
+	}
+	if report := pool.Stats(); report.Misuses.DoublePut != 1 || report.TotalPut != 1 {
+		t.Errorf("Expected 1 double put and 1 counted put, got %+v, %d", report.Misuses, report.TotalPut)
+	}
+
+	released := pool.GetBuffer(10)
+	released.Release()
+	released.Release()
+	if report := pool.Stats(); report.Misuses.RefCountUnderflow != 1 {
+		t.Errorf("Expected 1 refcount underflow, got %+v", report.Misuses)
+	}
+}
+
+func TestWithMisusePolicy_PerKind(t *testing.T) {
+	h := &recordHandler{}
+	pool := NewPools([]int{128}, WithLogger(slog.New(h)),
+		WithMisusePolicy(MisuseIgnore, MisuseOversize, MisuseForeignPut),
+		WithMisusePolicy(MisuseLog, MisuseRefCountUnderflow))
+
+	pool.Get(1000)
+	pool.Put(make([]byte, 100))
+	buf := pool.GetBuffer(10)
+	buf.Release()
+	buf.Release()
+
+	if got := h.events(); len(got) != 1 || got[0] != eventUnderflow {
+		t.Errorf("Expected only the underflow logged, got %v", got)
+	}
+	want := MisuseStats{ForeignPut: 1, RefCountUnderflow: 1, Oversize: 1}
+	if got := pool.Stats().Misuses; got != want {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+	if pool.MisuseErr() != nil {
+		t.Errorf("Expected no misuse error, got %v", pool.MisuseErr())
+	}
+}
+
+func TestMisuse_UnderflowPanicsInDebug(t *testing.T) {
+	pool := NewPools([]int{128}, WithDebug())
+	buf := pool.GetBuffer(10)
+	buf.Release()
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected refcount underflow panic in debug mode")
+		}
+	}()
+	buf.Release()
+}
+
+func TestMisuse_RefCountOverflowSaturates(t *testing.T) {
+	pool := NewPools([]int{128}, WithMaxRefCount(3), WithMisusePolicy(MisuseError, MisuseRefCountOverflow))
+	buf := pool.GetBuffer(10)
+	for range 5 {
+		buf.Retain()
+	}
+	if err := pool.MisuseErr(); !errors.Is(err, ErrMisuse) || !strings.Contains(err.Error(), "refcount_overflow") {
+		t.Errorf("Expected refcount overflow error, got %v", err)
+	}
+
+	// a saturated buffer stays valid however often it is released
+	for range 10 {
+		buf.Release()
+	}
+	if data, release := buf.Bytes(); len(data) != 10 {
+		t.Errorf("Expected saturated buffer kept alive, got %d bytes", len(data))
+	} else {
+		release()
+	}
+	report := pool.Stats()
+	if report.Misuses.RefCountOverflow != 1 || report.Misuses.RefCountUnderflow != 0 || report.TotalPut != 0 {
+		t.Errorf("Expected one overflow and the buffer never pooled, got %+v and %d puts", report.Misuses, report.TotalPut)
+	}
+}
+
+func TestMisuse_RefCountOverflowPanicsInDebug(t *testing.T) {
+	pool := NewPools([]int{128}, WithDebug(), WithMaxRefCount(1))
+	buf := pool.GetBuffer(10)
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected refcount overflow panic in debug mode")
+		}
+	}()
+	buf.Retain()
+}
@@ -0,0 +1,8 @@
+package bytepool
+
+// AdoptMmap wraps a memory-mapped region in a Buffer with a reference count of 1
+// When the count reaches zero unmap is called instead of returning the data to a pool,
+// so file-served payloads flow through the same Buffer fan-out as pooled data
+func AdoptMmap(data []byte, unmap func()) *Buffer {
+	return NewBufferWithRelease(data, unmap)
+}
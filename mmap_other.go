@@ -0,0 +1,13 @@
+//go:build !unix
+
+package bytepool
+
+import "errors"
+
+// mmapSupported reports whether memory mapping is available on this platform
+const mmapSupported = false
+
+// NewBufferFromMmap is not supported on this platform
+func NewBufferFromMmap(path string) (*Buffer, error) {
+	return nil, errors.New("bytepool: mmap is not supported on this platform")
+}
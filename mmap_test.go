@@ -0,0 +1,59 @@
+//go:build unix
+
+package bytepool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAdoptMmap(t *testing.T) {
+	unmapped := 0
+	buf := AdoptMmap([]byte("payload"), func() { unmapped++ })
+
+	data, release := buf.Bytes()
+	if string(data) != "payload" {
+		t.Errorf("Expected payload, got %q", data)
+	}
+	buf.Release()
+	if unmapped != 0 {
+		t.Error("Expected mapping to stay while referenced")
+	}
+	release()
+	if unmapped != 1 {
+		t.Errorf("Expected unmap once, got %d", unmapped)
+	}
+}
+
+func TestNewBufferFromMmap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "segment.ts")
+	if err := os.WriteFile(path, []byte("file served payload"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := NewBufferFromMmap(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, release := buf.UnsafeString()
+	if s != "file served payload" {
+		t.Errorf("Unexpected content %q", s)
+	}
+	release()
+	buf.Release()
+
+	empty := filepath.Join(t.TempDir(), "empty")
+	if err := os.WriteFile(empty, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	buf, err = NewBufferFromMmap(empty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf.Release()
+
+	if _, err := NewBufferFromMmap(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
@@ -0,0 +1,35 @@
+//go:build unix
+
+package bytepool
+
+import (
+	"os"
+	"syscall"
+)
+
+// mmapSupported reports whether memory mapping is available on this platform
+const mmapSupported = true
+
+// NewBufferFromMmap maps the file at path read-only and adopts it with AdoptMmap
+// The returned Buffer must not be written to; the mapping is removed on final release
+func NewBufferFromMmap(path string) (*Buffer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() == 0 {
+		return AdoptMmap(nil, func() {}), nil
+	}
+
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(info.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+	return AdoptMmap(data, func() { _ = syscall.Munmap(data) }), nil
+}
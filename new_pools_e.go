@@ -0,0 +1,51 @@
+package bytepool
+
+import (
+	"errors"
+	"fmt"
+)
+
+// maxTiers bounds the number of tiers accepted by NewPoolsE
+const maxTiers = 1024
+
+// NewPoolsE is like NewPools but validates the sizes and the configuration assembled by
+// the options, returning the problems wrapped in ErrInvalidConfig instead of panicking
+// or silently merging duplicate sizes. Sizes need not be sorted
+func NewPoolsE(sizes []int, opts ...Option) (*BytePool, error) {
+	if err := validateSizes(sizes); err != nil {
+		return nil, err
+	}
+	pool := configure(sizes, opts)
+	if err := pool.initial.Validate(); err != nil {
+		return nil, err
+	}
+	pool.start()
+	return pool, nil
+}
+
+// validateSizes reports all problems of a tier size list at once
+func validateSizes(sizes []int) error {
+	var errs []error
+	invalid := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%w: "+format, append([]any{ErrInvalidConfig}, args...)...))
+	}
+	if len(sizes) == 0 {
+		invalid("sizes is empty")
+	}
+	if len(sizes) > maxTiers {
+		invalid("%d sizes exceed the limit of %d tiers", len(sizes), maxTiers)
+	}
+	seen := make(map[int]int, len(sizes))
+	for i, size := range sizes {
+		if size <= 0 {
+			invalid("size %d at index %d must be positive", size, i)
+			continue
+		}
+		if first, ok := seen[size]; ok {
+			invalid("size %d at index %d duplicates index %d", size, i, first)
+			continue
+		}
+		seen[size] = i
+	}
+	return errors.Join(errs...)
+}
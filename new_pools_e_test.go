@@ -0,0 +1,43 @@
+package bytepool
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewPoolsE(t *testing.T) {
+	pool, err := NewPoolsE([]int{1024, 128})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sizes := pool.GetAvailableSizes(); len(sizes) != 2 || sizes[0] != 128 {
+		t.Errorf("Expected sorted sizes, got %v", sizes)
+	}
+
+	tests := []struct {
+		name  string
+		sizes []int
+		opts  []Option
+		want  string
+	}{
+		{"empty", nil, nil, "sizes is empty"},
+		{"negative", []int{128, -1}, nil, "size -1 at index 1 must be positive"},
+		{"duplicate", []int{128, 256, 128}, nil, "size 128 at index 2 duplicates index 0"},
+		{"too many", make([]int, maxTiers+1), nil, "exceed the limit"},
+		{"invalid option", []int{128}, []Option{func(p *BytePool) { p.initial.MinEfficiency = 2 }}, "min efficiency 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.name == "too many" {
+				for i := range tt.sizes {
+					tt.sizes[i] = i + 1
+				}
+			}
+			pool, err := NewPoolsE(tt.sizes, tt.opts...)
+			if pool != nil || !errors.Is(err, ErrInvalidConfig) || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !race
+
+package bytepool
+
+// raceEnabled reports whether the race detector is on, sync.Pool drops items at random then
+const raceEnabled = false
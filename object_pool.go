@@ -0,0 +1,51 @@
+package bytepool
+
+// ObjectPool pools values of a struct type whose []byte fields are leased from a
+// BytePool, e.g. a Packet with Header and Payload. Get leases the fields together with
+// the value and Put returns them, so callers don't manage the byte tiers by hand
+type ObjectPool[T any] struct {
+	values *Pool[*T]
+	bytes  *BytePool
+	fields []func(*T) *[]byte
+}
+
+// NewObjectPool creates an ObjectPool whose values lease the fields selected by fields
+// from bytes, e.g. func(p *Packet) *[]byte { return &p.Payload }
+func NewObjectPool[T any](bytes *BytePool, fields ...func(*T) *[]byte) *ObjectPool[T] {
+	return &ObjectPool[T]{
+		values: NewPool(func() *T { return new(T) }),
+		bytes:  bytes,
+		fields: fields,
+	}
+}
+
+// Get returns a zeroed value whose fields are leased with the given lengths, in the
+// order the fields were passed to NewObjectPool. Fields without a positive length stay nil
+// Panics when given more lengths than fields
+func (o *ObjectPool[T]) Get(lengths ...int) *T {
+	if len(lengths) > len(o.fields) {
+		panic("more lengths than pooled fields")
+	}
+	v := o.values.Get()
+	for i, length := range lengths {
+		*o.fields[i](v) = o.bytes.Get(length)
+	}
+	return v
+}
+
+// Put returns the pooled fields of v to the byte pool, zeroes v and pools it
+// Fields may have been shortened like buf[:n] but not advanced like buf[n:], which
+// changes the capacity. v must not be used afterwards
+func (o *ObjectPool[T]) Put(v *T) {
+	if v == nil {
+		return
+	}
+	for _, field := range o.fields {
+		if buf := *field(v); buf != nil {
+			o.bytes.Put(buf)
+		}
+	}
+	var zero T
+	*v = zero
+	o.values.Put(v)
+}
@@ -0,0 +1,46 @@
+package bytepool
+
+import "testing"
+
+type testPacket struct {
+	Seq     int
+	Header  []byte
+	Payload []byte
+}
+
+func TestObjectPool(t *testing.T) {
+	pool := NewPools([]int{64, 1024})
+	packets := NewObjectPool(pool,
+		func(p *testPacket) *[]byte { return &p.Header },
+		func(p *testPacket) *[]byte { return &p.Payload },
+	)
+
+	pkt := packets.Get(16, 1000)
+	if len(pkt.Header) != 16 || cap(pkt.Header) != 64 || len(pkt.Payload) != 1000 || cap(pkt.Payload) != 1024 {
+		t.Errorf("Expected pooled fields, got header %d/%d and payload %d/%d",
+			len(pkt.Header), cap(pkt.Header), len(pkt.Payload), cap(pkt.Payload))
+	}
+	pkt.Seq = 7
+	pkt.Payload = pkt.Payload[:10]
+	if pool.Outstanding() != 2 {
+		t.Errorf("Expected 2 leased buffers, got %d", pool.Outstanding())
+	}
+
+	packets.Put(pkt)
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected the fields returned, got %d outstanding", pool.Outstanding())
+	}
+	if pkt.Seq != 0 || pkt.Header != nil || pkt.Payload != nil {
+		t.Errorf("Expected the value zeroed, got %+v", pkt)
+	}
+
+	// a field without a length stays nil
+	pkt = packets.Get(16)
+	if pkt.Payload != nil {
+		t.Errorf("Expected nil payload, got %d bytes", len(pkt.Payload))
+	}
+	packets.Put(pkt)
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected the header returned, got %d outstanding", pool.Outstanding())
+	}
+}
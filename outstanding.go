@@ -0,0 +1,38 @@
+package bytepool
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+)
+
+// DumpOutstanding writes one line per currently leased buffer with its size, its age and,
+// for the sampled share of Gets, the caller that leased it, oldest first, to attach to
+// bug reports when the pool is starved. Leases are tracked in debug mode and while
+// StatsHoldTimes is on, returns ErrNotTracked otherwise
+// Buffers leased before tracking started and buffers that were never returned but
+// collected by GC are not distinguished, the latter show up with a growing age
+func (p *BytePool) DumpOutstanding(w io.Writer) error {
+	if !p.tracksLeases() {
+		return ErrNotTracked
+	}
+	var leases []leaseRecord
+	p.leases.Range(func(_, v any) bool {
+		if rec, ok := v.(leaseRecord); ok {
+			leases = append(leases, rec)
+		}
+		return true
+	})
+	slices.SortFunc(leases, func(a, b leaseRecord) int { return a.at.Compare(b.at) })
+
+	now := p.now()
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s: %d outstanding buffers at %s\n", p.describe(), len(leases), now.Format(time.RFC3339Nano))
+	for _, rec := range leases {
+		fmt.Fprintf(bw, "size=%d age=%s site=%s\n", rec.size, now.Sub(rec.at), cmp.Or(rec.site, "unsampled"))
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,53 @@
+package bytepool
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBytePool_DumpOutstanding(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	pool := NewPools([]int{128, 1024}, WithDebug(), WithClock(clock))
+
+	old := pool.Get(1000)
+	clock.Advance(time.Minute)
+	var held [][]byte
+	for range 16 {
+		held = append(held, pool.Get(100))
+	}
+	pool.Put(held[0])
+
+	var out bytes.Buffer
+	if err := pool.DumpOutstanding(&out); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 17 || !strings.Contains(lines[0], "16 outstanding buffers") {
+		t.Fatalf("Expected a header and 16 buffers, got:\n%s", out.String())
+	}
+	if !strings.HasPrefix(lines[1], "size=1024 age=1m0s") {
+		t.Errorf("Expected the oldest buffer first, got %q", lines[1])
+	}
+	for _, line := range lines[1:] {
+		if !strings.HasSuffix(line, "site=unsampled") && !strings.Contains(line, "outstanding_test.go") {
+			t.Errorf("Expected the caller or unsampled, got %q", line)
+		}
+	}
+
+	pool.Put(old)
+	for _, buf := range held[1:] {
+		pool.Put(buf)
+	}
+	out.Reset()
+	pool.DumpOutstanding(&out)
+	if !strings.Contains(out.String(), " 0 outstanding buffers") {
+		t.Errorf("Expected no outstanding buffers, got %q", out.String())
+	}
+
+	if err := NewPools([]int{128}).DumpOutstanding(&out); !errors.Is(err, ErrNotTracked) {
+		t.Errorf("Expected ErrNotTracked outside debug mode, got %v", err)
+	}
+}
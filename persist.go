@@ -0,0 +1,127 @@
+package bytepool
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+// statsFormatVersion is the version of the SaveStats format
+const statsFormatVersion = 1
+
+// savedStats is the persisted form of the cumulative counters
+// Loaded values are kept apart from the live counters, so in-use figures and leak
+// checks only reflect buffers leased by the running process
+type savedStats struct {
+	Version           int               `json:"version"`
+	Tiers             map[int]savedTier `json:"tiers"`
+	TotalGet          int64             `json:"total_get"`
+	TotalPut          int64             `json:"total_put"`
+	Discarded         int64             `json:"discarded"`
+	Inefficient       int64             `json:"inefficient_get"`
+	Throttled         int64             `json:"throttled"`
+	Degraded          int64             `json:"degraded"`
+	Consolidations    int64             `json:"consolidations"`
+	ConsolidatedBytes int64             `json:"consolidated_bytes"`
+	Discards          map[string]int64  `json:"discards,omitempty"` // by DiscardReason name
+	TierFallbacks     int64             `json:"tier_fallback,omitempty"`
+	Spilled           int64             `json:"spilled,omitempty"`
+	SpilledBytes      int64             `json:"spilled_bytes,omitempty"`
+	RetainsExpired    int64             `json:"retains_expired,omitempty"`
+	EventsDropped     int64             `json:"events_dropped,omitempty"`
+	Refused           int64             `json:"refused,omitempty"`
+	Wiped             int64             `json:"wiped,omitempty"`
+	GrowthCopies      int64             `json:"growth_copies,omitempty"`
+	GrowthCopiedBytes int64             `json:"growth_copied_bytes,omitempty"`
+	PutAnomalies      int64             `json:"put_anomalies,omitempty"`
+	Misuses           map[string]int64  `json:"misuses,omitempty"` // by MisuseKind name
+	RecentLengths     []int             `json:"recent_lengths"`    // tracker samples, the source of LengthHistogram
+}
+
+// savedTier holds the cumulative counters of one tier
+type savedTier struct {
+	Get int64 `json:"get"`
+	Put int64 `json:"put"`
+}
+
+// SaveStats writes the cumulative counters and the recent-length samples to w as JSON
+// Gauges such as in-use and idle bytes describe the running process and are not saved
+// Together with LoadStats, counters of daemons that restart regularly continue where
+// they stopped instead of dropping to zero
+func (p *BytePool) SaveStats(w io.Writer) error {
+	r := p.Stats()
+	saved := savedStats{
+		Version:           statsFormatVersion,
+		Tiers:             make(map[int]savedTier, len(r.Tiers)),
+		TotalGet:          r.TotalGet,
+		TotalPut:          r.TotalPut,
+		Discarded:         r.Discarded,
+		Inefficient:       r.Inefficient,
+		Throttled:         r.Throttled,
+		Degraded:          r.Degraded,
+		Consolidations:    r.Consolidations,
+		ConsolidatedBytes: r.ConsolidatedBytes,
+		Discards:          make(map[string]int64, numDiscardReasons),
+		TierFallbacks:     r.TierFallbacks,
+		Spilled:           r.Spilled,
+		SpilledBytes:      r.SpilledBytes,
+		RetainsExpired:    r.RetainsExpired,
+		EventsDropped:     r.EventsDropped,
+		Refused:           r.Refused,
+		Wiped:             r.Wiped,
+		GrowthCopies:      r.GrowthCopies,
+		GrowthCopiedBytes: r.GrowthCopiedBytes,
+		PutAnomalies:      r.PutAnomalies,
+		Misuses:           make(map[string]int64, numMisuseKinds),
+		RecentLengths:     p.tracker().Bytes(),
+	}
+	for _, tier := range r.Tiers {
+		saved.Tiers[tier.Size] = savedTier{Get: tier.Get, Put: tier.Put}
+	}
+	for reason := range DiscardReason(numDiscardReasons) {
+		if n := p.discarded(reason); n != 0 {
+			saved.Discards[reason.String()] = n
+		}
+	}
+	for kind := range MisuseKind(numMisuseKinds) {
+		if n := p.misused(kind); n != 0 {
+			saved.Misuses[kind.String()] = n
+		}
+	}
+	return json.NewEncoder(w).Encode(saved)
+}
+
+// LoadStats restores counters written by SaveStats, usually right after NewPools
+// Loaded counters are added to the live ones in all statistics, tiers missing from the
+// current configuration only contribute to the totals. Loading again replaces the
+// previously loaded counters. The samples are pushed into the recent-length tracker
+func (p *BytePool) LoadStats(r io.Reader) error {
+	var saved savedStats
+	if err := json.NewDecoder(r).Decode(&saved); err != nil {
+		return fmt.Errorf("bytepool: load stats: %w", err)
+	}
+	if saved.Version != statsFormatVersion {
+		return fmt.Errorf("bytepool: load stats: unsupported version %d", saved.Version)
+	}
+	p.restored.Store(&saved)
+
+	tracker := p.tracker()
+	for _, length := range saved.RecentLengths {
+		tracker.Push(length)
+	}
+	return nil
+}
+
+// restoredStats returns the counters loaded by LoadStats, zero when nothing was loaded
+func (p *BytePool) restoredStats() *savedStats {
+	if s := p.restored.Load(); s != nil {
+		return s
+	}
+	return &savedStats{}
+}
+
+// loadCounter reads a live counter and adds its restored value
+func loadCounter(live *int64, restored int64) int64 {
+	return atomic.LoadInt64(live) + restored
+}
@@ -0,0 +1,117 @@
+package bytepool
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBytePool_SaveLoadStats(t *testing.T) {
+	before := NewPools([]int{128, 256})
+	before.Get(100) // outstanding at save time
+	before.Put(before.Get(200))
+	before.Get(1000)
+
+	var state bytes.Buffer
+	if err := before.SaveStats(&state); err != nil {
+		t.Fatal(err)
+	}
+
+	after := NewPools([]int{128, 256})
+	if err := after.LoadStats(&state); err != nil {
+		t.Fatal(err)
+	}
+	after.Put(after.Get(100))
+
+	r := after.Stats()
+	if r.TotalGet != 3 || r.TotalPut != 2 || r.Discarded != 1 || after.GetDiscardedCount() != 1 {
+		t.Errorf("Expected counters to continue, got %+v", r)
+	}
+	if r.Tiers[0].Get != 2 || r.Tiers[0].Put != 1 || r.Tiers[0].InUse != 0 {
+		t.Errorf("Expected restored tier counters without in use, got %+v", r.Tiers[0])
+	}
+	if after.Outstanding() != 0 || r.InUseBytes != 0 {
+		t.Error("Expected restored counters not to count as outstanding")
+	}
+	if got := after.tracker().Len(); got != 4 {
+		t.Errorf("Expected 3 restored samples plus 1 new, got %d", got)
+	}
+	if stats := after.GetPoolStats(); stats["total_get"] != int64(3) {
+		t.Errorf("Expected GetPoolStats to include restored counters, got %v", stats["total_get"])
+	}
+}
+
+// persistedCounters keeps the cumulative counters of r, dropping the snapshot time and gauges
+func persistedCounters(r Report) Report {
+	r.Time = time.Time{}
+	r.InUseBytes, r.IdleBytes, r.HeldBytes, r.SpilledInUse = 0, 0, 0, 0
+	tiers := make([]TierStats, len(r.Tiers))
+	for i, tier := range r.Tiers {
+		tiers[i] = TierStats{Size: tier.Size, Get: tier.Get, Put: tier.Put}
+	}
+	r.Tiers = tiers
+	return r
+}
+
+func TestBytePool_SaveLoadStatsRoundTrip(t *testing.T) {
+	// a free list keeps the idle 256 buffer the fallback Get reuses, sync.Pool may drop it
+	opts := []Option{
+		WithBackend(FreeListBackend(4)),
+		WithMaxGetLength(4096),
+		WithTierFallback(1),
+		WithHygieneForRange(256, 256, HygieneSensitive),
+		WithPutAnomalyHook(func(int) {}),
+	}
+	before := NewPools([]int{128, 256, 1024}, opts...)
+	before.Put(before.Get(200))  // wiped
+	before.Get(100)              // served by the idle 256 buffer
+	before.Get(2000)             // oversize get
+	before.Get(8192)             // refused
+	before.Put(make([]byte, 64)) // tier mismatch and foreign put
+	before.Put(make([]byte, 2048))
+	before.Put(make([]byte, 1024)) // put anomaly
+	b := before.NewBytesBuffer(100)
+	b.Write(make([]byte, 50))
+	b.Write(make([]byte, 500)) // growth copy
+	b.Close()
+
+	want := persistedCounters(before.Stats())
+	if want.Discarded != want.Discards.OversizeGet+want.Discards.OversizePut+want.Discards.TierMismatch+want.Discards.Frozen {
+		t.Fatalf("Expected discards to add up before saving, got %+v", want)
+	}
+	if want.Refused == 0 || want.Wiped == 0 || want.TierFallbacks == 0 || want.GrowthCopies == 0 ||
+		want.PutAnomalies == 0 || want.Misuses.ForeignPut == 0 || want.Misuses.Oversize == 0 {
+		t.Fatalf("Expected every counter exercised, got %+v", want)
+	}
+
+	var state bytes.Buffer
+	if err := before.SaveStats(&state); err != nil {
+		t.Fatal(err)
+	}
+	after := NewPools([]int{128, 256, 1024}, opts...)
+	if err := after.LoadStats(&state); err != nil {
+		t.Fatal(err)
+	}
+	if got := persistedCounters(after.Stats()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected restored counters to match\nwant %+v\ngot  %+v", want, got)
+	}
+	stats := after.GetPoolStats()
+	if stats["discarded_tier_mismatch"] != want.Discards.TierMismatch || stats["refused"] != want.Refused {
+		t.Errorf("Expected GetPoolStats to include restored counters, got %v", stats)
+	}
+}
+
+func TestBytePool_LoadStatsInvalid(t *testing.T) {
+	pool := NewPools([]int{128})
+	if err := pool.LoadStats(strings.NewReader("not json")); err == nil {
+		t.Error("Expected decode error")
+	}
+	if err := pool.LoadStats(strings.NewReader(`{"version":99}`)); err == nil {
+		t.Error("Expected version error")
+	}
+	if pool.Stats().TotalGet != 0 {
+		t.Error("Expected failed loads to leave counters untouched")
+	}
+}
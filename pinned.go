@@ -0,0 +1,70 @@
+package bytepool
+
+import "sync"
+
+// WithPinnedTiers marks the tiers of the given sizes as pinned hot: their idle buffers
+// are held strongly in a free list and never dropped, not by GC, idle reaping of
+// EvictLRU, memory pressure of WithSoftCache or GC rotation, so a Get after a quiet
+// period is still served without allocating. A pinned tier holds as many buffers as
+// its peak concurrent use, other tiers keep their backend and stay elastic
+// Sizes that are not tiers are ignored, a tier added later by ApplyConfig is pinned
+// when its size is listed
+func WithPinnedTiers(sizes ...int) Option {
+	for _, size := range sizes {
+		if size <= 0 {
+			panic("pinned tier size must be positive")
+		}
+	}
+	return func(p *BytePool) {
+		if p.pinned == nil {
+			p.pinned = make(map[int]bool, len(sizes))
+		}
+		for _, size := range sizes {
+			p.pinned[size] = true
+		}
+	}
+}
+
+// pinnedBackend creates the unbounded stores of pinned tiers
+type pinnedBackend struct{}
+
+func (pinnedBackend) NewStore(int) Store {
+	return &pinnedStore{}
+}
+
+// pinnedStore is an unbounded LIFO free list that never drops a buffer
+type pinnedStore struct {
+	mu    sync.Mutex
+	items []*[]byte
+}
+
+func (s *pinnedStore) Get() *[]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := len(s.items)
+	if n == 0 {
+		return nil
+	}
+	buf := s.items[n-1]
+	s.items[n-1] = nil
+	s.items = s.items[:n-1]
+	return buf
+}
+
+func (s *pinnedStore) Put(buf *[]byte) {
+	s.mu.Lock()
+	s.items = append(s.items, buf)
+	s.mu.Unlock()
+}
+
+// Len returns the number of idle buffers
+func (s *pinnedStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items)
+}
+
+// EvictionStats reports the pinned policy, pinned stores never evict
+func (s *pinnedStore) EvictionStats() EvictionStats {
+	return EvictionStats{Policy: "pinned"}
+}
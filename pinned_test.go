@@ -0,0 +1,53 @@
+package bytepool
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestBytePool_PinnedTiers(t *testing.T) {
+	pool := NewPools([]int{128, 1500, 65536},
+		WithPinnedTiers(1500, 4096), WithSoftCache(1, 0.9), WithGCRotationMitigation(4))
+
+	st := pool.state.Load()
+	if _, ok := st.tier(1500).store.(*pinnedStore); !ok {
+		t.Fatalf("Expected pinned store for 1500, got %T", st.tier(1500).store)
+	}
+	if st.tier(1500).gcRotation != nil {
+		t.Error("Expected no GC rotation for the pinned tier")
+	}
+	if _, ok := st.tier(128).store.(*pinnedStore); ok {
+		t.Error("Expected other tiers to keep the default backend")
+	}
+
+	bufs := make([][]byte, 8)
+	for i := range bufs {
+		bufs[i] = pool.Get(1500)
+	}
+	for _, buf := range bufs {
+		pool.Put(buf)
+	}
+	runtime.GC()
+	runtime.GC()
+	tier := pool.Stats().Tiers[1]
+	if tier.Idle != 8 || !tier.IdleExact {
+		t.Errorf("Expected 8 idle buffers kept across GC, got %d", tier.Idle)
+	}
+	if tier.Eviction == nil || tier.Eviction.Policy != "pinned" {
+		t.Errorf("Expected pinned eviction policy, got %+v", tier.Eviction)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for a non-positive size")
+		}
+	}()
+	WithPinnedTiers(0)
+}
+
+func TestBytePool_PinnedLargestTier(t *testing.T) {
+	pool := NewPools([]int{128, 65536}, WithPinnedTiers(65536), WithSoftCache(1, 0.9))
+	if _, ok := pool.state.Load().tier(65536).store.(*pinnedStore); !ok {
+		t.Error("Expected pinning to take precedence over the soft cache")
+	}
+}
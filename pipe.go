@@ -0,0 +1,148 @@
+package bytepool
+
+import (
+	"io"
+	"sync"
+)
+
+// pipeWindow is the number of chunks a Pipe holds in flight before Write blocks
+const pipeWindow = 4
+
+// Pipe creates a synchronous in-memory pipe like io.Pipe, but buffered in pooled chunks
+// of chunkSize bytes so the writer does not wait for every read. At most pipeWindow chunks
+// are in flight, a Write blocks while they are full. Closing the writer makes the reader
+// return io.EOF once drained, closing the reader makes writes fail with io.ErrClosedPipe
+// and returns the chunks to the pool
+func (p *BytePool) Pipe(chunkSize int) (io.WriteCloser, io.ReadCloser) {
+	if chunkSize <= 0 {
+		panic("chunk size must be positive")
+	}
+	pp := &pipe{pool: p, chunkSize: p.clampLength(chunkSize)}
+	pp.cond.L = &pp.mu
+	return &pipeWriter{pp}, &pipeReader{pp}
+}
+
+// pipe is the state shared by both ends, chunks[0] is read from roff on
+type pipe struct {
+	pool      *BytePool
+	chunkSize int
+
+	mu      sync.Mutex
+	cond    sync.Cond
+	chunks  [][]byte
+	roff    int
+	wclosed bool
+	rclosed bool
+}
+
+func (pp *pipe) write(b []byte) (int, error) {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+
+	n := 0
+	for len(b) > 0 {
+		if pp.wclosed || pp.rclosed {
+			return n, io.ErrClosedPipe
+		}
+		last := len(pp.chunks) - 1
+		switch {
+		case last >= 0 && len(pp.chunks[last]) < pp.chunkSize:
+			tail := pp.chunks[last]
+			c := copy(tail[len(tail):pp.chunkSize], b)
+			pp.chunks[last] = tail[:len(tail)+c]
+			b = b[c:]
+			n += c
+			pp.cond.Broadcast()
+		case len(pp.chunks) < pipeWindow:
+			chunk, err := pp.pool.GetE(pp.chunkSize)
+			if err != nil {
+				return n, err
+			}
+			pp.chunks = append(pp.chunks, chunk[:0])
+		default:
+			pp.cond.Wait()
+		}
+	}
+	return n, nil
+}
+
+func (pp *pipe) read(b []byte) (int, error) {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+
+	for {
+		if pp.rclosed {
+			return 0, io.ErrClosedPipe
+		}
+		if len(pp.chunks) > 0 && pp.roff < len(pp.chunks[0]) {
+			break
+		}
+		if pp.wclosed {
+			return 0, io.EOF
+		}
+		pp.cond.Wait()
+	}
+	head := pp.chunks[0]
+	n := copy(b, head[pp.roff:])
+	pp.roff += n
+	if pp.roff == len(head) {
+		if len(pp.chunks) == 1 {
+			// reuse the only chunk instead of cycling it through the pool
+			pp.chunks[0] = head[:0]
+		} else {
+			pp.pool.Put(head)
+			pp.chunks[0] = nil
+			pp.chunks = pp.chunks[1:]
+		}
+		pp.roff = 0
+	}
+	pp.cond.Broadcast()
+	return n, nil
+}
+
+// release returns the chunks to the pool once no end can use them
+func (pp *pipe) release() {
+	for _, chunk := range pp.chunks {
+		pp.pool.Put(chunk)
+	}
+	pp.chunks = nil
+	pp.roff = 0
+}
+
+type pipeWriter struct{ pp *pipe }
+
+// Write copies b into pooled chunks, blocking while pipeWindow chunks are full
+func (w *pipeWriter) Write(b []byte) (int, error) {
+	return w.pp.write(b)
+}
+
+// Close makes the reader return io.EOF once the buffered data is read
+func (w *pipeWriter) Close() error {
+	pp := w.pp
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+	pp.wclosed = true
+	if pp.rclosed {
+		pp.release()
+	}
+	pp.cond.Broadcast()
+	return nil
+}
+
+type pipeReader struct{ pp *pipe }
+
+// Read reads buffered data, blocking until the writer writes or closes
+func (r *pipeReader) Read(b []byte) (int, error) {
+	return r.pp.read(b)
+}
+
+// Close discards the buffered data, returning its chunks to the pool
+func (r *pipeReader) Close() error {
+	pp := r.pp
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+	pp.rclosed = true
+	pp.release()
+	pp.cond.Broadcast()
+	return nil
+}
@@ -0,0 +1,75 @@
+package bytepool
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestBytePool_Pipe(t *testing.T) {
+	pool := NewPools([]int{1024, 4096})
+	w, r := pool.Pipe(1024)
+
+	payload := bytes.Repeat([]byte("0123456789abcdef"), 64*1024)
+	go func() {
+		for chunk := range pieces(payload, 3000) {
+			if _, err := w.Write(chunk); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		w.Close()
+	}()
+
+	got, err := io.ReadAll(r)
+	if err != nil || !bytes.Equal(got, payload) {
+		t.Errorf("Expected %d bytes through the pipe, got %d (%v)", len(payload), len(got), err)
+	}
+	r.Close()
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected all chunks released, got %d outstanding", pool.Outstanding())
+	}
+}
+
+func TestBytePool_PipeBounded(t *testing.T) {
+	pool := NewPools([]int{1024})
+	w, r := pool.Pipe(1024)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := w.Write(make([]byte, 10*1024))
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		t.Fatalf("Expected Write to block on a full window, got %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if out := pool.Outstanding(); out != pipeWindow {
+		t.Errorf("Expected %d chunks in flight, got %d", pipeWindow, out)
+	}
+
+	r.Close()
+	if err := <-done; !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("Expected io.ErrClosedPipe after the reader closed, got %v", err)
+	}
+	w.Close()
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected all chunks released, got %d outstanding", pool.Outstanding())
+	}
+}
+
+// pieces yields b in pieces of at most n bytes
+func pieces(b []byte, n int) func(func([]byte) bool) {
+	return func(yield func([]byte) bool) {
+		for len(b) > 0 {
+			k := min(n, len(b))
+			if !yield(b[:k]) {
+				return
+			}
+			b = b[k:]
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package bytepool
+
+import "testing"
+
+func TestWithPoisonOnPut(t *testing.T) {
+	pool := NewPools([]int{128}, WithBackend(FreeListBackend(1)), WithPoisonOnPut(0xDE), WithZeroOnPut())
+
+	buf := pool.Get(10)
+	copy(buf, "secret")
+	pool.Put(buf)
+
+	// a stale reference now reads the poison pattern over the whole tier
+	stale := buf[:cap(buf)]
+	for i, b := range stale {
+		if b != 0xDE {
+			t.Fatalf("Expected poison byte at %d, got %#x", i, b)
+		}
+	}
+}
+
+func TestPoison(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 127, 128} {
+		buf := make([]byte, n)
+		poison(buf, 0xAB)
+		for i, b := range buf {
+			if b != 0xAB {
+				t.Fatalf("len %d: expected 0xAB at %d, got %#x", n, i, b)
+			}
+		}
+	}
+}
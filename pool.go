@@ -1,33 +1,129 @@
 package bytepool
 
 import (
+	"context"
+	"errors"
 	"expvar"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"runtime"
+	"runtime/trace"
 	"slices"
+	"sync"
 	"sync/atomic"
+	"time"
 )
 
+// RingQueuer tracks recent Get lengths
 type RingQueuer interface {
 	Push(item int)
 	Bytes() []int
+	Len() int // current number of samples
+	Cap() int // maximum number of samples
 }
 
 // BytePool is a multi-tier memory pool
 type BytePool struct {
-	pools          map[int]*Pool[*[]byte]
-	stats          map[int]*PoolStats
-	sizes          []int
-	sizesLen       int
-	discardedCount int64 // count of discarded items that exceed maxPoolSize
-	maxPoolSize    int
-	recentLengths  RingQueuer // statistics of recent 256 get operation lengths
-	totalGet       int64      // total number of valid get operations
-	totalPut       int64      // total number of valid put operations
+	state         atomic.Pointer[poolState]  // tiers and tunables in effect, swapped by ApplyConfig
+	initial       PoolConfig                 // configuration assembled by the options
+	recentLengths atomic.Pointer[RingQueuer] // recent Get lengths, capacity scaled by GOMAXPROCS
+
+	// hot counters each own a cache line so Get and Put on different cores don't bounce it
+	_              cacheLinePad
+	totalGet       int64 // total number of valid get operations
+	_              cacheLinePad
+	totalPut       int64 // total number of valid put operations
+	_              cacheLinePad
+	inUseBytes     int64 // bytes of pooled buffers currently leased
+	_              cacheLinePad
+	discardedCount int64 // count of discarded items, the sum of discardReasons
+	_              cacheLinePad
+	discardReasons [numDiscardReasons]paddedCounter
+
+	hygieneRanges        []hygieneRange
+	tracing              bool    // wrap operations in runtime/trace regions
+	backend              Backend // default backend for idle buffers
+	backendRanges        []backendRange
+	softCache            Backend        // backend of the largest tier set by WithSoftCache
+	gcRotationKeep       int            // idle buffers per tier kept across GC, 0 disables
+	pinned               map[int]bool   // tier sizes set by WithPinnedTiers
+	fifoReuse            bool           // reuse idle buffers in return order
+	batchedStats         bool           // accumulate Get and Put counters in shards
+	trackerBuckets       TrackerBuckets // what the tracker records per sampled Get
+	customTracker        bool           // tracker configured by an option, kept by WithAutoTune
+	gcSentinels          bool           // plant GC sentinels in sync.Pool tiers
+	clearFuncs           []func([]byte) // wipe functions of HygieneSensitive tiers
+	misusePolicies       [numMisuseKinds]MisusePolicy
+	misuses              [numMisuseKinds]int64
+	misuseErr            atomic.Pointer[error] // latest misuse recorded under MisuseError
+	wiped                int64                 // buffers wiped by HygieneSensitive
+	growth               GrowthPolicy          // how PooledBytesBuffer grows across tiers
+	putAnomalies         int64                 // Puts without a matching Get in their tier
+	onPutAnomaly         func(size int)
+	faults               func(op OpKind, size int) error // set by WithFaultInjection
+	heat                 *heatmap                        // per-minute tier Gets, WithHeatmap only
+	maxRefCount          int32                           // Buffer reference limit, 0 for DefaultMaxRefCount
+	growthCopies         int64                           // PooledBytesBuffer growths that moved data
+	growthCopiedBytes    int64
+	clock                Clock // time source for time-based features
+	frozen               atomic.Bool
+	ringMirror           *RingFile // optional on-disk mirror of recentLengths
+	debug                bool
+	zeroCheck            bool     // verify cleared buffers are still zero on Get, debug mode only
+	putSites             sync.Map // backing array address to putSite, with zeroCheck
+	inefficientGets      int64
+	labels               map[string]string // static labels for monitoring
+	throttled            int64             // number of throttled Gets
+	degradeToChain       bool
+	degraded             int64 // number of GetChain calls served from smaller tiers
+	consolidateThreshold int
+	consolidations       int64        // number of chains copied into a single buffer
+	consolidatedBytes    int64        // bytes copied by chain consolidation
+	events               *eventLogger // rate limited event logging, nil when silent
+	logInterval          time.Duration
+	hooks                []func(Event)
+	subsMu               sync.RWMutex // held for reading while emitting to subscribers
+	subs                 []chan Event // channels returned by Events
+	subsCount            atomic.Int32 // len(subs), checked before taking subsMu
+	eventsDropped        int64        // events not delivered to a full subscriber
+	overBudget           atomic.Bool  // soft budget exceeded and not yet recovered
+	pressure             pressureWatches
+	autoTune             bool
+	statsLevel           atomic.Uint32 // StatsLevel set by SetStatsLevel
+	leases               sync.Map      // backing array address to leaseRecord, with StatsHoldTimes
+	holds                holdTimes
+	adaptive             *adaptiveSampler           // tracker sample rate set by WithAdaptiveSampling
+	tuning               *Tuning                    // parameters chosen by WithAutoTune
+	id                   uint64                     // process unique pool id, used by debug watermarks
+	restored             atomic.Pointer[savedStats] // counters loaded by LoadStats
+	tierFallbacks        int64                      // Gets served by a larger tier
+	spillDir             string
+	spillMin             int   // smallest GetBuffer length spilled to a temp file, 0 disables
+	spilled              int64 // number of spilled buffers
+	spilledBytes         int64 // bytes spilled in total
+	spilledInUse         int64 // bytes of spilled buffers not yet released
+	retainsExpired       int64 // RetainFor references released by their deadline
+	refused              int64 // Gets above MaxGetLength
 }
 
+// cacheLineSize is the assumed CPU cache line size
+const cacheLineSize = 64
+
+// cacheLinePad fills the rest of a cache line after an int64 counter
+type cacheLinePad [cacheLineSize - 8]byte
+
 // PoolStats represents memory pool statistics
+// Each counter is padded to its own cache line, so concurrent Get and Put on
+// different tiers don't suffer from false sharing
 type PoolStats struct {
+	_   cacheLinePad
 	Get int64 `json:"get"`
+	_   cacheLinePad
 	Put int64 `json:"put"`
+	_   cacheLinePad
+
+	shards []statShard // pending deltas, WithBatchedStats only
 }
 
 type Option func(*BytePool)
@@ -42,112 +138,299 @@ const (
 	MutexRingQueue
 )
 
+// WithRingQueue uses a custom ring queue to track recent Get lengths
+// A nil queue or one reporting a non-positive capacity is ignored and the default kept
 func WithRingQueue(ringQueuer RingQueuer) Option {
 	return func(p *BytePool) {
-		p.recentLengths = ringQueuer
+		if ringQueuer == nil || ringQueuer.Cap() <= 0 {
+			return
+		}
+		p.recentLengths.Store(&ringQueuer)
+		p.customTracker = true
 	}
 }
 
-// WithRingQueueType sets the type of ring queue to use
+// WithRingQueueType sets the type of ring queue to use, keeping the current capacity
 func WithRingQueueType(queueType RingQueueType) Option {
 	return func(p *BytePool) {
+		size := p.tracker().Cap()
+		var q RingQueuer
 		switch queueType {
 		case LockFreeRingQueue:
-			p.recentLengths = NewRingQueue[int](256)
+			q = NewRingQueue[int](size)
 		case MutexRingQueue:
-			p.recentLengths = NewLockedRingQueue[int](256)
+			q = NewLockedRingQueue[int](size)
 		default:
-			p.recentLengths = NewRingQueue[int](256) // default to lock-free
+			q = NewRingQueue[int](size) // default to lock-free
 		}
+		p.recentLengths.Store(&q)
+		p.customTracker = true
+	}
+}
+
+// trackerSamplesPerProc and maxDefaultTrackerCap derive the default tracker capacity
+const (
+	trackerSamplesPerProc = 256
+	maxDefaultTrackerCap  = 1 << 14
+)
+
+// DefaultTrackerCapacity returns the recent-length tracker capacity used when none is
+// configured: 256 samples per GOMAXPROCS, capped at 16384, so snapshots of busy pools
+// cover a useful window of traffic
+func DefaultTrackerCapacity() int {
+	return min(trackerSamplesPerProc*runtime.GOMAXPROCS(0), maxDefaultTrackerCap)
+}
+
+// WithTrackerCapacity sets the capacity of the recent-length tracker, keeping its type
+func WithTrackerCapacity(size int) Option {
+	if size <= 0 {
+		panic("tracker capacity must be positive")
+	}
+	return func(p *BytePool) {
+		var q RingQueuer
+		if _, ok := p.tracker().(*LockedRingQueue[int]); ok {
+			q = NewLockedRingQueue[int](size)
+		} else {
+			q = NewRingQueue[int](size)
+		}
+		p.recentLengths.Store(&q)
+	}
+}
+
+// WithSizes overrides the tier sizes passed to NewPools
+func WithSizes(sizes []int) Option {
+	return func(p *BytePool) {
+		p.initial.Sizes = slices.Clone(sizes)
+	}
+}
+
+// WithZeroOnPut clears buffer content before it is returned to the pool
+func WithZeroOnPut() Option {
+	return func(p *BytePool) {
+		if p.initial.Hygiene != HygienePoison {
+			p.initial.Hygiene = HygieneZero
+		}
+	}
+}
+
+// WithPoisonOnPut fills buffers with pattern (e.g. 0xDE) before they return to the pool,
+// so a consumer still reading a released buffer sees obviously wrong data instead of
+// stale valid-looking payloads. Intended for debug and staging builds, it takes
+// precedence over WithZeroOnPut
+func WithPoisonOnPut(pattern byte) Option {
+	return func(p *BytePool) {
+		p.initial.Hygiene = HygienePoison
+		p.initial.PoisonByte = pattern
+	}
+}
+
+// poison fills buf with pattern
+func poison(buf []byte, pattern byte) {
+	if len(buf) == 0 {
+		return
+	}
+	buf[0] = pattern
+	for filled := 1; filled < len(buf); filled *= 2 {
+		copy(buf[filled:], buf[:filled])
 	}
 }
 
 // NewPools creates a new BytePool with the given tier sizes
 // Items exceeding the maximum size will not be returned to the pool
 func NewPools(sizes []int, opts ...Option) *BytePool {
-	l := len(sizes)
-	if l < 1 {
+	pool := configure(sizes, opts)
+	if len(pool.initial.Sizes) < 1 {
 		panic("sizes is empty")
 	}
+	pool.start()
+	return pool
+}
+
+// configure creates a pool with the options applied but no tiers built yet
+func configure(sizes []int, opts []Option) *BytePool {
 	pool := BytePool{
-		pools:         make(map[int]*Pool[*[]byte]),
-		stats:         make(map[int]*PoolStats),
-		sizes:         make([]int, l),
-		sizesLen:      l,
-		recentLengths: NewRingQueue[int](256), // initialize ring queue with capacity 256
+		initial: PoolConfig{Sizes: slices.Clone(sizes)},
+		clock:   systemClock{},
+		id:      nextPoolID.Add(1),
 	}
+	// initialize ring queue scaled to the expected throughput
+	var defaultQueue RingQueuer = NewRingQueue[int](DefaultTrackerCapacity())
+	pool.recentLengths.Store(&defaultQueue)
+	pool.statsLevel.Store(uint32(StatsDefault))
 	for _, opt := range opts {
 		opt(&pool)
 	}
+	if pool.trackerBuckets != TrackerRawLengths {
+		var q RingQueuer = newBucketQueue(pool.tracker().Cap())
+		pool.recentLengths.Store(&q)
+	}
+	pool.SetTracker(pool.tracker())
+	if pool.autoTune {
+		pool.applyAutoTune()
+	}
+	return &pool
+}
 
-	copy(pool.sizes, sizes)
-	slices.Sort(pool.sizes)
-
-	pool.maxPoolSize = pool.sizes[l-1]
+// start builds the tiers of the configured pool and announces them
+func (p *BytePool) start() {
+	state := p.buildState(p.initial, nil)
+	p.state.Store(state)
+	if p.heat != nil {
+		// start the first minute with the counters of the fresh tiers
+		p.advanceHeatmap()
+	}
 
-	for _, size := range pool.sizes {
-		pool.pools[size] = NewPool(func() *[]byte {
-			buf := make([]byte, size)
-			return &buf
-		})
-		pool.stats[size] = &PoolStats{}
+	for _, size := range state.sizes {
+		p.emit(Event{Kind: EventTierAdded, Size: size})
 	}
-	return &pool
+	p.emit(Event{Kind: EventPoolCreated})
 }
 
-// Alloc allocates a buffer of the specified size (placeholder implementation)
+// Alloc pre-allocates one buffer in the tier fitting size
+//
+// Deprecated: Alloc was a placeholder that churned the statistics, use Reserve instead
 func (p *BytePool) Alloc(size int) *BytePool {
-	if size <= 0 || size > p.maxPoolSize {
+	if size <= 0 || size > p.GetMax() {
 		return nil
 	}
-	for _, size := range p.sizes {
-		for range size {
-			buf := p.Get(size)
-			p.Put(buf)
-		}
-	}
+	_ = p.Reserve(p.findBestSize(size), 1)
 	return p
 }
 
+// Reserve pre-allocates count buffers into the tier of exactly size bytes
+// Statistics are not affected, returns ErrInvalidTier when size is not a configured tier
+func (p *BytePool) Reserve(size, count int) error {
+	if p.state.Load().tier(size) == nil {
+		return fmt.Errorf("%w: %d", ErrInvalidTier, size)
+	}
+	if count <= 0 {
+		return ErrInvalidCount
+	}
+	p.prewarm(size, count)
+	return nil
+}
+
 // findBestSize finds the most suitable tier based on the required length
 func (p *BytePool) findBestSize(length int) int {
-	for _, size := range p.sizes {
-		if size >= length {
-			return size
-		}
-	}
-	return p.sizes[len(p.sizes)-1]
+	return p.state.Load().findBestSize(length)
 }
 
 // Get retrieves a []byte of the specified length from the pool
 func (p *BytePool) Get(length int) []byte {
+	if p.tracing && trace.IsEnabled() {
+		return p.tracedGet(length)
+	}
+	return p.get(length)
+}
+
+func (p *BytePool) get(length int) []byte {
+	if st := p.state.Load(); st.cfg.SoftBudget > 0 && length <= st.maxSize && p.overSoftBudget() {
+		atomic.AddInt64(&p.throttled, 1)
+		p.logBudgetBreach()
+		_ = p.sleep(context.Background(), st.cfg.SoftDelay)
+	}
+	var fault error
+	if p.faults != nil && length > 0 {
+		fault = p.faults(OpGet, length)
+	}
+	return p.lease(length, fault)
+}
+
+// lease takes a buffer from the tier stores without any throttling
+// A non-nil fault from WithFaultInjection changes the outcome, see there
+func (p *BytePool) lease(length int, fault error) []byte {
 	if length <= 0 {
 		return nil
 	}
 
+	st := p.state.Load()
+	if st.refuses(length) || errors.Is(fault, ErrTooLong) {
+		atomic.AddInt64(&p.refused, 1)
+		return nil
+	}
+
 	// record the requested length to the ring queue
-	p.recentLengths.Push(length)
+	if p.recording(StatsTracker) && p.sampled(st) {
+		p.tracker().Push(p.trackValue(st, length))
+	}
 
-	if length > p.maxPoolSize {
-		atomic.AddInt64(&p.discardedCount, 1)
+	if length > st.maxSize || errors.Is(fault, ErrOversize) {
+		p.discard(DiscardOversizeGet, length)
+		if fault == nil && p.misuse(MisuseOversize, MisuseLog, "get of %d bytes above the largest tier", length) {
+			p.logOversizeGet(length)
+		}
 		return make([]byte, length)
 	}
 
-	size := p.findBestSize(length)
-	if pool, ok := p.pools[size]; ok {
-		// only count when actually getting from the memory pool
-		atomic.AddInt64(&p.stats[size].Get, 1)
-		atomic.AddInt64(&p.totalGet, 1)
+	i := st.tierIndex(length)
+	t := &st.tiers[i]
+	if st.cfg.MinEfficiency > 0 {
+		p.checkEfficiency(length, t.size, st.cfg.MinEfficiency)
+	}
+	var buf []byte
+	if p.frozen.Load() || fault != nil {
+		buf = make([]byte, length, t.size)
+	} else {
+		buf = p.take(st, i, length)
+	}
+	// only count when actually getting from the memory pool, against the tier
+	// the buffer will be returned to
+	if cap(buf) == t.size {
+		p.countGet(t.stats, t.size)
+	} else {
+		p.countGet(st.tierStat(cap(buf)), cap(buf))
+	}
+	if p.tracksLeases() {
+		p.recordLease(buf)
+	}
+	if p.heat != nil {
+		p.maybeAdvanceHeatmap()
+	}
+	if p.pressure.list.Load() != nil {
+		p.checkPressure()
+	}
+	if p.debug {
+		if p.zeroCheck {
+			p.checkZeroed(st.tier(cap(buf)), buf)
+		}
+		p.watermark(buf)
+	}
+	return buf
+}
 
-		buf := *pool.Get()
-		return buf[:length]
+// take leases a buffer from the store of tier i, allocating a fresh one when it is empty
+func (p *BytePool) take(st *poolState, i, length int) []byte {
+	if st.tiers[i].latency != nil && rand.Uint32N(uint32(st.cfg.LatencySampleEvery)) == 0 {
+		return p.timedTake(st, i, length)
 	}
+	buf, _ := p.takeStore(st, i, length)
+	return buf
+}
 
-	return make([]byte, length)
+// takeStore leases a buffer from the store of tier i or, with WithTierFallback, a larger
+// tier's store. It allocates when all of them are empty and reports whether a store
+// served the buffer
+func (p *BytePool) takeStore(st *poolState, i, length int) ([]byte, bool) {
+	t := &st.tiers[i]
+	if buf := t.get(); buf != nil {
+		return buf[:length], true
+	}
+	if st.cfg.TierFallback > 0 {
+		if buf := p.takeLarger(st, i, length); buf != nil {
+			return buf, true
+		}
+	}
+	return make([]byte, length, t.size), false
 }
 
 // GetBuffer retrieves a Buffer of the specified length from the pool
+// With WithSpill large requests may be served from a temp file while over the soft budget
 func (p *BytePool) GetBuffer(length int) *Buffer {
+	if p.spillMin > 0 && length >= p.spillMin && !p.state.Load().refuses(length) && p.overSoftBudget() {
+		if buf := p.spill(length); buf != nil {
+			return buf
+		}
+	}
 	buf := p.Get(length)
 	return NewBuffer(buf, p)
 }
@@ -159,38 +442,115 @@ func (p *BytePool) ReleaseBuffer(buf *Buffer) {
 
 // Put returns a []byte to the pool
 func (p *BytePool) Put(buf []byte) {
+	if p.tracing && trace.IsEnabled() {
+		p.tracedPut(buf)
+		return
+	}
+	p.put(buf)
+}
+
+func (p *BytePool) put(buf []byte) {
 	if buf == nil || cap(buf) == 0 {
 		return
 	}
+	dropped := p.faults != nil && p.faults(OpPut, cap(buf)) != nil
+
+	if watermarking.Load() {
+		p.checkWatermark(buf)
+	}
 
 	capacity := cap(buf)
+	st := p.state.Load()
+	t := st.tier(capacity)
+	if t == nil {
+		switch stat := st.retired[capacity]; {
+		case stat != nil:
+			// leased before ApplyConfig removed the tier, count it and let GC collect
+			p.countPut(stat, capacity)
+			if p.tracksLeases() {
+				p.forgetLease(buf)
+			}
+		case capacity > st.maxSize:
+			// discard if exceeding maximum pool size
+			p.discard(DiscardOversizePut, capacity)
+			p.misuse(MisuseOversize, MisuseIgnore, "put of capacity %d above the largest tier", capacity)
+		default:
+			// if capacity doesn't match any tier, discard and let GC collect
+			p.discard(DiscardTierMismatch, capacity)
+			if p.misuse(MisuseForeignPut, MisuseLog, "put of capacity %d matches no tier", capacity) {
+				p.logEvent(slog.LevelWarn, eventForeignPut, "bytepool: put buffer matches no tier", slog.Int("cap", capacity))
+			}
+		}
+		return
+	}
 
-	// discard if exceeding maximum pool size
-	if capacity > p.maxPoolSize {
-		atomic.AddInt64(&p.discardedCount, 1)
+	if p.tracksLeases() && p.recordReturn(buf) {
+		// the buffer is already idle in the store, pooling it twice would hand it out twice
+		if p.misuse(MisuseDoublePut, p.debugPolicy(), "buffer of tier %d put twice", capacity) {
+			p.logEvent(slog.LevelWarn, eventDoublePut, "bytepool: buffer put twice", slog.Int("cap", capacity))
+		}
+		return
+	}
+	// only count when actually returning to the memory pool
+	p.countPut(t.stats, capacity)
+	if dropped {
 		return
 	}
 
-	if pool, ok := p.pools[capacity]; ok {
-		// only count when actually returning to the memory pool
-		atomic.AddInt64(&p.stats[capacity].Put, 1)
-		atomic.AddInt64(&p.totalPut, 1)
+	// a frozen pool keeps its stores untouched, let GC collect the buffer
+	if p.frozen.Load() {
+		p.discard(DiscardFrozen, capacity)
+		return
+	}
+
+	// reset slice length to capacity and clear content
+	buf = buf[:capacity]
+	p.applyHygiene(t, buf, st.cfg.PoisonByte)
+	if p.zeroCheck && p.debug && t.hygiene == HygieneZero {
+		p.recordPutSite(buf)
+	}
+	t.put(buf)
+}
 
-		// reset slice length to capacity and clear content
-		buf = buf[:capacity]
-		pool.Put(&buf)
+// countGet records a lease from the tier of the given size
+func (p *BytePool) countGet(stat *PoolStats, size int) {
+	if stat.shards != nil {
+		p.batchGet(stat, size)
+		return
+	}
+	if p.recording(StatsTierCounters) {
+		atomic.AddInt64(&stat.Get, 1)
+	}
+	atomic.AddInt64(&p.totalGet, 1)
+	atomic.AddInt64(&p.inUseBytes, int64(size))
+}
+
+// countPut records a return to the tier of the given size
+func (p *BytePool) countPut(stat *PoolStats, size int) {
+	if stat.shards != nil {
+		p.batchPut(stat, size)
+	} else {
+		if p.recording(StatsTierCounters) {
+			if p.checkPutAnomaly(stat, atomic.AddInt64(&stat.Put, 1), size) {
+				return
+			}
+		}
+		atomic.AddInt64(&p.totalPut, 1)
+		atomic.AddInt64(&p.inUseBytes, -int64(size))
+	}
+	if p.overBudget.Load() {
+		p.checkBudgetRecovered()
 	}
-	// if capacity doesn't match any tier, discard and let GC collect
 }
 
 // GetAvailableSizes returns all available tier sizes
 func (p *BytePool) GetAvailableSizes() []int {
-	return slices.Clone(p.sizes)
+	return slices.Clone(p.state.Load().sizes)
 }
 
 // GetDiscardedCount returns the count of discarded items
 func (p *BytePool) GetDiscardedCount() int64 {
-	return atomic.LoadInt64(&p.discardedCount)
+	return loadCounter(&p.discardedCount, p.restoredStats().Discarded)
 }
 
 // GetPoolStats returns pool statistics (for debugging)
@@ -199,32 +559,70 @@ func (p *BytePool) GetPoolStats() map[string]interface{} {
 
 	// statistics for each tier
 	poolStats := make(map[int]map[string]int64)
-	for size, stat := range p.stats {
+	p.flushStats()
+	st := p.state.Load()
+	for i := range st.tiers {
+		tier := p.tierStats(&st.tiers[i])
+		size := tier.Size
 		poolStats[size] = map[string]int64{
-			"get": atomic.LoadInt64(&stat.Get),
-			"put": atomic.LoadInt64(&stat.Put),
+			"get":        tier.Get,
+			"put":        tier.Put,
+			"idle":       tier.Idle,
+			"idle_bytes": tier.IdleBytes,
 		}
 	}
+	restored := p.restoredStats()
 	stats["pools"] = poolStats
-	stats["discarded"] = atomic.LoadInt64(&p.discardedCount)
+	stats["discarded"] = loadCounter(&p.discardedCount, restored.Discarded)
+	for reason := range DiscardReason(numDiscardReasons) {
+		stats["discarded_"+reason.String()] = p.discarded(reason)
+	}
+	stats["inefficient_get"] = loadCounter(&p.inefficientGets, restored.Inefficient)
+	stats["throttled"] = loadCounter(&p.throttled, restored.Throttled)
+	stats["degraded"] = loadCounter(&p.degraded, restored.Degraded)
+	stats["consolidations"] = loadCounter(&p.consolidations, restored.Consolidations)
+	stats["consolidated_bytes"] = loadCounter(&p.consolidatedBytes, restored.ConsolidatedBytes)
+	stats["tier_fallback"] = loadCounter(&p.tierFallbacks, restored.TierFallbacks)
+	stats["spilled"] = loadCounter(&p.spilled, restored.Spilled)
+	stats["spilled_bytes"] = loadCounter(&p.spilledBytes, restored.SpilledBytes)
+	stats["retains_expired"] = loadCounter(&p.retainsExpired, restored.RetainsExpired)
+	stats["refused"] = loadCounter(&p.refused, restored.Refused)
+	stats["wiped"] = loadCounter(&p.wiped, restored.Wiped)
+	stats["growth_copies"] = loadCounter(&p.growthCopies, restored.GrowthCopies)
+	stats["put_anomalies"] = loadCounter(&p.putAnomalies, restored.PutAnomalies)
+	stats["growth_copied_bytes"] = loadCounter(&p.growthCopiedBytes, restored.GrowthCopiedBytes)
+	for kind := range MisuseKind(numMisuseKinds) {
+		stats["misuse_"+kind.String()] = p.misused(kind)
+	}
 
 	// add total statistics
-	totalGet := atomic.LoadInt64(&p.totalGet)
-	totalPut := atomic.LoadInt64(&p.totalPut)
-	stats["total_get"] = totalGet
-	stats["total_put"] = totalPut
+	stats["total_get"] = loadCounter(&p.totalGet, restored.TotalGet)
+	stats["total_put"] = loadCounter(&p.totalPut, restored.TotalPut)
 
 	// add statistics of recent 256 get operation lengths
-	recentLengths := p.recentLengths.Bytes()
+	tracker := p.tracker()
+	recentLengths := tracker.Bytes()
 	stats["recent_lengths"] = recentLengths
+	stats["tracker_len"] = tracker.Len()
+	stats["tracker_cap"] = tracker.Cap()
+	stats["tracker_sample_every"] = p.trackerSampleEvery(st)
+	stats["tracker_buckets"] = p.trackerBuckets.String()
+	stats["growth_policy"] = p.growth.String()
+
+	if len(p.labels) > 0 {
+		stats["labels"] = p.Labels()
+	}
 
 	return stats
 }
 
 // Expvar publishes pool statistics to expvar with the given prefix
+// Labels set with WithLabels are appended to the name, e.g. myapp_pool_stats{listener=rtmp}
+// The metrics of MetricsSchema are published alongside as prefix+"metrics"
 func (p *BytePool) Expvar(prefix string) *BytePool {
-	expvar.Publish(prefix+"pool_stats", expvar.Func(func() any {
+	expvar.Publish(prefix+"pool_stats"+p.labelSuffix(), expvar.Func(func() any {
 		return p.GetPoolStats()
 	}))
+	p.publishMetrics(prefix)
 	return p
 }
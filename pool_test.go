@@ -40,7 +40,7 @@ func TestBytePool_RecentLengthsTracking(t *testing.T) {
 }
 
 func TestBytePool_RecentLengthsOverflow(t *testing.T) {
-	pool := NewPools([]int{128, 256, 512, 1024})
+	pool := NewPools([]int{128, 256, 512, 1024}, WithTrackerCapacity(256))
 
 	// 写入超过 256 个长度，测试环形队列的循环覆盖
 	for i := 1; i <= 300; i++ {
@@ -154,7 +154,7 @@ func TestBytePool_OnlyValidOperationsTracked(t *testing.T) {
 }
 
 func TestBytePool_RecentLengthsConcurrent(t *testing.T) {
-	pool := NewPools([]int{128, 256, 512, 1024})
+	pool := NewPools([]int{128, 256, 512, 1024}, WithTrackerCapacity(256))
 
 	// 并发写入测试
 	const numGoroutines = 10
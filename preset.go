@@ -0,0 +1,99 @@
+package bytepool
+
+import (
+	"math"
+	"time"
+)
+
+// PresetKind identifies a predefined pool configuration
+type PresetKind int
+
+const (
+	// Balanced covers 128B to 1MB tiers with the lock-free tracker, suitable for most services
+	// Tiers from 64KB keep up to 16 idle buffers in a free list so they survive GC
+	Balanced PresetKind = iota
+	// LatencyOptimized covers 128B to 2MB tiers and skips buffer zeroing to keep Put cheap
+	// Counters are batched per shard, tiers from 64KB keep up to 64 idle buffers in a free
+	// list and an empty tier borrows from the next larger one before allocating
+	LatencyOptimized
+	// MemoryOptimized keeps only tiers up to 64KB so large buffers are left to the GC,
+	// and zeroes buffers on put so pooled memory never retains stale payloads
+	// Tiers from 16KB keep at most 4 idle buffers and trim those idle for a minute, Gets
+	// above 1MB are refused and Gets are throttled while 64MB are leased
+	MemoryOptimized
+)
+
+// preset parameters
+const (
+	presetLargeTier       = 65536 // smallest tier kept in a free list by Balanced and LatencyOptimized
+	presetBalancedIdle    = 16
+	presetLatencyIdle     = 64
+	presetMemoryLargeTier = 16384 // smallest tier kept in a trimmed free list by MemoryOptimized
+	presetMemoryIdle      = 4
+	presetMemoryIdleTTL   = time.Minute
+	presetMemoryBudget    = 64 << 20
+	presetMemoryMaxGet    = 1 << 20
+)
+
+// String returns the preset name
+func (k PresetKind) String() string {
+	switch k {
+	case Balanced:
+		return "balanced"
+	case LatencyOptimized:
+		return "latency"
+	case MemoryOptimized:
+		return "memory"
+	default:
+		return "unknown"
+	}
+}
+
+// Preset returns a fully configured option bundle for the given kind
+// Tiers are set through WithSizes, so the sizes passed to NewPools may be nil:
+//
+//	pool := bytepool.NewPools(nil, bytepool.Preset(bytepool.Balanced)...)
+//
+// Options appended after the preset override its choices
+// Panics if kind is not a known preset
+func Preset(kind PresetKind) []Option {
+	switch kind {
+	case LatencyOptimized:
+		return []Option{
+			WithSizes(SizePowerOfTwo()),
+			WithRingQueueType(LockFreeRingQueue),
+			WithBackendForRange(presetLargeTier, math.MaxInt, FreeListBackend(presetLatencyIdle)),
+			WithBatchedStats(),
+			WithTierFallback(1),
+		}
+	case MemoryOptimized:
+		return []Option{
+			WithSizes(sizesUpTo(SizePowerOfTwo(), 65536)),
+			WithRingQueueType(LockFreeRingQueue),
+			WithZeroOnPut(),
+			WithBackendForRange(presetMemoryLargeTier, math.MaxInt,
+				FreeListBackendWithPolicy(presetMemoryIdle, EvictLRU(presetMemoryIdleTTL))),
+			WithSoftBudget(presetMemoryBudget, time.Millisecond),
+			WithMaxGetLength(presetMemoryMaxGet),
+		}
+	case Balanced:
+		return []Option{
+			WithSizes(sizesUpTo(SizePowerOfTwo(), 1048576)),
+			WithRingQueueType(LockFreeRingQueue),
+			WithBackendForRange(presetLargeTier, math.MaxInt, FreeListBackend(presetBalancedIdle)),
+		}
+	default:
+		panic("unknown preset kind")
+	}
+}
+
+// sizesUpTo returns the sizes not exceeding limit
+func sizesUpTo(sizes []int, limit int) []int {
+	out := sizes[:0]
+	for _, size := range sizes {
+		if size <= limit {
+			out = append(out, size)
+		}
+	}
+	return out
+}
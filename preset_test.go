@@ -0,0 +1,104 @@
+package bytepool
+
+import "testing"
+
+func TestPreset(t *testing.T) {
+	tests := []struct {
+		kind      PresetKind
+		maxSize   int
+		zeroOnPut bool
+	}{
+		{Balanced, 1048576, false},
+		{LatencyOptimized, 2097152, false},
+		{MemoryOptimized, 65536, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind.String(), func(t *testing.T) {
+			pool := NewPools(nil, Preset(tt.kind)...)
+			if pool.GetMax() != tt.maxSize {
+				t.Errorf("Expected max size %d, got %d", tt.maxSize, pool.GetMax())
+			}
+			if (pool.Config().Hygiene == HygieneZero) != tt.zeroOnPut {
+				t.Errorf("Expected zeroOnPut %v, got %v", tt.zeroOnPut, pool.Config().Hygiene)
+			}
+		})
+	}
+}
+
+func TestPreset_Choices(t *testing.T) {
+	balanced := NewPools(nil, Preset(Balanced)...)
+	latency := NewPools(nil, Preset(LatencyOptimized)...)
+	memory := NewPools(nil, Preset(MemoryOptimized)...)
+
+	if b, ok := balanced.backendFor(65536).(freeListBackend); !ok || b.maxIdle != 16 {
+		t.Errorf("Expected balanced 64KB tier in a free list of 16, got %#v", balanced.backendFor(65536))
+	}
+	if _, ok := balanced.backendFor(4096).(syncPoolBackend); !ok {
+		t.Errorf("Expected balanced small tiers in sync.Pool, got %#v", balanced.backendFor(4096))
+	}
+
+	if b, ok := latency.backendFor(1048576).(freeListBackend); !ok || b.maxIdle != 64 {
+		t.Errorf("Expected latency large tiers in a free list of 64, got %#v", latency.backendFor(1048576))
+	}
+	if !latency.batchedStats || latency.Config().TierFallback != 1 {
+		t.Errorf("Expected latency preset with batched stats and tier fallback, got %v and %d",
+			latency.batchedStats, latency.Config().TierFallback)
+	}
+	if balanced.batchedStats || balanced.Config().TierFallback != 0 {
+		t.Error("Expected balanced preset without batched stats or tier fallback")
+	}
+
+	b, ok := memory.backendFor(16384).(freeListBackend)
+	if !ok || b.maxIdle != 4 || b.policy.kind != evictLRU {
+		t.Errorf("Expected memory 16KB tier in a trimmed free list of 4, got %#v", memory.backendFor(16384))
+	}
+	if cfg := memory.Config(); cfg.SoftBudget != 64<<20 || cfg.MaxGetLength != 1<<20 {
+		t.Errorf("Expected a 64MB soft budget and 1MB max get, got %d and %d", cfg.SoftBudget, cfg.MaxGetLength)
+	}
+	if memory.Get(2<<20) != nil {
+		t.Error("Expected memory preset to refuse a 2MB Get")
+	}
+	if cfg := balanced.Config(); cfg.SoftBudget != 0 || cfg.MaxGetLength != 0 {
+		t.Error("Expected balanced preset without limits")
+	}
+}
+
+func TestPreset_Override(t *testing.T) {
+	opts := append(Preset(MemoryOptimized), WithSizes([]int{256, 128}))
+	pool := NewPools(nil, opts...)
+
+	sizes := pool.GetAvailableSizes()
+	if len(sizes) != 2 || sizes[0] != 128 || sizes[1] != 256 {
+		t.Errorf("Expected sizes [128 256], got %v", sizes)
+	}
+}
+
+func TestWithZeroOnPut(t *testing.T) {
+	pool := NewPools([]int{128}, WithZeroOnPut())
+
+	buf := pool.Get(128)
+	for i := range buf {
+		buf[i] = 0xFF
+	}
+	pool.Put(buf)
+
+	// sync.Pool may drop the item, only check when the same array comes back
+	again := pool.Get(128)
+	if &again[0] == &buf[0] {
+		for i, b := range again {
+			if b != 0 {
+				t.Fatalf("Expected zeroed byte at %d, got %#x", i, b)
+			}
+		}
+	}
+}
+
+func TestPresetUnknownKind(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for an unknown preset kind")
+		}
+	}()
+	Preset(PresetKind(99))
+}
@@ -0,0 +1,109 @@
+package bytepool
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// pressureCheckInterval bounds how often Gets recompute the held bytes
+const pressureCheckInterval = 100 * time.Millisecond
+
+// pressureWatch is a callback registered with OnPressure
+type pressureWatch struct {
+	threshold float64
+	fn        func(Report)
+	active    atomic.Bool // fired and held bytes not yet back below the threshold
+}
+
+// pressureWatches holds the OnPressure callbacks of a pool
+type pressureWatches struct {
+	mu      sync.Mutex
+	list    atomic.Pointer[[]*pressureWatch]
+	nextRun atomic.Int64 // unix nanos before which Gets skip the check
+}
+
+// OnPressure calls f when the bytes held by the pool, leased plus idle, reach threshold
+// times the soft budget, e.g. 0.8 to shed load before Gets are throttled. f fires once
+// per crossing and again only after held bytes dropped below the threshold
+// Held bytes are rechecked by Gets at most every 100ms and by every Stats call. Idle
+// buffers only count when their store knows them exactly, so sync.Pool tiers, whose idle
+// count is an estimate, contribute their leased bytes only
+// Without WithSoftBudget f never fires
+// f runs on the Get path and must not block
+func (p *BytePool) OnPressure(threshold float64, f func(Report)) {
+	if threshold <= 0 || f == nil {
+		panic("pressure threshold must be positive and f non-nil")
+	}
+	w := &p.pressure
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var list []*pressureWatch
+	if cur := w.list.Load(); cur != nil {
+		list = append(list, *cur...)
+	}
+	list = append(list, &pressureWatch{threshold: threshold, fn: f})
+	w.list.Store(&list)
+}
+
+// checkPressure recomputes the held bytes after a Get, rate limited
+func (p *BytePool) checkPressure() {
+	w := &p.pressure
+	if w.list.Load() == nil || p.state.Load().cfg.SoftBudget <= 0 {
+		return
+	}
+	now := p.now().UnixNano()
+	next := w.nextRun.Load()
+	if now < next || !w.nextRun.CompareAndSwap(next, now+int64(pressureCheckInterval)) {
+		return
+	}
+	p.evalPressure(p.heldBytes(), nil)
+}
+
+// heldBytes sums the leased and exactly known idle bytes of the tiers, without building
+// a report
+func (p *BytePool) heldBytes() int64 {
+	st := p.state.Load()
+	var held int64
+	for i := range st.tiers {
+		t := &st.tiers[i]
+		p.flushTier(t.stats)
+		held += max(atomic.LoadInt64(&t.stats.Get)-atomic.LoadInt64(&t.stats.Put), 0) * int64(t.size)
+		if idle, exact := idleCount(t.store); exact {
+			held += idle * int64(t.size)
+		}
+	}
+	return held
+}
+
+// pressureBytes returns the held bytes of report the watches compare, see heldBytes
+func pressureBytes(report *Report) int64 {
+	held := report.InUseBytes
+	for i := range report.Tiers {
+		if report.Tiers[i].IdleExact {
+			held += report.Tiers[i].IdleBytes
+		}
+	}
+	return held
+}
+
+// evalPressure fires the watches whose threshold held crossed and re-arms the others
+// A nil report is built only when a watch fires
+func (p *BytePool) evalPressure(held int64, report *Report) {
+	list := p.pressure.list.Load()
+	budget := p.state.Load().cfg.SoftBudget
+	if list == nil || budget <= 0 {
+		return
+	}
+	for _, w := range *list {
+		if float64(held) < w.threshold*float64(budget) {
+			w.active.Store(false)
+		} else if w.active.CompareAndSwap(false, true) {
+			if report == nil {
+				r := p.report()
+				report = &r
+			}
+			w.fn(*report)
+		}
+	}
+}
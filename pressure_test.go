@@ -0,0 +1,105 @@
+package bytepool
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBytePool_OnPressure(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	pool := NewPools([]int{1024}, WithSoftBudget(10*1024, time.Millisecond), WithClock(clock),
+		WithBackend(FreeListBackend(16)))
+
+	var fired []int64
+	pool.OnPressure(0.5, func(r Report) { fired = append(fired, r.HeldBytes) })
+
+	bufs := make([][]byte, 0, 6)
+	for range 4 {
+		bufs = append(bufs, pool.Get(1000))
+		clock.Advance(time.Second)
+	}
+	if len(fired) != 0 {
+		t.Fatalf("Expected no callback below the threshold, got %v", fired)
+	}
+	bufs = append(bufs, pool.Get(1000)) // 5 KiB held
+	if len(fired) != 1 || fired[0] != 5*1024 {
+		t.Fatalf("Expected one callback at 5 KiB held, got %v", fired)
+	}
+
+	// returned buffers stay idle, so the pool still holds them
+	for _, buf := range bufs {
+		pool.Put(buf)
+	}
+	clock.Advance(time.Second)
+	pool.Put(pool.Get(1000))
+	if len(fired) != 1 {
+		t.Errorf("Expected a single callback per crossing, got %v", fired)
+	}
+}
+
+func TestBytePool_OnPressureRearm(t *testing.T) {
+	pool := NewPools([]int{1024}, WithSoftBudget(4*1024, time.Millisecond))
+
+	count := 0
+	pool.OnPressure(0.5, func(Report) { count++ })
+	a, b := pool.Get(1000), pool.Get(1000)
+	pool.Stats()
+	if count != 1 {
+		t.Fatalf("Expected callback from Stats, got %d", count)
+	}
+
+	pool.ApplyConfig(PoolConfig{Sizes: []int{1024}, SoftBudget: 64 * 1024, SoftDelay: time.Millisecond})
+	pool.Stats() // below the threshold again, re-arms
+	pool.ApplyConfig(PoolConfig{Sizes: []int{1024}, SoftBudget: 4 * 1024, SoftDelay: time.Millisecond})
+	pool.Stats()
+	if count != 2 {
+		t.Errorf("Expected the callback to fire again after re-arming, got %d", count)
+	}
+	pool.Put(a)
+	pool.Put(b)
+}
+
+func TestBytePool_PressureCheckHeldBytesOnly(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	pool := NewPools([]int{128, 1024}, WithSoftBudget(1<<20, time.Millisecond), WithClock(clock),
+		WithBackend(FreeListBackend(16)))
+	pool.OnPressure(0.5, func(Report) { t.Error("Expected no callback below the threshold") })
+
+	held := pool.Get(1000)
+	pool.Put(pool.Get(100))
+	if got, want := pool.heldBytes(), pool.Stats().HeldBytes; got != want || got != 1024+128 {
+		t.Errorf("Expected held bytes %d as in Stats, got %d", want, got)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		clock.Advance(time.Second)
+		pool.checkPressure()
+	})
+	if allocs != 0 {
+		t.Errorf("Expected the pressure check not to build a report, got %v allocs", allocs)
+	}
+	pool.Put(held)
+}
+
+func TestBytePool_PressureIgnoresEstimatedIdle(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	pool := NewPools([]int{1024}, WithSoftBudget(16*1024, time.Millisecond), WithClock(clock))
+	fired := 0
+	pool.OnPressure(0.5, func(Report) { fired++ })
+
+	// only the first Get runs the rate limited check, below the threshold
+	bufs := make([][]byte, 10)
+	for i := range bufs {
+		bufs[i] = pool.Get(1000)
+	}
+	for _, buf := range bufs {
+		pool.Put(buf)
+	}
+	pool.Stats()
+	if fired != 0 {
+		t.Errorf("Expected estimated sync.Pool idle bytes left out of pressure, fired %d times", fired)
+	}
+	if got := pool.heldBytes(); got != 0 {
+		t.Errorf("Expected no leased or exactly idle bytes, got %d", got)
+	}
+}
@@ -0,0 +1,76 @@
+package bytepool
+
+import (
+	"slices"
+)
+
+// PrewarmFromSamples pre-allocates totalBuffers buffers across tiers in proportion to
+// the observed size distribution in samples, e.g. the recent_lengths from GetPoolStats
+// Samples that are non-positive or exceed the largest tier are ignored
+// Returns the number of buffers placed into each tier
+func (p *BytePool) PrewarmFromSamples(samples []int, totalBuffers int) map[int]int {
+	plan := make(map[int]int)
+	if totalBuffers <= 0 {
+		return plan
+	}
+
+	st := p.state.Load()
+	counts := make(map[int]int)
+	valid := 0
+	for _, length := range samples {
+		if length <= 0 || length > st.maxSize {
+			continue
+		}
+		counts[st.findBestSize(length)]++
+		valid++
+	}
+	if valid == 0 {
+		return plan
+	}
+
+	// largest remainder method keeps the total exact
+	type remainder struct {
+		size int
+		frac int
+	}
+	rems := make([]remainder, 0, len(counts))
+	assigned := 0
+	for size, count := range counts {
+		n := count * totalBuffers / valid
+		plan[size] = n
+		assigned += n
+		rems = append(rems, remainder{size: size, frac: count * totalBuffers % valid})
+	}
+	slices.SortFunc(rems, func(a, b remainder) int {
+		if a.frac != b.frac {
+			return b.frac - a.frac
+		}
+		return a.size - b.size
+	})
+	for i := 0; assigned < totalBuffers; i++ {
+		plan[rems[i%len(rems)].size]++
+		assigned++
+	}
+
+	for size, n := range plan {
+		if n == 0 {
+			delete(plan, size)
+			continue
+		}
+		p.prewarm(size, n)
+	}
+	return plan
+}
+
+// prewarm places count freshly allocated buffers into the given tier
+// It bypasses Get/Put so statistics are not affected
+func (p *BytePool) prewarm(size, count int) {
+	t := p.state.Load().tier(size)
+	if t == nil {
+		return
+	}
+	for range count {
+		buf := make([]byte, size)
+		t.put(buf)
+	}
+}
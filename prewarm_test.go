@@ -0,0 +1,42 @@
+package bytepool
+
+import "testing"
+
+func TestBytePool_PrewarmFromSamples(t *testing.T) {
+	pool := NewPools([]int{128, 256, 512})
+
+	// 3/4 of traffic maps to the 128 tier, 1/4 to 512, oversize is ignored
+	samples := []int{10, 20, 100, 500, 4096}
+	plan := pool.PrewarmFromSamples(samples, 10)
+
+	total := 0
+	for _, n := range plan {
+		total += n
+	}
+	if total != 10 {
+		t.Errorf("Expected 10 buffers, got %d (%v)", total, plan)
+	}
+	if plan[128] != 8 || plan[512] != 2 {
+		t.Errorf("Expected plan map[128:8 512:2], got %v", plan)
+	}
+	if _, ok := plan[256]; ok {
+		t.Errorf("Expected no buffers for tier 256, got %v", plan)
+	}
+
+	// prewarming must not churn the statistics
+	stats := pool.GetPoolStats()
+	if stats["total_get"].(int64) != 0 || stats["total_put"].(int64) != 0 {
+		t.Errorf("Expected untouched stats, got get=%v put=%v", stats["total_get"], stats["total_put"])
+	}
+}
+
+func TestBytePool_PrewarmFromSamplesEmpty(t *testing.T) {
+	pool := NewPools([]int{128})
+
+	if plan := pool.PrewarmFromSamples(nil, 10); len(plan) != 0 {
+		t.Errorf("Expected empty plan, got %v", plan)
+	}
+	if plan := pool.PrewarmFromSamples([]int{64}, 0); len(plan) != 0 {
+		t.Errorf("Expected empty plan, got %v", plan)
+	}
+}
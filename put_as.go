@@ -0,0 +1,44 @@
+package bytepool
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// PutAs returns buf to the given tier even though its capacity no longer matches,
+// for callers that capped a pooled buffer with a full slice expression like buf[:n:n]
+// Re-extending a capped buffer needs proof it was leased from the tier, so it is only
+// done while lease records are kept, in debug mode or with StatsHoldTimes. Otherwise
+// only buffers with the full capacity of the tier are accepted
+// Buffers that cannot belong to the tier are discarded, or panic in debug mode
+func (p *BytePool) PutAs(buf []byte, tier int) {
+	if buf == nil {
+		return
+	}
+	if !p.leasedFrom(buf, tier) {
+		p.discard(DiscardTierMismatch, cap(buf))
+		if p.debug {
+			panic(fmt.Sprintf("bytepool: PutAs buffer with cap %d cannot belong to tier %d", cap(buf), tier))
+		}
+		return
+	}
+	p.Put(unsafe.Slice(unsafe.SliceData(buf), tier))
+}
+
+// leasedFrom reports whether buf can be returned to tier, a capped buffer needs a lease
+// record of that tier for its backing array
+func (p *BytePool) leasedFrom(buf []byte, tier int) bool {
+	if p.state.Load().tier(tier) == nil || cap(buf) > tier || cap(buf) == 0 {
+		return false
+	}
+	if !p.tracksLeases() {
+		return cap(buf) == tier
+	}
+	key, _ := leaseKey(buf)
+	v, ok := p.leases.Load(key)
+	if !ok {
+		return false
+	}
+	rec, leased := v.(leaseRecord)
+	return leased && rec.size == tier
+}
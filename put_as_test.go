@@ -0,0 +1,65 @@
+package bytepool
+
+import "testing"
+
+func TestBytePool_PutAs(t *testing.T) {
+	pool := NewPools([]int{128, 256}, WithBackend(FreeListBackend(4)), WithDebug())
+
+	buf := pool.Get(200)
+	capped := buf[:10:10]
+
+	pool.Put(capped) // capacity 10 matches no tier
+	if pool.Stats().TotalPut != 0 {
+		t.Fatal("Expected plain Put of a capped buffer to be dropped")
+	}
+
+	pool.PutAs(capped, 256)
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected buffer returned, got %d outstanding", pool.Outstanding())
+	}
+	again := pool.Get(256)
+	if cap(again) != 256 || &again[0] != &buf[0] {
+		t.Error("Expected the original tier buffer to be reused")
+	}
+}
+
+func TestBytePool_PutAsInvalid(t *testing.T) {
+	pool := NewPools([]int{128, 256})
+
+	pool.PutAs(make([]byte, 200), 128) // larger than the tier
+	pool.PutAs(make([]byte, 10), 100)  // not a tier
+	pool.PutAs(pool.Get(200)[:10:10], 256)
+	if got := pool.GetDiscardedCount(); got != 3 {
+		t.Errorf("Expected 3 discarded, got %d", got)
+	}
+	pool.PutAs(pool.Get(200), 256)
+	if got := pool.Stats().TotalPut; got != 1 {
+		t.Errorf("Expected a full capacity buffer returned, got %d puts", got)
+	}
+
+	debugPool := NewPools([]int{128}, WithDebug())
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic in debug mode")
+		}
+	}()
+	debugPool.PutAs(make([]byte, 200), 128)
+}
+
+func TestBytePool_PutAsUnleased(t *testing.T) {
+	pool := NewPools([]int{128, 256}, WithDebug())
+
+	for _, buf := range [][]byte{make([]byte, 10), pool.Get(100)[:10:10]} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("Expected panic for a buffer not leased from the tier")
+				}
+			}()
+			pool.PutAs(buf, 256)
+		}()
+	}
+	if pool.Stats().TotalPut != 0 {
+		t.Error("Expected no buffer returned")
+	}
+}
@@ -0,0 +1,46 @@
+package bytepool
+
+import "log/slog"
+
+// DefaultMaxRefCount is the number of references a Buffer may hold before it saturates
+const DefaultMaxRefCount = 1 << 24
+
+// maxRefCountLimit keeps concurrent Retains past the limit far from int32 overflow
+const maxRefCountLimit = 1 << 30
+
+// WithMaxRefCount sets the number of references a Buffer of the pool may hold, a Retain
+// past it is a MisuseRefCountOverflow. Without this option the limit is DefaultMaxRefCount
+// Panics when max is not positive or above 1<<30
+func WithMaxRefCount(max int32) Option {
+	if max <= 0 || max > maxRefCountLimit {
+		panic("max refcount must be in (0, 1<<30]")
+	}
+	return func(p *BytePool) {
+		p.maxRefCount = max
+	}
+}
+
+// maxRefs returns the reference limit of the buffer
+func (b *Buffer) maxRefs() int32 {
+	if b.pools != nil && b.pools.maxRefCount > 0 {
+		return b.pools.maxRefCount
+	}
+	return DefaultMaxRefCount
+}
+
+// saturate pins the buffer after a Retain past the limit, so a retain leak can never wrap
+// the count and free memory that is still referenced. Retain and Release become no-ops
+// and the memory is left to GC instead of returning to the pool
+func (b *Buffer) saturate(n int32) {
+	if !b.saturated.CompareAndSwap(false, true) || b.pools == nil {
+		return
+	}
+	p := b.pools
+	def := MisuseLog
+	if p.debug {
+		def = MisusePanic
+	}
+	if p.misuse(MisuseRefCountOverflow, def, "buffer retained %d times, above the limit of %d", n, b.maxRefs()) {
+		p.logEvent(slog.LevelWarn, eventOverflow, "bytepool: buffer refcount saturated", slog.Int("refs", int(n)))
+	}
+}
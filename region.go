@@ -0,0 +1,87 @@
+package bytepool
+
+import (
+	"context"
+	"sync"
+)
+
+// regionBlockSize is the preferred block size carved by a Region
+const regionBlockSize = 32768
+
+// Region is a bump allocator carving small allocations out of pooled blocks
+// All allocations share the lifetime of the region and are recycled together by Reset,
+// which suits per-request scratch memory
+type Region struct {
+	mu        sync.Mutex
+	pool      *BytePool
+	blockSize int
+	blocks    [][]byte
+	current   []byte // remaining space of the last block
+}
+
+// Region creates a bump allocator backed by the pool
+func (p *BytePool) Region() *Region {
+	return &Region{pool: p, blockSize: p.clampLength(p.findBestSize(regionBlockSize))}
+}
+
+// Alloc returns a zeroed slice of n bytes valid until Reset
+// Allocations larger than a block get a dedicated pooled buffer, nil when the pool
+// refuses n, see WithMaxGetLength
+func (r *Region) Alloc(n int) []byte {
+	if n <= 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if n > r.blockSize {
+		buf, err := r.pool.GetE(n)
+		if err != nil {
+			return nil
+		}
+		r.blocks = append(r.blocks, buf)
+		buf = buf[:n:n]
+		clear(buf)
+		return buf
+	}
+	if len(r.current) < n {
+		block, err := r.pool.GetE(r.blockSize)
+		if err != nil {
+			// the max get length was lowered by ApplyConfig since the region was created
+			return nil
+		}
+		r.blocks = append(r.blocks, block)
+		r.current = block
+	}
+	buf := r.current[:n:n]
+	clear(buf)
+	r.current = r.current[n:]
+	return buf
+}
+
+// Reset returns every block to the pool, slices returned by Alloc must no longer be used
+func (r *Region) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, block := range r.blocks {
+		r.pool.Put(block)
+	}
+	clear(r.blocks)
+	r.blocks = r.blocks[:0]
+	r.current = nil
+}
+
+type regionKey struct{}
+
+// ContextWithRegion returns a copy of ctx carrying the region
+func ContextWithRegion(ctx context.Context, r *Region) context.Context {
+	return context.WithValue(ctx, regionKey{}, r)
+}
+
+// RegionFromContext returns the region stored in ctx, or nil if there is none
+func RegionFromContext(ctx context.Context) *Region {
+	r, _ := ctx.Value(regionKey{}).(*Region)
+	return r
+}
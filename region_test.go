@@ -0,0 +1,77 @@
+package bytepool
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRegion(t *testing.T) {
+	pool := NewPools(SizePowerOfTwo())
+	r := pool.Region()
+
+	a := r.Alloc(100)
+	b := r.Alloc(200)
+	if len(a) != 100 || cap(a) != 100 || len(b) != 200 {
+		t.Errorf("Unexpected slice shapes: a %d/%d, b %d", len(a), cap(a), len(b))
+	}
+	if &a[99] == &b[0] {
+		t.Error("Expected allocations not to overlap")
+	}
+
+	// small allocations share one block, large ones get their own
+	big := r.Alloc(100000)
+	if len(big) != 100000 {
+		t.Errorf("Expected 100000 bytes, got %d", len(big))
+	}
+	if pool.Outstanding() != 2 {
+		t.Errorf("Expected 2 leased blocks, got %d", pool.Outstanding())
+	}
+	if r.Alloc(0) != nil {
+		t.Error("Expected nil for zero length")
+	}
+
+	r.Reset()
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected all blocks returned, got %d outstanding", pool.Outstanding())
+	}
+
+	// the region is reusable after reset
+	if c := r.Alloc(10); len(c) != 10 {
+		t.Errorf("Expected 10 bytes after reset, got %d", len(c))
+	}
+	r.Reset()
+}
+
+func TestRegionContext(t *testing.T) {
+	pool := NewPools(SizePowerOfTwo())
+	r := pool.Region()
+
+	ctx := ContextWithRegion(context.Background(), r)
+	if RegionFromContext(ctx) != r {
+		t.Error("Expected region from context")
+	}
+	if RegionFromContext(context.Background()) != nil {
+		t.Error("Expected nil region for empty context")
+	}
+}
+
+func TestRegionLargeAllocZeroed(t *testing.T) {
+	pool := NewPools([]int{1024, regionBlockSize, 65536}, WithBackend(FreeListBackend(4)))
+	dirty := pool.Get(65536)
+	for i := range dirty {
+		dirty[i] = 0xaa
+	}
+	pool.Put(dirty)
+
+	region := pool.Region()
+	buf := region.Alloc(40000)
+	if &buf[0] != &dirty[0] {
+		t.Fatal("Expected the dirty buffer to be reused")
+	}
+	for i, b := range buf {
+		if b != 0 {
+			t.Fatalf("Expected zeroed allocation, got %#x at %d", b, i)
+		}
+	}
+	region.Reset()
+}
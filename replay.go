@@ -0,0 +1,136 @@
+package bytepool
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// OpKind is the kind of a recorded pool operation
+type OpKind string
+
+const (
+	OpGet       OpKind = "get"        // Get(Len), the result becomes handle ID
+	OpPut       OpKind = "put"        // Put of handle ID
+	OpGetBuffer OpKind = "get_buffer" // GetBuffer(Len), the result becomes handle ID
+	OpRetain    OpKind = "retain"     // Retain on Buffer handle ID
+	OpRelease   OpKind = "release"    // Release on Buffer handle ID
+)
+
+// Op is one recorded pool operation, an op log is a JSON line per Op in execution order
+type Op struct {
+	Kind OpKind `json:"op"`
+	G    int    `json:"g"`             // goroutine that performed the op in the recording
+	ID   int    `json:"id"`            // buffer handle, assigned by get ops and referenced by the others
+	Len  int    `json:"len,omitempty"` // requested length of get ops
+}
+
+// ReadOpLog decodes an op log of JSON lines, blank lines are skipped
+func ReadOpLog(r io.Reader) ([]Op, error) {
+	var ops []Op
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var op Op
+		if err := json.Unmarshal(scanner.Bytes(), &op); err != nil {
+			return nil, fmt.Errorf("op log line %d: %w", line, err)
+		}
+		ops = append(ops, op)
+	}
+	return ops, scanner.Err()
+}
+
+// WriteOpLog encodes ops as JSON lines
+func WriteOpLog(w io.Writer, ops []Op) error {
+	enc := json.NewEncoder(w)
+	for _, op := range ops {
+		if err := enc.Encode(op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replayHandle is a buffer leased during replay
+type replayHandle struct {
+	buf    []byte
+	buffer *Buffer
+	refs   int
+}
+
+// Replay runs ops against pool one at a time in log order, so a trace recorded across
+// goroutines replays deterministically. It stops at the first op that misuses a handle,
+// e.g. a Put of a buffer never leased or a Release below zero references, and checks
+// the pool counters against the handles still held once all ops ran
+func Replay(pool *BytePool, ops []Op) error {
+	handles := make(map[int]*replayHandle)
+	for i, op := range ops {
+		fail := func(format string, args ...any) error {
+			return fmt.Errorf("op %d (%s id %d, goroutine %d): %s", i, op.Kind, op.ID, op.G, fmt.Sprintf(format, args...))
+		}
+		h := handles[op.ID]
+		switch op.Kind {
+		case OpGet, OpGetBuffer:
+			if h != nil {
+				return fail("handle still in use")
+			}
+			if op.Len < 0 {
+				return fail("negative length %d", op.Len)
+			}
+			h = &replayHandle{refs: 1}
+			if op.Kind == OpGet {
+				h.buf = pool.Get(op.Len)
+			} else {
+				h.buffer = pool.GetBuffer(op.Len)
+			}
+			handles[op.ID] = h
+		case OpPut:
+			if h == nil || h.buf == nil {
+				return fail("put of a buffer not leased by get")
+			}
+			pool.Put(h.buf)
+			delete(handles, op.ID)
+		case OpRetain:
+			if h == nil || h.buffer == nil {
+				return fail("retain of an unknown Buffer")
+			}
+			h.buffer.Retain()
+			h.refs++
+		case OpRelease:
+			if h == nil || h.buffer == nil {
+				return fail("release of an unknown Buffer")
+			}
+			h.buffer.Release()
+			if h.refs--; h.refs == 0 {
+				delete(handles, op.ID)
+			}
+		default:
+			return fail("unknown op")
+		}
+	}
+
+	held := int64(0)
+	for _, h := range handles {
+		n := cap(h.buf)
+		if h.buffer != nil {
+			if data := h.buffer.buf.Load(); data != nil {
+				n = cap(*data)
+			}
+		}
+		if pool.state.Load().tier(n) != nil {
+			held++
+		}
+	}
+	if got := pool.Outstanding(); got != held {
+		return fmt.Errorf("pool reports %d outstanding buffers, the op log holds %d", got, held)
+	}
+	for _, tier := range pool.Stats().Tiers {
+		if tier.Put > tier.Get {
+			return fmt.Errorf("tier %d counts %d puts for %d gets", tier.Size, tier.Put, tier.Get)
+		}
+	}
+	return nil
+}
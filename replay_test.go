@@ -0,0 +1,37 @@
+package bytepool
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReplay_Misuse(t *testing.T) {
+	cases := map[string][]Op{
+		"put unknown":      {{Kind: OpPut, ID: 1}},
+		"double put":       {{Kind: OpGet, ID: 1, Len: 10}, {Kind: OpPut, ID: 1}, {Kind: OpPut, ID: 1}},
+		"release too much": {{Kind: OpGetBuffer, ID: 1, Len: 10}, {Kind: OpRelease, ID: 1}, {Kind: OpRelease, ID: 1}},
+		"handle reuse":     {{Kind: OpGet, ID: 1, Len: 10}, {Kind: OpGet, ID: 1, Len: 10}},
+		"unknown op":       {{Kind: "alloc", ID: 1}},
+	}
+	for name, ops := range cases {
+		if err := Replay(NewPools([]int{128}), ops); err == nil {
+			t.Errorf("%s: expected replay to fail", name)
+		}
+	}
+}
+
+func TestOpLog_RoundTrip(t *testing.T) {
+	ops := []Op{{Kind: OpGet, G: 1, ID: 7, Len: 64}, {Kind: OpPut, G: 2, ID: 7}}
+	var buf bytes.Buffer
+	if err := WriteOpLog(&buf, ops); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadOpLog(&buf)
+	if err != nil || len(got) != 2 || got[0] != ops[0] || got[1] != ops[1] {
+		t.Errorf("Expected %v, got %v, %v", ops, got, err)
+	}
+	if _, err := ReadOpLog(strings.NewReader("{")); err == nil {
+		t.Error("Expected decode error")
+	}
+}
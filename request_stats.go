@@ -0,0 +1,64 @@
+package bytepool
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// RequestStats tallies the pool operations performed under one request's context
+type RequestStats struct {
+	gets        int64
+	puts        int64
+	leasedBytes int64
+}
+
+type requestStatsKey struct{}
+
+// ContextWithStats returns a copy of ctx carrying a fresh RequestStats, so every
+// GetContext and PutContext under it is additionally attributed to the request
+func ContextWithStats(ctx context.Context) context.Context {
+	return context.WithValue(ctx, requestStatsKey{}, &RequestStats{})
+}
+
+// StatsFromContext returns the RequestStats carried by ctx, or nil if there is none
+func StatsFromContext(ctx context.Context) *RequestStats {
+	s, _ := ctx.Value(requestStatsKey{}).(*RequestStats)
+	return s
+}
+
+// Gets returns the number of buffers leased under the request
+func (s *RequestStats) Gets() int64 {
+	return atomic.LoadInt64(&s.gets)
+}
+
+// Puts returns the number of buffers returned under the request
+func (s *RequestStats) Puts() int64 {
+	return atomic.LoadInt64(&s.puts)
+}
+
+// LeasedBytes returns the total capacity of the buffers leased under the request
+func (s *RequestStats) LeasedBytes() int64 {
+	return atomic.LoadInt64(&s.leasedBytes)
+}
+
+// String summarizes the tally for logging
+func (s *RequestStats) String() string {
+	return fmt.Sprintf("leased %d bytes across %d buffers, returned %d", s.LeasedBytes(), s.Gets(), s.Puts())
+}
+
+// recordGet attributes a leased buffer to the request carried by ctx
+func recordGet(ctx context.Context, buf []byte) {
+	if s := StatsFromContext(ctx); s != nil && buf != nil {
+		atomic.AddInt64(&s.gets, 1)
+		atomic.AddInt64(&s.leasedBytes, int64(cap(buf)))
+	}
+}
+
+// PutContext returns a []byte to the pool like Put, attributing it to the request in ctx
+func (p *BytePool) PutContext(ctx context.Context, buf []byte) {
+	if s := StatsFromContext(ctx); s != nil && buf != nil {
+		atomic.AddInt64(&s.puts, 1)
+	}
+	p.Put(buf)
+}
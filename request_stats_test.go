@@ -0,0 +1,32 @@
+package bytepool
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRequestStats(t *testing.T) {
+	pool := NewPools([]int{128, 1024})
+	ctx := ContextWithStats(context.Background())
+
+	a, _ := pool.GetContext(ctx, 100)
+	b, _ := pool.GetContext(ctx, 1000)
+	pool.Put(pool.Get(10)) // not under the request
+	pool.PutContext(ctx, a)
+
+	s := StatsFromContext(ctx)
+	if s.Gets() != 2 || s.Puts() != 1 || s.LeasedBytes() != 1152 {
+		t.Errorf("Unexpected tally: %s", s)
+	}
+	pool.PutContext(ctx, b)
+	if s.Puts() != 2 {
+		t.Errorf("Expected 2 puts, got %d", s.Puts())
+	}
+
+	if StatsFromContext(context.Background()) != nil {
+		t.Error("Expected nil stats for plain context")
+	}
+	// operations without request stats still work
+	buf, _ := pool.GetContext(context.Background(), 10)
+	pool.PutContext(context.Background(), buf)
+}
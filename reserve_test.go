@@ -0,0 +1,44 @@
+package bytepool
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBytePool_Reserve(t *testing.T) {
+	pool := NewPools([]int{128, 256}, WithBackend(FreeListBackend(8)))
+
+	if err := pool.Reserve(256, 3); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n := pool.state.Load().tier(256).store.(*freeListStore).Len(); n != 3 {
+		t.Errorf("Expected 3 idle buffers, got %d", n)
+	}
+	if pool.GetPoolStats()["total_get"].(int64) != 0 {
+		t.Error("Expected Reserve not to touch the statistics")
+	}
+
+	if err := pool.Reserve(200, 1); !errors.Is(err, ErrInvalidTier) {
+		t.Errorf("Expected ErrInvalidTier, got %v", err)
+	}
+	if err := pool.Reserve(128, 0); !errors.Is(err, ErrInvalidCount) {
+		t.Errorf("Expected ErrInvalidCount, got %v", err)
+	}
+}
+
+func TestBytePool_AllocShim(t *testing.T) {
+	pool := NewPools([]int{128, 256}, WithBackend(FreeListBackend(8)))
+
+	if pool.Alloc(0) != nil || pool.Alloc(1024) != nil {
+		t.Error("Expected nil for invalid sizes")
+	}
+	if pool.Alloc(200) != pool {
+		t.Error("Expected Alloc to return the pool")
+	}
+	if n := pool.state.Load().tier(256).store.(*freeListStore).Len(); n != 1 {
+		t.Errorf("Expected 1 idle buffer, got %d", n)
+	}
+	if pool.GetPoolStats()["total_get"].(int64) != 0 {
+		t.Error("Expected Alloc not to churn the statistics")
+	}
+}
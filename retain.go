@@ -0,0 +1,37 @@
+package bytepool
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// RetainFor increments the reference count and returns a function releasing it
+// If the function has not been called after d, the reference is released anyway, which
+// guards against async send callbacks that third-party libraries occasionally drop
+// Calling the function after the deadline, or more than once, is a no-op
+// The deadline follows the pool clock, see WithClock
+func (b *Buffer) RetainFor(d time.Duration) func() {
+	b.Retain()
+	var done atomic.Bool
+	expire := func() {
+		if done.CompareAndSwap(false, true) {
+			if b.pools != nil {
+				atomic.AddInt64(&b.pools.retainsExpired, 1)
+			}
+			b.Release()
+		}
+	}
+	var stop func()
+	if b.pools != nil {
+		stop = b.pools.afterFunc(d, expire)
+	} else {
+		timer := time.AfterFunc(d, expire)
+		stop = func() { timer.Stop() }
+	}
+	return func() {
+		if done.CompareAndSwap(false, true) {
+			stop()
+			b.Release()
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package bytepool
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuffer_RetainFor(t *testing.T) {
+	pool := NewPools([]int{128})
+	buf := pool.GetBuffer(100)
+
+	release := buf.RetainFor(time.Hour)
+	release()
+	release() // no-op
+	if buf.refCount != 1 {
+		t.Errorf("Expected refCount 1, got %d", buf.refCount)
+	}
+	if pool.Stats().RetainsExpired != 0 {
+		t.Error("Expected no expired retains")
+	}
+	buf.Release()
+}
+
+func TestBuffer_RetainForExpires(t *testing.T) {
+	pool := NewPools([]int{128})
+	buf := pool.GetBuffer(100)
+
+	release := buf.RetainFor(10 * time.Millisecond)
+	buf.Release() // the owner is done, only the async reference remains
+
+	deadline := time.Now().Add(time.Second)
+	for pool.Stats().RetainsExpired == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := pool.Stats().RetainsExpired; got != 1 {
+		t.Fatalf("Expected 1 expired retain, got %d", got)
+	}
+	if pool.Stats().TotalPut != 1 {
+		t.Errorf("Expected the buffer back in the pool, got %d puts", pool.Stats().TotalPut)
+	}
+
+	release() // late callback must not release again
+	if buf.refCount != 0 {
+		t.Errorf("Expected refCount 0, got %d", buf.refCount)
+	}
+}
+
+func TestBuffer_RetainForManualClock(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	pool := NewPools([]int{128}, WithClock(clock))
+	buf := pool.GetBuffer(100)
+
+	buf.RetainFor(time.Minute)
+	buf.Release()
+	clock.Advance(30 * time.Second)
+	if pool.Stats().RetainsExpired != 0 {
+		t.Error("Expected the retain alive before the deadline")
+	}
+
+	clock.Advance(time.Minute)
+	deadline := time.Now().Add(time.Second)
+	for pool.Stats().RetainsExpired == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := pool.Stats().RetainsExpired; got != 1 {
+		t.Fatalf("Expected 1 expired retain, got %d", got)
+	}
+	if pool.Stats().TotalPut != 1 {
+		t.Errorf("Expected the buffer back in the pool, got %d puts", pool.Stats().TotalPut)
+	}
+}
@@ -0,0 +1,134 @@
+package bytepool
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"unsafe"
+)
+
+var _ RingQueuer = (*RingFile)(nil)
+
+// ring file layout, all fields are native-endian int64:
+//
+//	[0:8)   magic
+//	[8:16)  capacity
+//	[16:24) write position, never rolls back
+//	[24:)   capacity slots
+const (
+	ringFileMagic      = "BPRING01"
+	ringFileHeaderSize = 24
+)
+
+// ErrInvalidRingFile is returned when a file is not a valid ring file
+var ErrInvalidRingFile = errors.New("bytepool: invalid ring file")
+
+// RingFile is a lock-free ring queue persisted in a memory-mapped file
+// Pushed samples reach the page cache immediately, so they survive a process crash
+// and can be recovered with ReadRingFile for post-mortem analysis
+type RingFile struct {
+	data     []byte
+	size     int64
+	writePos *int64
+	slots    []int64
+}
+
+// ringFileLen returns the file length needed for size slots
+func ringFileLen(size int) int {
+	return ringFileHeaderSize + size*8
+}
+
+// newRingFile wraps a mapped region, initializing the header when the file is new
+// or was created with a different capacity
+func newRingFile(data []byte, size int) *RingFile {
+	if string(data[:8]) != ringFileMagic || binary.NativeEndian.Uint64(data[8:16]) != uint64(size) {
+		clear(data)
+		copy(data, ringFileMagic)
+		binary.NativeEndian.PutUint64(data[8:16], uint64(size))
+	}
+	return &RingFile{
+		data:     data,
+		size:     int64(size),
+		writePos: (*int64)(unsafe.Pointer(&data[16])),
+		slots:    unsafe.Slice((*int64)(unsafe.Pointer(&data[ringFileHeaderSize])), size),
+	}
+}
+
+// Push adds a sample to the ring file
+func (rf *RingFile) Push(item int) {
+	pos := atomic.AddInt64(rf.writePos, 1) - 1
+	atomic.StoreInt64(&rf.slots[pos%rf.size], int64(item))
+}
+
+// Bytes returns the samples in the ring file in order (oldest to newest)
+func (rf *RingFile) Bytes() []int {
+	return ringSamples(atomic.LoadInt64(rf.writePos), rf.size, func(i int64) int64 {
+		return atomic.LoadInt64(&rf.slots[i])
+	})
+}
+
+// Len returns the current number of samples
+func (rf *RingFile) Len() int {
+	return int(min(atomic.LoadInt64(rf.writePos), rf.size))
+}
+
+// Cap returns the ring file capacity
+func (rf *RingFile) Cap() int {
+	return int(rf.size)
+}
+
+// ringSamples orders the samples of a ring with the given write position
+func ringSamples(writePos, size int64, load func(i int64) int64) []int {
+	if writePos <= 0 {
+		return nil
+	}
+	n := min(writePos, size)
+	first := (writePos - n) % size
+	result := make([]int, n)
+	for i := range n {
+		result[i] = int(load((first + i) % size))
+	}
+	return result
+}
+
+// ReadRingFile parses a ring file written by RingFile and returns its samples
+// in order (oldest to newest); the file does not need to be mapped by a live process
+func ReadRingFile(path string) ([]int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < ringFileHeaderSize || string(data[:8]) != ringFileMagic {
+		return nil, ErrInvalidRingFile
+	}
+	size := int64(binary.NativeEndian.Uint64(data[8:16]))
+	if size <= 0 || int64(len(data)) < int64(ringFileHeaderSize)+size*8 {
+		return nil, fmt.Errorf("%w: truncated", ErrInvalidRingFile)
+	}
+	writePos := int64(binary.NativeEndian.Uint64(data[16:24]))
+	return ringSamples(writePos, size, func(i int64) int64 {
+		off := ringFileHeaderSize + i*8
+		return int64(binary.NativeEndian.Uint64(data[off : off+8]))
+	}), nil
+}
+
+// mirrorQueue pushes every sample to both the tracker and a ring file
+type mirrorQueue struct {
+	RingQueuer
+	file *RingFile
+}
+
+func (m *mirrorQueue) Push(item int) {
+	m.RingQueuer.Push(item)
+	m.file.Push(item)
+}
+
+// WithRingFileMirror mirrors every sample pushed to the recent-length tracker into rf
+// The in-memory tracker still serves GetPoolStats, the file is for post-crash recovery
+func WithRingFileMirror(rf *RingFile) Option {
+	return func(p *BytePool) {
+		p.ringMirror = rf
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package bytepool
+
+import "errors"
+
+// OpenRingFile is not supported on this platform, use ReadRingFile to parse existing files
+func OpenRingFile(path string, size int) (*RingFile, error) {
+	return nil, errors.New("bytepool: ring file is not supported on this platform")
+}
+
+// Close is a no-op on this platform
+func (rf *RingFile) Close() error {
+	return nil
+}
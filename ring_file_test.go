@@ -0,0 +1,86 @@
+//go:build unix
+
+package bytepool
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestRingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lengths.ring")
+
+	rf, err := OpenRingFile(path, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= 5; i++ {
+		rf.Push(i)
+	}
+	if got := rf.Bytes(); !slices.Equal(got, []int{3, 4, 5}) {
+		t.Errorf("Expected [3 4 5], got %v", got)
+	}
+	if rf.Len() != 3 || rf.Cap() != 3 {
+		t.Errorf("Expected len 3 cap 3, got %d %d", rf.Len(), rf.Cap())
+	}
+
+	// samples are readable without the mapping, as after a crash
+	got, err := ReadRingFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, []int{3, 4, 5}) {
+		t.Errorf("Expected [3 4 5] from file, got %v", got)
+	}
+	if err := rf.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// reopening keeps the samples
+	rf, err = OpenRingFile(path, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rf.Close()
+	rf.Push(6)
+	if got := rf.Bytes(); !slices.Equal(got, []int{4, 5, 6}) {
+		t.Errorf("Expected [4 5 6] after reopen, got %v", got)
+	}
+}
+
+func TestWithRingFileMirror(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lengths.ring")
+	rf, err := OpenRingFile(path, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rf.Close()
+
+	pool := NewPools([]int{128, 256}, WithRingFileMirror(rf), WithRingQueueType(MutexRingQueue))
+	pool.Get(10)
+	pool.Get(200)
+
+	if got := pool.GetPoolStats()["recent_lengths"].([]int); !slices.Equal(got, []int{10, 200}) {
+		t.Errorf("Expected in-memory tracker [10 200], got %v", got)
+	}
+	got, err := ReadRingFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, []int{10, 200}) {
+		t.Errorf("Expected mirrored samples [10 200], got %v", got)
+	}
+}
+
+func TestReadRingFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "garbage")
+	if err := os.WriteFile(path, []byte("not a ring file at all!!"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadRingFile(path); !errors.Is(err, ErrInvalidRingFile) {
+		t.Errorf("Expected ErrInvalidRingFile, got %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+//go:build unix
+
+package bytepool
+
+import (
+	"os"
+	"syscall"
+)
+
+// OpenRingFile opens or creates a memory-mapped ring file holding size samples
+// An existing file with the same capacity keeps its samples
+func OpenRingFile(path string, size int) (*RingFile, error) {
+	if size <= 0 {
+		panic("ring queue size must be positive")
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	length := ringFileLen(size)
+	if err := f.Truncate(int64(length)); err != nil {
+		return nil, err
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, length, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+	return newRingFile(data, size), nil
+}
+
+// Close unmaps the ring file, it must not be used afterwards
+func (rf *RingFile) Close() error {
+	if rf.data == nil {
+		return nil
+	}
+	data := rf.data
+	rf.data = nil
+	return syscall.Munmap(data)
+}
@@ -13,6 +13,7 @@ type RingQueue[T any] struct {
 	data     []T
 	size     int64
 	writePos int64 // incrementing write position, never rolls back
+	readers  ringReaders[T]
 }
 
 // NewRingQueue creates a new ring queue with the specified size
@@ -69,6 +70,38 @@ func (rq *RingQueue[T]) Bytes() []T {
 	return result
 }
 
+// Last returns up to n most recent elements in order (oldest to newest)
+func (rq *RingQueue[T]) Last(n int) []T {
+	writePos := atomic.LoadInt64(&rq.writePos)
+	count := min(int64(max(n, 0)), writePos, rq.size)
+	return rq.window(writePos-count, writePos)
+}
+
+// Since returns the elements pushed at or after sequence pos and the sequence to pass
+// to the next call, so scrapers only read new samples. Elements already overwritten
+// are skipped; a pos beyond the current sequence (e.g. after Clear) restarts from the oldest
+func (rq *RingQueue[T]) Since(pos uint64) ([]T, uint64) {
+	writePos := atomic.LoadInt64(&rq.writePos)
+	oldest := max(writePos-rq.size, 0)
+	start := int64(pos)
+	if pos > uint64(writePos) {
+		start = oldest
+	}
+	return rq.window(max(start, oldest), writePos), uint64(writePos)
+}
+
+// window copies the elements with sequence in [from, to)
+func (rq *RingQueue[T]) window(from, to int64) []T {
+	if from >= to {
+		return nil
+	}
+	result := make([]T, to-from)
+	for i := range result {
+		result[i] = rq.data[(from+int64(i))%rq.size]
+	}
+	return result
+}
+
 // Len returns the current number of elements
 func (rq *RingQueue[T]) Len() int {
 	writePos := atomic.LoadInt64(&rq.writePos)
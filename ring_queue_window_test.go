@@ -0,0 +1,91 @@
+package bytepool
+
+import (
+	"slices"
+	"testing"
+)
+
+// windowQueue is implemented by both ring queues
+type windowQueue interface {
+	Push(int)
+	Last(n int) []int
+	Since(pos uint64) ([]int, uint64)
+	Clear()
+}
+
+func TestRingQueues_LastAndSince(t *testing.T) {
+	queues := map[string]windowQueue{
+		"lock_free": NewRingQueue[int](4),
+		"locked":    NewLockedRingQueue[int](4),
+	}
+
+	for name, q := range queues {
+		t.Run(name, func(t *testing.T) {
+			if got := q.Last(3); got != nil {
+				t.Errorf("Expected nil from empty queue, got %v", got)
+			}
+
+			q.Push(1)
+			q.Push(2)
+			got, pos := q.Since(0)
+			if !slices.Equal(got, []int{1, 2}) || pos != 2 {
+				t.Errorf("Expected [1 2] at 2, got %v at %d", got, pos)
+			}
+
+			q.Push(3)
+			got, pos = q.Since(pos)
+			if !slices.Equal(got, []int{3}) || pos != 3 {
+				t.Errorf("Expected [3] at 3, got %v at %d", got, pos)
+			}
+			if got, _ := q.Since(pos); got != nil {
+				t.Errorf("Expected no new samples, got %v", got)
+			}
+
+			// overwritten samples are skipped
+			for i := 4; i <= 8; i++ {
+				q.Push(i)
+			}
+			got, pos = q.Since(pos)
+			if !slices.Equal(got, []int{5, 6, 7, 8}) || pos != 8 {
+				t.Errorf("Expected [5 6 7 8] at 8, got %v at %d", got, pos)
+			}
+
+			if got := q.Last(2); !slices.Equal(got, []int{7, 8}) {
+				t.Errorf("Expected last [7 8], got %v", got)
+			}
+			if got := q.Last(10); !slices.Equal(got, []int{5, 6, 7, 8}) {
+				t.Errorf("Expected last [5 6 7 8], got %v", got)
+			}
+			if got := q.Last(-1); got != nil {
+				t.Errorf("Expected nil for negative n, got %v", got)
+			}
+		})
+	}
+}
+
+func TestRingQueue_SinceAfterClear(t *testing.T) {
+	q := NewRingQueue[int](4)
+	q.Push(1)
+	q.Push(2)
+	_, pos := q.Since(0)
+
+	q.Clear()
+	q.Push(3)
+	got, next := q.Since(pos)
+	if !slices.Equal(got, []int{3}) || next != 1 {
+		t.Errorf("Expected restart with [3] at 1, got %v at %d", got, next)
+	}
+}
+
+func TestLockedRingQueue_SinceAfterPop(t *testing.T) {
+	q := NewLockedRingQueue[int](4)
+	q.Push(1)
+	q.Push(2)
+	q.Push(3)
+	q.Pop()
+
+	got, pos := q.Since(0)
+	if !slices.Equal(got, []int{2, 3}) || pos != 3 {
+		t.Errorf("Expected [2 3] at 3, got %v at %d", got, pos)
+	}
+}
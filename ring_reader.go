@@ -0,0 +1,102 @@
+package bytepool
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// RingReader is an independent reader over a RingQueue tracking its own sequence
+// position, so several consumers can fan out the same pushes like a small telemetry bus
+// Each reader belongs to one goroutine; writers are never slowed down or blocked by
+// readers, and elements overwritten before a slow reader got to them are counted as lost
+type RingReader[T any] struct {
+	rq   *RingQueue[T]
+	name string
+	pos  atomic.Int64 // sequence of the next element to read
+	lost atomic.Int64
+}
+
+// RingReaderStats is the state of a reader, for lag monitoring
+type RingReaderStats struct {
+	Name string `json:"name"`
+	Pos  int64  `json:"pos"`  // sequence of the next element to read
+	Lag  int64  `json:"lag"`  // elements pushed but not read yet
+	Lost int64  `json:"lost"` // elements overwritten before they were read
+}
+
+// ringReaders is the reader registry of a RingQueue
+type ringReaders[T any] struct {
+	mu   sync.Mutex
+	list []*RingReader[T]
+}
+
+// NewReader registers a reader starting at the current write position, so it only sees
+// elements pushed from now on. Close unregisters it
+func (rq *RingQueue[T]) NewReader(name string) *RingReader[T] {
+	r := &RingReader[T]{rq: rq, name: name}
+	r.pos.Store(atomic.LoadInt64(&rq.writePos))
+	rq.readers.mu.Lock()
+	rq.readers.list = append(rq.readers.list, r)
+	rq.readers.mu.Unlock()
+	return r
+}
+
+// Readers returns the stats of all registered readers
+func (rq *RingQueue[T]) Readers() []RingReaderStats {
+	rq.readers.mu.Lock()
+	defer rq.readers.mu.Unlock()
+	out := make([]RingReaderStats, 0, len(rq.readers.list))
+	for _, r := range rq.readers.list {
+		out = append(out, r.Stats())
+	}
+	return out
+}
+
+// Read returns the elements pushed since the previous Read, oldest first
+func (r *RingReader[T]) Read() []T {
+	rq := r.rq
+	writePos := atomic.LoadInt64(&rq.writePos)
+	oldest := max(writePos-rq.size, 0)
+	pos := r.pos.Load()
+	switch {
+	case pos > writePos:
+		// the queue was cleared, restart from the oldest element
+		pos = oldest
+	case pos < oldest:
+		r.lost.Add(oldest - pos)
+		pos = oldest
+	}
+	r.pos.Store(writePos)
+	return rq.window(pos, writePos)
+}
+
+// Lag returns the number of elements pushed but not read yet, more than the capacity
+// means the next Read loses elements
+func (r *RingReader[T]) Lag() int64 {
+	return max(atomic.LoadInt64(&r.rq.writePos)-r.pos.Load(), 0)
+}
+
+// Stats returns the position, lag and loss of the reader
+func (r *RingReader[T]) Stats() RingReaderStats {
+	return RingReaderStats{Name: r.name, Pos: r.pos.Load(), Lag: r.Lag(), Lost: r.lost.Load()}
+}
+
+// Close unregisters the reader
+func (r *RingReader[T]) Close() {
+	rq := r.rq
+	rq.readers.mu.Lock()
+	rq.readers.list = slices.DeleteFunc(rq.readers.list, func(o *RingReader[T]) bool { return o == r })
+	rq.readers.mu.Unlock()
+}
+
+// TrackerReader registers a reader over the recent-length tracker, false when the
+// tracker is not a RingQueue, e.g. after SetTracker with another implementation
+// The reader is bound to the tracker in use, SetTracker does not move it
+func (p *BytePool) TrackerReader(name string) (*RingReader[int], bool) {
+	rq, ok := p.tracker().(*RingQueue[int])
+	if !ok {
+		return nil, false
+	}
+	return rq.NewReader(name), true
+}
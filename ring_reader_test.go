@@ -0,0 +1,67 @@
+package bytepool
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRingQueue_NewReader(t *testing.T) {
+	rq := NewRingQueue[int](4)
+	rq.Push(0)
+
+	fast := rq.NewReader("fast")
+	slow := rq.NewReader("slow")
+	for i := 1; i <= 3; i++ {
+		rq.Push(i)
+	}
+	if got := fast.Read(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Expected elements pushed after the reader started, got %v", got)
+	}
+	if got := fast.Read(); got != nil {
+		t.Errorf("Expected nothing new, got %v", got)
+	}
+
+	for i := 4; i <= 6; i++ {
+		rq.Push(i)
+	}
+	if lag := slow.Lag(); lag != 6 {
+		t.Errorf("Expected slow reader 6 behind, got %d", lag)
+	}
+	if got := slow.Read(); !slices.Equal(got, []int{3, 4, 5, 6}) {
+		t.Errorf("Expected the 4 retained elements, got %v", got)
+	}
+	if stats := slow.Stats(); stats.Lost != 2 || stats.Lag != 0 || stats.Pos != 7 {
+		t.Errorf("Expected 2 lost and no lag, got %+v", stats)
+	}
+	if got := fast.Read(); !slices.Equal(got, []int{4, 5, 6}) {
+		t.Errorf("Expected readers independent of each other, got %v", got)
+	}
+
+	readers := rq.Readers()
+	if len(readers) != 2 || readers[0].Name != "fast" || readers[1].Lost != 2 {
+		t.Errorf("Unexpected reader stats %+v", readers)
+	}
+	slow.Close()
+	if readers := rq.Readers(); len(readers) != 1 {
+		t.Errorf("Expected closed reader unregistered, got %+v", readers)
+	}
+}
+
+func TestBytePool_TrackerReader(t *testing.T) {
+	pool := NewPools([]int{128}, WithTrackerCapacity(256))
+	pool.Get(10)
+	r, ok := pool.TrackerReader("telemetry")
+	if !ok {
+		t.Fatal("Expected a reader over the default tracker")
+	}
+	pool.Get(20)
+	pool.Get(30)
+	if got := r.Read(); !slices.Equal(got, []int{20, 30}) {
+		t.Errorf("Expected lengths recorded after the reader started, got %v", got)
+	}
+
+	pool.SetTracker(NewLockedRingQueue[int](256))
+	if _, ok := pool.TrackerReader("telemetry"); ok {
+		t.Error("Expected no reader over a locked tracker")
+	}
+}
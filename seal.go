@@ -0,0 +1,59 @@
+package bytepool
+
+import (
+	"hash/maphash"
+	"sync/atomic"
+)
+
+// sealSeed hashes sealed buffer content in debug mode
+var sealSeed = maphash.MakeSeed()
+
+// Seal marks the buffer immutable until its final release, e.g. right before handing it
+// to an asynchronous send that the kernel or netpoller may still read after Write returns
+// Later RetainForWrite calls fail with ErrSealed. In debug mode Seal also hashes the
+// content and the final release panics if it changed, and sealing while a writer still
+// holds the buffer panics, catching send-then-recycle races during development
+func (b *Buffer) Seal() {
+	debug := b.pools != nil && b.pools.debug
+	if debug && atomic.LoadInt32(&b.writers) > 0 {
+		panic("bytepool: buffer sealed while a writer still holds it")
+	}
+	if !b.sealed.CompareAndSwap(false, true) || !debug {
+		return
+	}
+	if bufPtr := b.buf.Load(); bufPtr != nil {
+		b.sealSum = maphash.Bytes(sealSeed, *bufPtr)
+	}
+}
+
+// Sealed reports whether Seal was called
+func (b *Buffer) Sealed() bool {
+	return b.sealed.Load()
+}
+
+// RetainForWrite returns the buffer data for writing and a release function,
+// or ErrSealed once the buffer is sealed
+func (b *Buffer) RetainForWrite() ([]byte, func(), error) {
+	if b.sealed.Load() {
+		return nil, func() {}, ErrSealed
+	}
+	atomic.AddInt32(&b.writers, 1)
+	data, release := b.Bytes()
+	done := func() {
+		atomic.AddInt32(&b.writers, -1)
+		release()
+	}
+	// Seal may have won the race after the first check
+	if b.sealed.Load() {
+		done()
+		return nil, func() {}, ErrSealed
+	}
+	return data, done, nil
+}
+
+// verifySeal panics if data changed since Seal, called at the final release in debug mode
+func (b *Buffer) verifySeal(data []byte) {
+	if maphash.Bytes(sealSeed, data) != b.sealSum {
+		panic("bytepool: sealed buffer was modified before release")
+	}
+}
@@ -0,0 +1,70 @@
+package bytepool
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuffer_Seal(t *testing.T) {
+	pool := NewPools([]int{128})
+	buf := pool.GetBuffer(100)
+
+	data, release, err := buf.RetainForWrite()
+	if err != nil || len(data) != 100 {
+		t.Fatalf("Expected writable data, got %v", err)
+	}
+	copy(data, "frame")
+	release()
+
+	buf.Seal()
+	if !buf.Sealed() {
+		t.Error("Expected sealed buffer")
+	}
+	if _, _, err := buf.RetainForWrite(); !errors.Is(err, ErrSealed) {
+		t.Errorf("Expected ErrSealed, got %v", err)
+	}
+
+	// readers are unaffected
+	read, done := buf.Bytes()
+	if string(read[:5]) != "frame" {
+		t.Error("Expected readable data after Seal")
+	}
+	done()
+	buf.Release()
+	if pool.Outstanding() != 0 {
+		t.Error("Expected sealed buffer returned to the pool")
+	}
+}
+
+func TestBuffer_SealDebug(t *testing.T) {
+	pool := NewPools([]int{128}, WithDebug())
+
+	// modifying sealed content is caught at release
+	buf := pool.GetBuffer(100)
+	buf.Seal()
+	data, done := buf.Bytes()
+	data[0] ^= 0xff
+	done()
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for a modified sealed buffer")
+			}
+		}()
+		buf.Release()
+	}()
+
+	// sealing while a writer holds the buffer
+	buf = pool.GetBuffer(100)
+	_, release, _ := buf.RetainForWrite()
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for sealing with an active writer")
+			}
+		}()
+		buf.Seal()
+	}()
+	release()
+	buf.Release()
+}
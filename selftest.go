@@ -0,0 +1,135 @@
+package bytepool
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SelfTestOptions configures RunSelfTest, zero values pick defaults
+type SelfTestOptions struct {
+	Duration    time.Duration // total run time, default 200ms
+	Goroutines  int           // parallel workers, default GOMAXPROCS
+	Lengths     []int         // requested lengths, default spread over all tiers
+	MinHitRatio float64       // fail the test below this ratio, 0 disables
+}
+
+// SelfTestReport holds the measurements of RunSelfTest
+type SelfTestReport struct {
+	Goroutines      int           `json:"goroutines"`
+	Ops             int64         `json:"ops"` // Get/Put pairs
+	OpsPerSec       float64       `json:"ops_per_sec"`
+	SingleOpsPerSec float64       `json:"single_ops_per_sec"` // one goroutine, the uncontended baseline
+	Scaling         float64       `json:"scaling"`            // parallel over ideal linear throughput, 1 means no contention
+	HitRatio        float64       `json:"hit_ratio"`          // sampled Gets served by a store
+	HitP99          time.Duration `json:"hit_p99_ns"`         // worst tier p99 of store hits
+	MissP99         time.Duration `json:"miss_p99_ns"`        // worst tier p99 of fallback allocations
+	Tiers           []TierStats   `json:"tiers"`
+}
+
+// RunSelfTest stresses a scratch pool with the same tiers, policies and backends as p and
+// returns measured hit ratio, throughput and contention indicators, e.g. as a startup
+// health check in canary environments. p itself and its statistics are not touched
+// It runs a single goroutine baseline for a quarter of the duration, then all goroutines
+// Returns ErrSelfTestFailed when the hit ratio is below opts.MinHitRatio
+func (p *BytePool) RunSelfTest(opts SelfTestOptions) (SelfTestReport, error) {
+	if opts.Duration <= 0 {
+		opts.Duration = 200 * time.Millisecond
+	}
+	if opts.Goroutines <= 0 {
+		opts.Goroutines = runtime.GOMAXPROCS(0)
+	}
+	cfg := p.Config()
+	if len(opts.Lengths) == 0 {
+		opts.Lengths = selfTestLengths(cfg.Sizes)
+	}
+	cfg.LatencySampleEvery = 16
+	scratch := NewPools(nil, cfg.Option(), func(q *BytePool) {
+		q.backend = p.backend
+		q.backendRanges = p.backendRanges
+		q.hygieneRanges = p.hygieneRanges
+	})
+
+	single := scratch.stress(1, opts.Duration/4, opts.Lengths)
+	parallel := scratch.stress(opts.Goroutines, opts.Duration-opts.Duration/4, opts.Lengths)
+
+	report := SelfTestReport{
+		Goroutines:      opts.Goroutines,
+		Ops:             single.ops + parallel.ops,
+		OpsPerSec:       parallel.rate(),
+		SingleOpsPerSec: single.rate(),
+		Tiers:           scratch.Stats().Tiers,
+	}
+	if report.SingleOpsPerSec > 0 {
+		report.Scaling = report.OpsPerSec / (report.SingleOpsPerSec * float64(opts.Goroutines))
+	}
+	var hits, misses int64
+	for _, tier := range report.Tiers {
+		hits += tier.HitSamples
+		misses += tier.MissSamples
+		report.HitP99 = max(report.HitP99, tier.HitP99)
+		report.MissP99 = max(report.MissP99, tier.MissP99)
+	}
+	if hits+misses > 0 {
+		report.HitRatio = float64(hits) / float64(hits+misses)
+	}
+	if opts.MinHitRatio > 0 && report.HitRatio < opts.MinHitRatio {
+		return report, fmt.Errorf("%w: hit ratio %.3f below %.3f", ErrSelfTestFailed, report.HitRatio, opts.MinHitRatio)
+	}
+	return report, nil
+}
+
+// stressResult is the outcome of one stress phase
+type stressResult struct {
+	ops     int64
+	elapsed time.Duration
+}
+
+func (r stressResult) rate() float64 {
+	if r.elapsed <= 0 {
+		return 0
+	}
+	return float64(r.ops) / r.elapsed.Seconds()
+}
+
+// stress runs Get/Put pairs of the given lengths on n goroutines for d
+func (p *BytePool) stress(n int, d time.Duration, lengths []int) stressResult {
+	var ops atomic.Int64
+	var stop atomic.Bool
+	var wg sync.WaitGroup
+	start := time.Now()
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var local int64
+			for i := rand.IntN(len(lengths)); !stop.Load(); i++ {
+				// hold a few buffers at once so stores see real reuse patterns
+				a := p.Get(lengths[i%len(lengths)])
+				b := p.Get(lengths[(i+1)%len(lengths)])
+				p.Put(a)
+				p.Put(b)
+				local += 2
+			}
+			ops.Add(local)
+		}()
+	}
+	time.Sleep(d)
+	stop.Store(true)
+	wg.Wait()
+	return stressResult{ops: ops.Load(), elapsed: time.Since(start)}
+}
+
+// selfTestLengths picks one length inside every tier, halfway above the previous tier
+func selfTestLengths(sizes []int) []int {
+	lengths := make([]int, 0, len(sizes))
+	prev := 0
+	for _, size := range sizes {
+		lengths = append(lengths, prev+(size-prev+1)/2)
+		prev = size
+	}
+	return lengths
+}
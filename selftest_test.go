@@ -0,0 +1,42 @@
+package bytepool
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBytePool_RunSelfTest(t *testing.T) {
+	pool := NewPools(SizeStream(), WithLabels(map[string]string{"service": "canary"}))
+	report, err := pool.RunSelfTest(SelfTestOptions{Duration: 40 * time.Millisecond, Goroutines: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Ops == 0 || report.OpsPerSec <= 0 || report.SingleOpsPerSec <= 0 || report.Scaling <= 0 {
+		t.Errorf("Expected throughput measurements, got %+v", report)
+	}
+	if report.HitRatio <= 0 || report.HitRatio > 1 {
+		t.Errorf("Expected hit ratio within (0, 1], got %v", report.HitRatio)
+	}
+	if len(report.Tiers) != len(SizeStream()) {
+		t.Errorf("Expected stats for every tier, got %d", len(report.Tiers))
+	}
+	if pool.Stats().TotalGet != 0 {
+		t.Error("Expected the self test not to touch the pool")
+	}
+}
+
+func TestBytePool_RunSelfTestMinHitRatio(t *testing.T) {
+	pool := NewPools([]int{128})
+	_, err := pool.RunSelfTest(SelfTestOptions{Duration: 10 * time.Millisecond, Goroutines: 1, MinHitRatio: 1.5})
+	if !errors.Is(err, ErrSelfTestFailed) {
+		t.Errorf("Expected ErrSelfTestFailed, got %v", err)
+	}
+}
+
+func TestSelfTestLengths(t *testing.T) {
+	if got := selfTestLengths([]int{128, 256, 1024}); !slices.Equal(got, []int{64, 192, 640}) {
+		t.Errorf("Unexpected lengths %v", got)
+	}
+}
@@ -0,0 +1,131 @@
+// Package slogbridge emits structured slog records for bytepool lifecycle and threshold events
+//
+//	bridge := slogbridge.New(slog.Default(), slogbridge.WithLevel(bytepool.EventTierAdded, slog.LevelDebug))
+//	pool := bytepool.NewPools(bytepool.SizePowerOfTwo(), bridge.Option())
+package slogbridge
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ixugo/bytepool"
+)
+
+// defaultLevels are the record levels used unless overridden by WithLevel
+var defaultLevels = map[bytepool.EventKind]slog.Level{
+	bytepool.EventPoolCreated:     slog.LevelInfo,
+	bytepool.EventTierAdded:       slog.LevelDebug,
+	bytepool.EventBudgetExceeded:  slog.LevelWarn,
+	bytepool.EventBudgetRecovered: slog.LevelInfo,
+	bytepool.EventLeakDetected:    slog.LevelError,
+	bytepool.EventTierRemoved:     slog.LevelInfo,
+	bytepool.EventConfigApplied:   slog.LevelInfo,
+	bytepool.EventDiscarded:       slog.LevelDebug,
+}
+
+// Bridge turns pool events into slog records
+type Bridge struct {
+	logger   *slog.Logger
+	levels   map[bytepool.EventKind]slog.Level
+	disabled map[bytepool.EventKind]bool
+}
+
+// Option configures a Bridge
+type Option func(*Bridge)
+
+// WithLevel sets the record level of an event class
+func WithLevel(kind bytepool.EventKind, level slog.Level) Option {
+	return func(b *Bridge) {
+		b.levels[kind] = level
+	}
+}
+
+// WithoutEvent drops all events of the given class
+func WithoutEvent(kind bytepool.EventKind) Option {
+	return func(b *Bridge) {
+		b.disabled[kind] = true
+	}
+}
+
+// New creates a Bridge writing to logger, slog.Default() when logger is nil
+func New(logger *slog.Logger, opts ...Option) *Bridge {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	b := Bridge{
+		logger:   logger,
+		levels:   make(map[bytepool.EventKind]slog.Level, len(defaultLevels)),
+		disabled: make(map[bytepool.EventKind]bool),
+	}
+	for kind, level := range defaultLevels {
+		b.levels[kind] = level
+	}
+	for _, opt := range opts {
+		opt(&b)
+	}
+	return &b
+}
+
+// Option returns the pool option that connects the bridge to a pool
+func (b *Bridge) Option() bytepool.Option {
+	return bytepool.WithEventHook(b.Handle)
+}
+
+// Handle writes a record for e
+func (b *Bridge) Handle(e bytepool.Event) {
+	if b.disabled[e.Kind] {
+		return
+	}
+	level, ok := b.levels[e.Kind]
+	if !ok {
+		level = slog.LevelInfo
+	}
+	ctx := context.Background()
+	if !b.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{slog.String("event", e.Kind.String())}
+	switch e.Kind {
+	case bytepool.EventPoolCreated:
+		if e.Pool != nil {
+			attrs = append(attrs, slog.Any("sizes", e.Pool.GetAvailableSizes()))
+		}
+	case bytepool.EventTierAdded, bytepool.EventTierRemoved:
+		attrs = append(attrs, slog.Int("size", e.Size))
+	case bytepool.EventDiscarded:
+		attrs = append(attrs, slog.Int("size", e.Size), slog.String("reason", e.Reason.String()))
+	case bytepool.EventBudgetExceeded, bytepool.EventBudgetRecovered:
+		attrs = append(attrs, slog.Int64("in_use_bytes", e.Bytes), slog.Int64("budget", e.Limit))
+	case bytepool.EventLeakDetected:
+		attrs = append(attrs, slog.Int64("leaked", e.Count), slog.Int64("in_use_bytes", e.Bytes))
+	case bytepool.EventConfigApplied:
+		changes := make([]any, 0, len(e.Changes))
+		for _, c := range e.Changes {
+			changes = append(changes, slog.Group(c.Field, slog.Any("old", c.Old), slog.Any("new", c.New)))
+		}
+		attrs = append(attrs, slog.Group("changes", changes...))
+	}
+	if e.Pool != nil {
+		if labels := e.Pool.Labels(); len(labels) > 0 {
+			group := make([]any, 0, len(labels))
+			for k, v := range labels {
+				group = append(group, slog.String(k, v))
+			}
+			attrs = append(attrs, slog.Group("labels", group...))
+		}
+	}
+	b.logger.LogAttrs(ctx, level, messages[e.Kind], attrs...)
+}
+
+// messages are the record messages of each event class
+var messages = map[bytepool.EventKind]string{
+	bytepool.EventPoolCreated:     "bytepool: pool created",
+	bytepool.EventTierAdded:       "bytepool: tier added",
+	bytepool.EventBudgetExceeded:  "bytepool: soft budget exceeded",
+	bytepool.EventBudgetRecovered: "bytepool: soft budget recovered",
+	bytepool.EventLeakDetected:    "bytepool: leak detected",
+	bytepool.EventTierRemoved:     "bytepool: tier removed",
+	bytepool.EventConfigApplied:   "bytepool: config applied",
+	bytepool.EventDiscarded:       "bytepool: buffer discarded",
+}
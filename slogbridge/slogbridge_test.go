@@ -0,0 +1,106 @@
+package slogbridge
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ixugo/bytepool"
+)
+
+// decode parses JSON lines written by a slog.JSONHandler
+func decode(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var r map[string]any
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+func TestBridge_Lifecycle(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	bridge := New(logger, WithLevel(bytepool.EventTierAdded, slog.LevelWarn))
+	bytepool.NewPools([]int{128, 256}, bridge.Option(), bytepool.WithLabels(map[string]string{"service": "sfu"}))
+
+	records := decode(t, &buf)
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %d", len(records))
+	}
+	if records[0]["event"] != "tier_added" || records[0]["level"] != "WARN" || records[0]["size"] != 128.0 {
+		t.Errorf("Unexpected tier record %v", records[0])
+	}
+	created := records[2]
+	if created["event"] != "pool_created" || created["level"] != "INFO" {
+		t.Errorf("Unexpected created record %v", created)
+	}
+	if labels, _ := created["labels"].(map[string]any); labels["service"] != "sfu" {
+		t.Errorf("Expected labels group, got %v", created["labels"])
+	}
+}
+
+func TestBridge_Budget(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	bridge := New(logger, WithoutEvent(bytepool.EventPoolCreated))
+	pool := bytepool.NewPools([]int{128}, bridge.Option(), bytepool.WithSoftBudget(100, time.Microsecond))
+
+	held := pool.Get(128)
+	pool.Put(pool.Get(64))
+	pool.Put(pool.Get(64)) // still over budget, no second event
+	pool.Put(held)
+
+	records := decode(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	if records[0]["event"] != "budget_exceeded" || records[0]["level"] != "WARN" || records[0]["budget"] != 100.0 {
+		t.Errorf("Unexpected exceeded record %v", records[0])
+	}
+	if records[1]["event"] != "budget_recovered" {
+		t.Errorf("Unexpected recovered record %v", records[1])
+	}
+}
+
+func TestBridge_Leak(t *testing.T) {
+	var buf bytes.Buffer
+	bridge := New(slog.New(slog.NewJSONHandler(&buf, nil)))
+	bridge.Handle(bytepool.Event{Kind: bytepool.EventLeakDetected, Count: 2, Bytes: 256})
+
+	records := decode(t, &buf)
+	if len(records) != 1 || records[0]["level"] != "ERROR" || records[0]["leaked"] != 2.0 {
+		t.Errorf("Unexpected leak record %v", records)
+	}
+}
+
+func TestBridge_ConfigApplied(t *testing.T) {
+	var buf bytes.Buffer
+	bridge := New(slog.New(slog.NewJSONHandler(&buf, nil)), WithoutEvent(bytepool.EventPoolCreated))
+	pool := bytepool.NewPools([]int{128}, bridge.Option())
+
+	cfg := pool.Config()
+	cfg.Sizes = []int{256}
+	if err := pool.ApplyConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	records := decode(t, &buf)
+	if len(records) != 2 || records[0]["event"] != "tier_removed" || records[1]["event"] != "config_applied" {
+		t.Fatalf("Unexpected records %v", records)
+	}
+	changes, _ := records[1]["changes"].(map[string]any)
+	if sizes, _ := changes["Sizes"].(map[string]any); sizes == nil || sizes["new"] == nil {
+		t.Errorf("Expected sizes change, got %v", records[1]["changes"])
+	}
+}
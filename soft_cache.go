@@ -0,0 +1,144 @@
+package bytepool
+
+import (
+	"math"
+	"runtime"
+	"runtime/debug"
+	"runtime/metrics"
+	"sync"
+	"weak"
+)
+
+// WithSoftCache keeps up to maxIdle idle buffers of the largest tier in a cache that is
+// dropped when the process nears its memory limit. After every GC cycle the cache
+// compares the memory used by the runtime against debug.SetMemoryLimit and empties
+// itself once highWater of the limit is reached, e.g. 0.9
+// Without a memory limit it behaves like FreeListBackend
+func WithSoftCache(maxIdle int, highWater float64) Option {
+	if maxIdle <= 0 {
+		panic("soft cache size must be positive")
+	}
+	if highWater <= 0 || highWater > 1 {
+		panic("soft cache high water must be within (0, 1]")
+	}
+	return func(p *BytePool) {
+		p.softCache = softCacheBackend{maxIdle: maxIdle, highWater: highWater}
+	}
+}
+
+type softCacheBackend struct {
+	maxIdle   int
+	highWater float64
+}
+
+func (b softCacheBackend) NewStore(int) Store {
+	s := &softStore{maxIdle: b.maxIdle, highWater: b.highWater, pressure: memoryPressure}
+	watchGC(weak.Make(s), (*softStore).checkPressure)
+	return s
+}
+
+// memoryPressure returns the memory used by the runtime as a fraction of the memory
+// limit, 0 when no limit is set
+func memoryPressure() float64 {
+	limit := debug.SetMemoryLimit(-1)
+	if limit <= 0 || limit == math.MaxInt64 {
+		return 0
+	}
+	sample := []metrics.Sample{{Name: "/memory/classes/total:bytes"}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return float64(sample[0].Value.Uint64()) / float64(limit)
+}
+
+// gcSentinel is collected by every GC cycle, its cleanup checks the memory pressure
+// It holds a pointer so it is never tiny-allocated together with long lived objects
+type gcSentinel struct {
+	_ *byte
+}
+
+// watchGC calls fn with the value of w after every GC cycle until the value is collected
+func watchGC[T any](w weak.Pointer[T], fn func(*T)) {
+	runtime.AddCleanup(&gcSentinel{}, func(w weak.Pointer[T]) {
+		v := w.Value()
+		if v == nil {
+			return
+		}
+		fn(v)
+		watchGC(w, fn)
+	}, w)
+}
+
+// softStore is a bounded LIFO stack dropped as a whole under memory pressure
+type softStore struct {
+	mu        sync.Mutex
+	items     []*[]byte
+	maxIdle   int
+	highWater float64
+	pressure  func() float64
+
+	hits, misses, evicted, dropped int64
+}
+
+func (s *softStore) Get() *[]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := len(s.items)
+	if n == 0 {
+		s.misses++
+		return nil
+	}
+	s.hits++
+	buf := s.items[n-1]
+	s.items[n-1] = nil
+	s.items = s.items[:n-1]
+	return buf
+}
+
+func (s *softStore) Put(buf *[]byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.items) == s.maxIdle {
+		s.evicted++
+		return
+	}
+	s.items = append(s.items, buf)
+}
+
+// checkPressure drops all idle buffers when the memory pressure reached the high water
+func (s *softStore) checkPressure() {
+	s.mu.Lock()
+	pressure := s.pressure
+	s.mu.Unlock()
+	if pressure() < s.highWater {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dropped += int64(len(s.items))
+	clear(s.items)
+	s.items = s.items[:0]
+}
+
+// Len returns the number of idle buffers
+func (s *softStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items)
+}
+
+// EvictionStats reports the cache counters, Trimmed counts buffers dropped under pressure
+func (s *softStore) EvictionStats() EvictionStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return EvictionStats{
+		Policy:  "soft",
+		Hits:    s.hits,
+		Misses:  s.misses,
+		Evicted: s.evicted,
+		Trimmed: s.dropped,
+	}
+}
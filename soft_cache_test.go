@@ -0,0 +1,53 @@
+package bytepool
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBytePool_SoftCache(t *testing.T) {
+	pool := NewPools([]int{128, 65536}, WithSoftCache(2, 0.9))
+
+	store, ok := pool.state.Load().tier(65536).store.(*softStore)
+	if !ok {
+		t.Fatalf("Expected soft store for the largest tier, got %T", pool.state.Load().tier(65536).store)
+	}
+	if _, ok := pool.state.Load().tier(128).store.(*softStore); ok {
+		t.Error("Expected smaller tiers to keep the default backend")
+	}
+
+	var pressure atomic.Value
+	pressure.Store(0.5)
+	store.mu.Lock()
+	store.pressure = func() float64 { return pressure.Load().(float64) }
+	store.mu.Unlock()
+
+	a, b, c := pool.Get(60000), pool.Get(60000), pool.Get(60000)
+	pool.Put(a)
+	pool.Put(b)
+	pool.Put(c) // evicted, the cache is full
+	store.checkPressure()
+	if store.Len() != 2 {
+		t.Fatalf("Expected 2 cached buffers below the high water, got %d", store.Len())
+	}
+
+	pressure.Store(0.95)
+	runtime.GC()
+	deadline := time.Now().Add(time.Second)
+	for store.Len() != 0 && time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+	if store.Len() != 0 {
+		t.Fatal("Expected the cache to be dropped after a GC under pressure")
+	}
+
+	for _, tier := range pool.Stats().Tiers {
+		if tier.Size == 65536 && (tier.Eviction == nil || tier.Eviction.Policy != "soft" ||
+			tier.Eviction.Trimmed != 2 || tier.Eviction.Evicted != 1) {
+			t.Errorf("Expected 2 dropped and 1 evicted, got %+v", tier.Eviction)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package bytepool
+
+import (
+	"log/slog"
+	"sync/atomic"
+)
+
+// eventSpillFailed is logged when a spill file cannot be created
+const eventSpillFailed = "spill_failed"
+
+// WithSpill lets GetBuffer serve requests of at least minLength bytes from a sparse
+// temp file mapped into memory while the pool is over its soft budget, instead of
+// throttling. Batch jobs that prefer slow memory over waiting or failing enable it
+// together with WithSoftBudget; dir is the temp file directory, os.TempDir when empty
+// Spilled buffers behave like any other Buffer and are unmapped on final release, the
+// file is unlinked right after mapping. Plain Get never spills. Only unix platforms
+// support spilling, elsewhere GetBuffer falls back to the pool
+func WithSpill(dir string, minLength int) Option {
+	return func(p *BytePool) {
+		p.spillDir = dir
+		p.spillMin = max(minLength, 1)
+	}
+}
+
+// spill returns a Buffer backed by a temp file mapping, nil if it cannot be created
+func (p *BytePool) spill(length int) *Buffer {
+	data, unmap, err := mmapTemp(p.spillDir, length)
+	if err != nil {
+		p.logEvent(slog.LevelWarn, eventSpillFailed, "bytepool: spill to temp file failed",
+			slog.Int("length", length), slog.String("error", err.Error()))
+		return nil
+	}
+	atomic.AddInt64(&p.spilled, 1)
+	atomic.AddInt64(&p.spilledBytes, int64(length))
+	atomic.AddInt64(&p.spilledInUse, int64(length))
+	return AdoptMmap(data, func() {
+		atomic.AddInt64(&p.spilledInUse, -int64(length))
+		unmap()
+	})
+}
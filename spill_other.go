@@ -0,0 +1,10 @@
+//go:build !unix
+
+package bytepool
+
+import "errors"
+
+// mmapTemp is not supported on this platform
+func mmapTemp(dir string, length int) ([]byte, func(), error) {
+	return nil, nil, errors.New("bytepool: spilling is not supported on this platform")
+}
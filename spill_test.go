@@ -0,0 +1,55 @@
+//go:build unix
+
+package bytepool
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestWithSpill(t *testing.T) {
+	dir := t.TempDir()
+	pool := NewPools([]int{1024, 65536}, WithSoftBudget(1024, time.Millisecond), WithSpill(dir, 4096))
+
+	held := pool.Get(1024) // at the budget, not over it
+	normal := pool.GetBuffer(8192)
+	if pool.Stats().Spilled != 0 {
+		t.Error("Expected no spill within the budget")
+	}
+
+	// over budget: large buffers spill, small ones still come from the pool
+	spilled := pool.GetBuffer(100000)
+	data, release := spilled.Bytes()
+	if len(data) != 100000 {
+		t.Fatalf("Expected 100000 byte spilled buffer, got %d", len(data))
+	}
+	data[0], data[len(data)-1] = 1, 2
+	release()
+
+	r := pool.Stats()
+	if r.Spilled != 1 || r.SpilledBytes != 100000 || r.SpilledInUse != 100000 {
+		t.Errorf("Unexpected spill stats %+v", r)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Expected spill file unlinked, found %d entries", len(entries))
+	}
+
+	spilled.Release()
+	normal.Release()
+	pool.Put(held)
+	if r := pool.Stats(); r.SpilledInUse != 0 || pool.Outstanding() != 0 {
+		t.Errorf("Expected everything released, got %+v", r)
+	}
+}
+
+func TestWithSpill_Failure(t *testing.T) {
+	pool := NewPools([]int{1024}, WithSoftBudget(1, time.Microsecond), WithSpill("/nonexistent/dir", 1))
+	held := pool.Get(1024)
+	buf := pool.GetBuffer(100) // spill fails, served by the pool after throttling
+	if pool.Stats().Spilled != 0 || buf == nil {
+		t.Error("Expected fallback to the pool when spilling fails")
+	}
+	buf.Release()
+	pool.Put(held)
+}
@@ -0,0 +1,27 @@
+//go:build unix
+
+package bytepool
+
+import (
+	"os"
+	"syscall"
+)
+
+// mmapTemp maps a sparse, already unlinked temp file of length bytes read-write
+func mmapTemp(dir string, length int) ([]byte, func(), error) {
+	f, err := os.CreateTemp(dir, "bytepool-spill-*")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	defer os.Remove(f.Name())
+
+	if err := f.Truncate(int64(length)); err != nil {
+		return nil, nil, err
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, length, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() { _ = syscall.Munmap(data) }, nil
+}
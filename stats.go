@@ -0,0 +1,203 @@
+package bytepool
+
+import (
+	"iter"
+	"sync/atomic"
+	"time"
+)
+
+// TierStats represents the statistics of a single tier
+type TierStats struct {
+	Size       int   `json:"size"`
+	Get        int64 `json:"get"`
+	Put        int64 `json:"put"`
+	InUse      int64 `json:"in_use"`       // leased buffers, get minus put
+	InUseBytes int64 `json:"in_use_bytes"` // bytes held by leased buffers
+	Idle       int64 `json:"idle"`         // idle buffers in the store, -1 when the backend cannot tell
+	IdleBytes  int64 `json:"idle_bytes"`   // bytes held by idle buffers
+	IdleExact  bool  `json:"idle_exact"`   // false when Idle is approximated, e.g. for sync.Pool
+
+	// sampled Get latency, only with WithGetLatency
+	HitSamples  int64         `json:"hit_samples,omitempty"`
+	HitP99      time.Duration `json:"hit_p99_ns,omitempty"`
+	MissSamples int64         `json:"miss_samples,omitempty"`
+	MissP99     time.Duration `json:"miss_p99_ns,omitempty"` // Gets that fell back to make
+
+	Eviction   *EvictionStats `json:"eviction,omitempty"`    // free list backends only
+	SurvivedGC int64          `json:"survived_gc,omitempty"` // buffers kept across GC, WithGCRotationMitigation only
+
+	// sync.Pool drain signal, only with WithGCSentinels and after the first GC
+	SurvivedLastGC *bool `json:"survived_last_gc,omitempty"`
+	GCDrains       int64 `json:"gc_drains,omitempty"` // GC cycles that drained the tier
+}
+
+// Report is a typed snapshot of the pool statistics
+type Report struct {
+	Time               time.Time         `json:"time"`               // when the snapshot was taken, by the pool clock
+	Interval           time.Duration     `json:"interval,omitempty"` // set by Diff, the time between the snapshots
+	Tiers              []TierStats       `json:"tiers"`              // ordered by tier size
+	TotalGet           int64             `json:"total_get"`
+	TotalPut           int64             `json:"total_put"`
+	Discarded          int64             `json:"discarded"`
+	Discards           DiscardStats      `json:"discards"` // Discarded broken down by reason
+	Inefficient        int64             `json:"inefficient_get"`
+	Throttled          int64             `json:"throttled"`
+	Degraded           int64             `json:"degraded"`
+	Consolidations     int64             `json:"consolidations"`
+	ConsolidatedBytes  int64             `json:"consolidated_bytes"`
+	TierFallbacks      int64             `json:"tier_fallback"` // Gets served by a larger tier
+	Spilled            int64             `json:"spilled"`       // GetBuffer calls served from a temp file
+	SpilledBytes       int64             `json:"spilled_bytes"`
+	SpilledInUse       int64             `json:"spilled_in_use_bytes"` // spilled bytes not yet released
+	RetainsExpired     int64             `json:"retains_expired"`      // RetainFor references released by their deadline
+	EventsDropped      int64             `json:"events_dropped"`       // events not delivered to a full Events channel
+	Refused            int64             `json:"refused"`              // Gets above MaxGetLength
+	Wiped              int64             `json:"wiped"`                // buffers wiped by HygieneSensitive
+	GrowthCopies       int64             `json:"growth_copies"`        // PooledBytesBuffer growths that moved data to a larger tier
+	GrowthCopiedBytes  int64             `json:"growth_copied_bytes"`
+	PutAnomalies       int64             `json:"put_anomalies"` // Puts without a matching Get in their tier
+	Misuses            MisuseStats       `json:"misuses"`
+	TrackerLen         int               `json:"tracker_len"` // samples held by the recent-length tracker
+	TrackerCap         int               `json:"tracker_cap"`
+	TrackerSampleEvery int               `json:"tracker_sample_every"`   // effective rate, one in N Gets is recorded
+	HoldSamples        int64             `json:"hold_samples,omitempty"` // buffers timed from Get to Put, StatsHoldTimes only
+	HoldP50            time.Duration     `json:"hold_p50_ns,omitempty"`
+	HoldP99            time.Duration     `json:"hold_p99_ns,omitempty"`
+	InUseBytes         int64             `json:"in_use_bytes"`
+	IdleBytes          int64             `json:"idle_bytes"`
+	HeldBytes          int64             `json:"held_bytes"` // in use plus idle, the memory attributable to the pool
+	Frozen             bool              `json:"frozen"`
+	Labels             map[string]string `json:"labels,omitempty"`
+	Tuning             *Tuning           `json:"tuning,omitempty"` // set by WithAutoTune
+}
+
+// lener is implemented by stores that can report their exact idle buffer count
+type lener interface {
+	Len() int
+}
+
+// approxLener is implemented by stores that can only estimate their idle buffer count
+type approxLener interface {
+	ApproxLen() int
+}
+
+// idleCount returns the idle buffers of store and whether the count is exact, -1 when
+// the store cannot tell
+func idleCount(store Store) (int64, bool) {
+	switch s := store.(type) {
+	case lener:
+		return int64(s.Len()), true
+	case approxLener:
+		return int64(s.ApproxLen()), false
+	}
+	return -1, false
+}
+
+// Stats returns a typed snapshot of the pool statistics
+func (p *BytePool) Stats() Report {
+	report := p.report()
+	p.evalPressure(pressureBytes(&report), &report)
+	return report
+}
+
+// report builds the snapshot returned by Stats
+func (p *BytePool) report() Report {
+	p.flushStats()
+	st := p.state.Load()
+	restored := p.restoredStats()
+	report := Report{
+		Time:               p.now(),
+		Tiers:              make([]TierStats, 0, len(st.sizes)),
+		TotalGet:           loadCounter(&p.totalGet, restored.TotalGet),
+		TotalPut:           loadCounter(&p.totalPut, restored.TotalPut),
+		Discarded:          loadCounter(&p.discardedCount, restored.Discarded),
+		Inefficient:        loadCounter(&p.inefficientGets, restored.Inefficient),
+		Throttled:          loadCounter(&p.throttled, restored.Throttled),
+		Degraded:           loadCounter(&p.degraded, restored.Degraded),
+		Consolidations:     loadCounter(&p.consolidations, restored.Consolidations),
+		ConsolidatedBytes:  loadCounter(&p.consolidatedBytes, restored.ConsolidatedBytes),
+		TierFallbacks:      loadCounter(&p.tierFallbacks, restored.TierFallbacks),
+		Spilled:            loadCounter(&p.spilled, restored.Spilled),
+		SpilledBytes:       loadCounter(&p.spilledBytes, restored.SpilledBytes),
+		SpilledInUse:       atomic.LoadInt64(&p.spilledInUse),
+		RetainsExpired:     loadCounter(&p.retainsExpired, restored.RetainsExpired),
+		EventsDropped:      loadCounter(&p.eventsDropped, restored.EventsDropped),
+		Refused:            loadCounter(&p.refused, restored.Refused),
+		Wiped:              loadCounter(&p.wiped, restored.Wiped),
+		GrowthCopies:       loadCounter(&p.growthCopies, restored.GrowthCopies),
+		GrowthCopiedBytes:  loadCounter(&p.growthCopiedBytes, restored.GrowthCopiedBytes),
+		PutAnomalies:       loadCounter(&p.putAnomalies, restored.PutAnomalies),
+		Misuses:            p.misuseStats(),
+		Discards:           p.discardStats(),
+		TrackerLen:         p.tracker().Len(),
+		TrackerCap:         p.tracker().Cap(),
+		TrackerSampleEvery: p.trackerSampleEvery(st),
+		Frozen:             p.frozen.Load(),
+		Labels:             p.Labels(),
+	}
+	if p.tuning != nil {
+		t := *p.tuning
+		report.Tuning = &t
+	}
+	for i := range st.tiers {
+		report.Tiers = append(report.Tiers, p.tierStats(&st.tiers[i]))
+	}
+	for _, tier := range report.Tiers {
+		report.InUseBytes += tier.InUseBytes
+		report.IdleBytes += tier.IdleBytes
+	}
+	report.HeldBytes = report.InUseBytes + report.IdleBytes
+	p.fillHolds(&report)
+	return report
+}
+
+// Tiers returns an iterator over the tier sizes and their statistics in size order
+// Unlike Stats it builds no intermediate slice, so periodic scraping does not allocate
+// for tiers backed by sync.Pool
+func (p *BytePool) Tiers() iter.Seq2[int, TierStats] {
+	return func(yield func(int, TierStats) bool) {
+		st := p.state.Load()
+		for i := range st.tiers {
+			if !yield(st.tiers[i].size, p.tierStats(&st.tiers[i])) {
+				return
+			}
+		}
+	}
+}
+
+// tierStats builds the statistics of a tier
+func (p *BytePool) tierStats(t *tierState) TierStats {
+	size, stat := t.size, t.stats
+	p.flushTier(stat)
+	tier := TierStats{
+		Size: size,
+		Get:  atomic.LoadInt64(&stat.Get),
+		Put:  atomic.LoadInt64(&stat.Put),
+		Idle: -1,
+	}
+	tier.InUse = max(tier.Get-tier.Put, 0)
+	tier.InUseBytes = tier.InUse * int64(size)
+	// restored counters are historical, they never count as in use
+	if saved, ok := p.restoredStats().Tiers[size]; ok {
+		tier.Get += saved.Get
+		tier.Put += saved.Put
+	}
+	tier.Idle, tier.IdleExact = idleCount(t.store)
+	if es, ok := t.store.(evictionStatser); ok {
+		stats := es.EvictionStats()
+		tier.Eviction = &stats
+	}
+	if tier.Idle > 0 {
+		tier.IdleBytes = tier.Idle * int64(size)
+	}
+	if t.latency != nil {
+		t.latency.fill(&tier)
+	}
+	if t.gcRotation != nil {
+		tier.SurvivedGC = t.gcRotation.survived.Load()
+	}
+	if t.sentinel != nil {
+		t.sentinel.fill(&tier)
+	}
+	return tier
+}
@@ -0,0 +1,94 @@
+package bytepool
+
+// Diff returns the change from prev to r: counters hold the increase over the interval,
+// gauges such as in-use bytes, idle buffers and percentiles keep the values of r, and
+// Interval holds the time between the snapshots for PerSecond
+// A counter lower than in prev, e.g. after the process restarted, counts from zero
+// Tiers are matched by size, tiers missing from prev count from zero
+func (r Report) Diff(prev Report) Report {
+	d := r
+	d.Interval = r.Time.Sub(prev.Time)
+	d.TotalGet = delta(r.TotalGet, prev.TotalGet)
+	d.TotalPut = delta(r.TotalPut, prev.TotalPut)
+	d.Discarded = delta(r.Discarded, prev.Discarded)
+	d.Discards = DiscardStats{
+		OversizeGet:  delta(r.Discards.OversizeGet, prev.Discards.OversizeGet),
+		OversizePut:  delta(r.Discards.OversizePut, prev.Discards.OversizePut),
+		TierMismatch: delta(r.Discards.TierMismatch, prev.Discards.TierMismatch),
+		Frozen:       delta(r.Discards.Frozen, prev.Discards.Frozen),
+	}
+	d.Inefficient = delta(r.Inefficient, prev.Inefficient)
+	d.Throttled = delta(r.Throttled, prev.Throttled)
+	d.Degraded = delta(r.Degraded, prev.Degraded)
+	d.Consolidations = delta(r.Consolidations, prev.Consolidations)
+	d.ConsolidatedBytes = delta(r.ConsolidatedBytes, prev.ConsolidatedBytes)
+	d.TierFallbacks = delta(r.TierFallbacks, prev.TierFallbacks)
+	d.Spilled = delta(r.Spilled, prev.Spilled)
+	d.SpilledBytes = delta(r.SpilledBytes, prev.SpilledBytes)
+	d.RetainsExpired = delta(r.RetainsExpired, prev.RetainsExpired)
+	d.EventsDropped = delta(r.EventsDropped, prev.EventsDropped)
+	d.Refused = delta(r.Refused, prev.Refused)
+	d.Wiped = delta(r.Wiped, prev.Wiped)
+	d.GrowthCopies = delta(r.GrowthCopies, prev.GrowthCopies)
+	d.GrowthCopiedBytes = delta(r.GrowthCopiedBytes, prev.GrowthCopiedBytes)
+	d.PutAnomalies = delta(r.PutAnomalies, prev.PutAnomalies)
+	d.Misuses = MisuseStats{
+		DoublePut:         delta(r.Misuses.DoublePut, prev.Misuses.DoublePut),
+		ForeignPut:        delta(r.Misuses.ForeignPut, prev.Misuses.ForeignPut),
+		RefCountUnderflow: delta(r.Misuses.RefCountUnderflow, prev.Misuses.RefCountUnderflow),
+		Oversize:          delta(r.Misuses.Oversize, prev.Misuses.Oversize),
+		RefCountOverflow:  delta(r.Misuses.RefCountOverflow, prev.Misuses.RefCountOverflow),
+	}
+	d.HoldSamples = delta(r.HoldSamples, prev.HoldSamples)
+
+	prevTiers := make(map[int]TierStats, len(prev.Tiers))
+	for _, tier := range prev.Tiers {
+		prevTiers[tier.Size] = tier
+	}
+	d.Tiers = make([]TierStats, len(r.Tiers))
+	for i, tier := range r.Tiers {
+		d.Tiers[i] = tier.diff(prevTiers[tier.Size])
+	}
+	return d
+}
+
+// diff returns the counter increases of a tier since prev, keeping the gauges
+func (t TierStats) diff(prev TierStats) TierStats {
+	d := t
+	d.Get = delta(t.Get, prev.Get)
+	d.Put = delta(t.Put, prev.Put)
+	d.HitSamples = delta(t.HitSamples, prev.HitSamples)
+	d.MissSamples = delta(t.MissSamples, prev.MissSamples)
+	d.SurvivedGC = delta(t.SurvivedGC, prev.SurvivedGC)
+	d.GCDrains = delta(t.GCDrains, prev.GCDrains)
+	if t.Eviction != nil {
+		var pe EvictionStats
+		if prev.Eviction != nil {
+			pe = *prev.Eviction
+		}
+		e := *t.Eviction
+		e.Hits = delta(e.Hits, pe.Hits)
+		e.Misses = delta(e.Misses, pe.Misses)
+		e.Evicted = delta(e.Evicted, pe.Evicted)
+		e.Trimmed = delta(e.Trimmed, pe.Trimmed)
+		d.Eviction = &e
+	}
+	return d
+}
+
+// PerSecond converts a counter of a Diff result into a rate over its interval
+// It returns 0 for reports that are not the result of Diff
+func (r Report) PerSecond(n int64) float64 {
+	if r.Interval <= 0 {
+		return 0
+	}
+	return float64(n) / r.Interval.Seconds()
+}
+
+// delta returns the increase of a counter, the current value when it was reset
+func delta(cur, prev int64) int64 {
+	if cur < prev {
+		return cur
+	}
+	return cur - prev
+}
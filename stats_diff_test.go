@@ -0,0 +1,49 @@
+package bytepool
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReport_Diff(t *testing.T) {
+	clock := NewManualClock(time.Unix(100, 0))
+	pool := NewPools([]int{128, 256}, WithClock(clock), WithBackendForRange(256, 256, FreeListBackend(2)))
+
+	held := pool.Get(100)
+	pool.Put(pool.Get(200))
+	prev := pool.Stats()
+
+	clock.Advance(2 * time.Second)
+	for range 4 {
+		pool.Put(pool.Get(200))
+	}
+	pool.Get(1000) // oversize
+	d := pool.Stats().Diff(prev)
+
+	if d.Interval != 2*time.Second {
+		t.Errorf("Expected interval 2s, got %v", d.Interval)
+	}
+	if d.TotalGet != 4 || d.TotalPut != 4 || d.Discarded != 1 {
+		t.Errorf("Expected 4 gets, 4 puts and 1 discard, got %d %d %d", d.TotalGet, d.TotalPut, d.Discarded)
+	}
+	if rate := d.PerSecond(d.TotalGet); rate != 2 {
+		t.Errorf("Expected 2 gets per second, got %v", rate)
+	}
+	if d.Tiers[0].Get != 0 || d.Tiers[0].InUse != 1 {
+		t.Errorf("Expected tier 128 with no new gets but 1 in use, got %+v", d.Tiers[0])
+	}
+	if d.Tiers[1].Get != 4 || d.Tiers[1].Eviction.Hits != 4 {
+		t.Errorf("Expected tier 256 with 4 gets and 4 hits, got %+v %+v", d.Tiers[1], d.Tiers[1].Eviction)
+	}
+
+	// counters lower than before mean a reset and count from zero
+	reset := pool.Stats()
+	reset.TotalGet = 3
+	if got := reset.Diff(pool.Stats()).TotalGet; got != 3 {
+		t.Errorf("Expected reset counter to count from zero, got %d", got)
+	}
+	if (Report{}).PerSecond(10) != 0 {
+		t.Error("Expected no rate without an interval")
+	}
+	pool.Put(held)
+}
@@ -0,0 +1,250 @@
+package bytepool
+
+import (
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// AppendJSON appends the JSON encoding of the report to dst and returns the extended slice
+// The output matches json.Marshal byte for byte but uses no reflection and, with enough
+// room in dst, allocates nothing, for scrapers polling Stats at a high rate
+func (r Report) AppendJSON(dst []byte) []byte {
+	dst = append(dst, `{"time":"`...)
+	dst = r.Time.AppendFormat(dst, time.RFC3339Nano)
+	dst = append(dst, '"')
+	if r.Interval != 0 {
+		dst = appendJSONInt(dst, "interval", int64(r.Interval))
+	}
+	dst = append(dst, `,"tiers":`...)
+	if r.Tiers == nil {
+		dst = append(dst, "null"...)
+	} else {
+		dst = append(dst, '[')
+		for i := range r.Tiers {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = r.Tiers[i].appendJSON(dst)
+		}
+		dst = append(dst, ']')
+	}
+	dst = appendJSONInt(dst, "total_get", r.TotalGet)
+	dst = appendJSONInt(dst, "total_put", r.TotalPut)
+	dst = appendJSONInt(dst, "discarded", r.Discarded)
+	dst = append(dst, `,"discards":{"oversize_get":`...)
+	dst = strconv.AppendInt(dst, r.Discards.OversizeGet, 10)
+	dst = appendJSONInt(dst, "oversize_put", r.Discards.OversizePut)
+	dst = appendJSONInt(dst, "tier_mismatch", r.Discards.TierMismatch)
+	dst = appendJSONInt(dst, "frozen", r.Discards.Frozen)
+	dst = append(dst, '}')
+	dst = appendJSONInt(dst, "inefficient_get", r.Inefficient)
+	dst = appendJSONInt(dst, "throttled", r.Throttled)
+	dst = appendJSONInt(dst, "degraded", r.Degraded)
+	dst = appendJSONInt(dst, "consolidations", r.Consolidations)
+	dst = appendJSONInt(dst, "consolidated_bytes", r.ConsolidatedBytes)
+	dst = appendJSONInt(dst, "tier_fallback", r.TierFallbacks)
+	dst = appendJSONInt(dst, "spilled", r.Spilled)
+	dst = appendJSONInt(dst, "spilled_bytes", r.SpilledBytes)
+	dst = appendJSONInt(dst, "spilled_in_use_bytes", r.SpilledInUse)
+	dst = appendJSONInt(dst, "retains_expired", r.RetainsExpired)
+	dst = appendJSONInt(dst, "events_dropped", r.EventsDropped)
+	dst = appendJSONInt(dst, "refused", r.Refused)
+	dst = appendJSONInt(dst, "wiped", r.Wiped)
+	dst = appendJSONInt(dst, "growth_copies", r.GrowthCopies)
+	dst = appendJSONInt(dst, "growth_copied_bytes", r.GrowthCopiedBytes)
+	dst = appendJSONInt(dst, "put_anomalies", r.PutAnomalies)
+	dst = append(dst, `,"misuses":{"double_put":`...)
+	dst = strconv.AppendInt(dst, r.Misuses.DoublePut, 10)
+	dst = appendJSONInt(dst, "foreign_put", r.Misuses.ForeignPut)
+	dst = appendJSONInt(dst, "refcount_underflow", r.Misuses.RefCountUnderflow)
+	dst = appendJSONInt(dst, "oversize", r.Misuses.Oversize)
+	dst = appendJSONInt(dst, "refcount_overflow", r.Misuses.RefCountOverflow)
+	dst = append(dst, '}')
+	dst = appendJSONInt(dst, "tracker_len", int64(r.TrackerLen))
+	dst = appendJSONInt(dst, "tracker_cap", int64(r.TrackerCap))
+	dst = appendJSONInt(dst, "tracker_sample_every", int64(r.TrackerSampleEvery))
+	if r.HoldSamples != 0 {
+		dst = appendJSONInt(dst, "hold_samples", r.HoldSamples)
+	}
+	if r.HoldP50 != 0 {
+		dst = appendJSONInt(dst, "hold_p50_ns", int64(r.HoldP50))
+	}
+	if r.HoldP99 != 0 {
+		dst = appendJSONInt(dst, "hold_p99_ns", int64(r.HoldP99))
+	}
+	dst = appendJSONInt(dst, "in_use_bytes", r.InUseBytes)
+	dst = appendJSONInt(dst, "idle_bytes", r.IdleBytes)
+	dst = appendJSONInt(dst, "held_bytes", r.HeldBytes)
+	dst = append(dst, `,"frozen":`...)
+	dst = strconv.AppendBool(dst, r.Frozen)
+	if len(r.Labels) > 0 {
+		dst = append(dst, `,"labels":`...)
+		dst = appendJSONLabels(dst, r.Labels)
+	}
+	if t := r.Tuning; t != nil {
+		dst = append(dst, `,"tuning":{"gomaxprocs":`...)
+		dst = strconv.AppendInt(dst, int64(t.GOMAXPROCS), 10)
+		dst = append(dst, `,"contention":`...)
+		dst = appendJSONFloat(dst, t.Contention)
+		dst = appendJSONInt(dst, "shards", int64(t.Shards))
+		dst = appendJSONInt(dst, "sample_every", int64(t.SampleEvery))
+		dst = append(dst, '}')
+	}
+	return append(dst, '}')
+}
+
+// appendJSON appends the JSON object of the tier
+func (t *TierStats) appendJSON(dst []byte) []byte {
+	dst = append(dst, `{"size":`...)
+	dst = strconv.AppendInt(dst, int64(t.Size), 10)
+	dst = appendJSONInt(dst, "get", t.Get)
+	dst = appendJSONInt(dst, "put", t.Put)
+	dst = appendJSONInt(dst, "in_use", t.InUse)
+	dst = appendJSONInt(dst, "in_use_bytes", t.InUseBytes)
+	dst = appendJSONInt(dst, "idle", t.Idle)
+	dst = appendJSONInt(dst, "idle_bytes", t.IdleBytes)
+	dst = append(dst, `,"idle_exact":`...)
+	dst = strconv.AppendBool(dst, t.IdleExact)
+	if t.HitSamples != 0 {
+		dst = appendJSONInt(dst, "hit_samples", t.HitSamples)
+	}
+	if t.HitP99 != 0 {
+		dst = appendJSONInt(dst, "hit_p99_ns", int64(t.HitP99))
+	}
+	if t.MissSamples != 0 {
+		dst = appendJSONInt(dst, "miss_samples", t.MissSamples)
+	}
+	if t.MissP99 != 0 {
+		dst = appendJSONInt(dst, "miss_p99_ns", int64(t.MissP99))
+	}
+	if e := t.Eviction; e != nil {
+		dst = append(dst, `,"eviction":{"policy":`...)
+		dst = appendJSONString(dst, e.Policy)
+		dst = appendJSONInt(dst, "hits", e.Hits)
+		dst = appendJSONInt(dst, "misses", e.Misses)
+		dst = appendJSONInt(dst, "evicted", e.Evicted)
+		dst = appendJSONInt(dst, "trimmed", e.Trimmed)
+		dst = append(dst, '}')
+	}
+	if t.SurvivedGC != 0 {
+		dst = appendJSONInt(dst, "survived_gc", t.SurvivedGC)
+	}
+	if t.SurvivedLastGC != nil {
+		dst = append(dst, `,"survived_last_gc":`...)
+		dst = strconv.AppendBool(dst, *t.SurvivedLastGC)
+	}
+	if t.GCDrains != 0 {
+		dst = appendJSONInt(dst, "gc_drains", t.GCDrains)
+	}
+	return append(dst, '}')
+}
+
+// appendJSONInt appends a member with an integer value, preceded by a comma
+func appendJSONInt(dst []byte, key string, v int64) []byte {
+	dst = append(dst, `,"`...)
+	dst = append(dst, key...)
+	dst = append(dst, `":`...)
+	return strconv.AppendInt(dst, v, 10)
+}
+
+// appendJSONLabels appends labels as an object with sorted keys like encoding/json
+// Keys are picked in order by repeated scans, labels are few and sorting would allocate
+func appendJSONLabels(dst []byte, labels map[string]string) []byte {
+	dst = append(dst, '{')
+	prev, first := "", true
+	for range labels {
+		next, found := "", false
+		for k := range labels {
+			if (first || k > prev) && (!found || k < next) {
+				next, found = k, true
+			}
+		}
+		if !first {
+			dst = append(dst, ',')
+		}
+		dst = appendJSONString(dst, next)
+		dst = append(dst, ':')
+		dst = appendJSONString(dst, labels[next])
+		prev, first = next, false
+	}
+	return append(dst, '}')
+}
+
+// appendJSONString appends s as a JSON string escaped like encoding/json, including
+// its HTML escaping of <, > and &
+func appendJSONString(dst []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	dst = append(dst, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				dst = append(dst, '\\', c)
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, "\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hex[r&0xf])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
+
+// appendJSONFloat appends f formatted like encoding/json, NaN and infinities as 0
+// since they have no JSON representation
+func appendJSONFloat(dst []byte, f float64) []byte {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return append(dst, '0')
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+	start := len(dst)
+	dst = strconv.AppendFloat(dst, f, format, -1, 64)
+	if format == 'e' {
+		// clean up e-09 to e-9
+		if n := len(dst) - start; n >= 4 && dst[len(dst)-4] == 'e' && dst[len(dst)-3] == '-' && dst[len(dst)-2] == '0' {
+			dst[len(dst)-2] = dst[len(dst)-1]
+			dst = dst[:len(dst)-1]
+		}
+	}
+	return dst
+}
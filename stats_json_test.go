@@ -0,0 +1,83 @@
+package bytepool
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fillInts sets every integer field reachable from v to a distinct non-zero value
+func fillInts(v reflect.Value, next *int64) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int64:
+		*next++
+		v.SetInt(*next)
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				fillInts(v.Field(i), next)
+			}
+		}
+	case reflect.Slice:
+		for i := range v.Len() {
+			fillInts(v.Index(i), next)
+		}
+	case reflect.Pointer:
+		if !v.IsNil() {
+			fillInts(v.Elem(), next)
+		}
+	}
+}
+
+func TestReport_AppendJSON(t *testing.T) {
+	pool := NewPools([]int{128, 1024}, WithAutoTune(), WithLabels(map[string]string{"service": "edge", "zone": "<a&b>\u2028\x01\"", "bad": "\xff"}))
+	pool.Put(pool.Get(100))
+
+	survived := true
+	full := pool.Stats()
+	full.Tiers = append(full.Tiers, TierStats{Eviction: &EvictionStats{Policy: "lru"}, SurvivedLastGC: &survived})
+	full.Tuning = &Tuning{Contention: 1.5e-7}
+	full.Frozen = true
+	var next int64
+	fillInts(reflect.ValueOf(&full).Elem(), &next)
+
+	reports := map[string]Report{
+		"zero":     {},
+		"live":     pool.Stats(),
+		"diff":     pool.Stats().Diff(pool.Stats()),
+		"all":      full,
+		"timezone": {Time: time.Date(2024, 5, 6, 7, 8, 9, 123400000, time.FixedZone("", 8*3600)), Tiers: []TierStats{}},
+	}
+	for name, report := range reports {
+		want, err := json.Marshal(report)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := report.AppendJSON(nil); string(got) != string(want) {
+			t.Errorf("%s: expected\n%s\ngot\n%s", name, want, got)
+		}
+	}
+}
+
+func TestReport_AppendJSONAllocs(t *testing.T) {
+	pool := NewPools([]int{128, 1024}, WithLabels(map[string]string{"service": "edge"}))
+	report := pool.Stats()
+	buf := make([]byte, 0, 4096)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = report.AppendJSON(buf[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, got %v", allocs)
+	}
+}
+
+// BenchmarkReport_AppendJSON 测试无反射编码统计快照的性能
+func BenchmarkReport_AppendJSON(b *testing.B) {
+	report := NewPools([]int{128, 1024, 4096}).Stats()
+	buf := make([]byte, 0, 4096)
+	b.ReportAllocs()
+	for b.Loop() {
+		buf = report.AppendJSON(buf[:0])
+	}
+}
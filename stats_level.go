@@ -0,0 +1,134 @@
+package bytepool
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+	"unsafe"
+	"weak"
+)
+
+// StatsLevel selects the statistics features recorded on the hot paths, as a bit set
+// Pool-wide totals, in-use bytes and discards are always counted
+type StatsLevel uint32
+
+const (
+	StatsTracker      StatsLevel = 1 << iota // recent-length ring tracking
+	StatsTierCounters                        // per-tier Get and Put counters
+	StatsHoldTimes                           // time between Get and Put of each buffer
+
+	StatsNone    StatsLevel = 0
+	StatsDefault            = StatsTracker | StatsTierCounters
+	StatsAll                = StatsDefault | StatsHoldTimes
+)
+
+// maxHoldNanos is the longest hold time tracked, longer holds are clamped
+const maxHoldNanos = int64(time.Hour)
+
+// leaseSiteSampleEvery is the share of Gets recording their caller in debug mode
+const leaseSiteSampleEvery = 8
+
+// SetStatsLevel enables the given statistics features and disables the others at
+// runtime, e.g. StatsAll during an incident and StatsDefault afterwards. Each feature is
+// an atomic flag checked on Get and Put. Per-tier in-use counts are skewed for buffers
+// leased before the tier counters were switched on; hold times are only recorded for
+// buffers leased while StatsHoldTimes is on
+func (p *BytePool) SetStatsLevel(level StatsLevel) {
+	prev := StatsLevel(p.statsLevel.Swap(uint32(level)))
+	if prev&StatsHoldTimes != 0 && level&StatsHoldTimes == 0 && !p.debug {
+		p.leases.Clear()
+	}
+}
+
+// StatsLevel returns the statistics features in effect
+func (p *BytePool) StatsLevel() StatsLevel {
+	return StatsLevel(p.statsLevel.Load())
+}
+
+// recording reports whether the statistics feature f is enabled
+func (p *BytePool) recording(f StatsLevel) bool {
+	return StatsLevel(p.statsLevel.Load())&f != 0
+}
+
+// holdTimes holds the histogram of buffer hold times
+type holdTimes struct {
+	mu   sync.Mutex
+	hist *SizeHistogram
+}
+
+// leaseRecord is kept per leased buffer in debug mode and while StatsHoldTimes is on
+type leaseRecord struct {
+	at   time.Time
+	size int
+	site string // caller of Get, sampled in debug mode
+}
+
+// tracksLeases reports whether leased buffers are recorded
+func (p *BytePool) tracksLeases() bool {
+	return p.debug || p.recording(StatsHoldTimes)
+}
+
+// returnedMark replaces the lease record of a buffer returned to the pool, so a second
+// Put is detected as a double put
+type returnedMark struct {
+	ptr weak.Pointer[byte] // detects a stale mark whose address was reused after GC
+}
+
+// leaseKey returns the key of buf in the lease records, the address of its backing array
+func leaseKey(buf []byte) (uintptr, *byte) {
+	base := unsafe.SliceData(buf[:cap(buf)])
+	return uintptr(unsafe.Pointer(base)), base
+}
+
+// recordLease remembers when buf was leased
+func (p *BytePool) recordLease(buf []byte) {
+	rec := leaseRecord{at: p.now(), size: cap(buf)}
+	if p.debug && rand.Uint32N(leaseSiteSampleEvery) == 0 {
+		rec.site = callSite()
+	}
+	key, _ := leaseKey(buf)
+	p.leases.Store(key, rec)
+}
+
+// recordReturn marks buf as returned and records its hold time with StatsHoldTimes
+// It reports a double put when buf was already returned and not leased since
+func (p *BytePool) recordReturn(buf []byte) (double bool) {
+	key, base := leaseKey(buf)
+	v, ok := p.leases.Swap(key, returnedMark{ptr: weak.Make(base)})
+	if !ok {
+		return false
+	}
+	rec, leased := v.(leaseRecord)
+	if !leased {
+		return v.(returnedMark).ptr.Value() == base
+	}
+	if !p.recording(StatsHoldTimes) {
+		return false
+	}
+	held := p.now().Sub(rec.at)
+	p.holds.mu.Lock()
+	if p.holds.hist == nil {
+		p.holds.hist = NewSizeHistogram(maxHoldNanos, 2)
+	}
+	p.holds.hist.Record(max(held.Nanoseconds(), 1))
+	p.holds.mu.Unlock()
+	return false
+}
+
+// forgetLease drops the lease record of a buffer leaving the pool for good
+func (p *BytePool) forgetLease(buf []byte) {
+	key, _ := leaseKey(buf)
+	p.leases.Delete(key)
+}
+
+// fillHolds adds the hold time percentiles to the report
+func (p *BytePool) fillHolds(report *Report) {
+	p.holds.mu.Lock()
+	defer p.holds.mu.Unlock()
+	if p.holds.hist == nil {
+		return
+	}
+	report.HoldSamples = p.holds.hist.TotalCount()
+	report.HoldP50 = time.Duration(p.holds.hist.ValueAtPercentile(50))
+	report.HoldP99 = time.Duration(p.holds.hist.ValueAtPercentile(99))
+}
@@ -0,0 +1,58 @@
+package bytepool
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBytePool_SetStatsLevel(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	pool := NewPools([]int{128}, WithClock(clock), WithTrackerCapacity(256))
+	if pool.StatsLevel() != StatsDefault {
+		t.Fatalf("Expected default level, got %b", pool.StatsLevel())
+	}
+
+	pool.SetStatsLevel(StatsNone)
+	pool.Put(pool.Get(100))
+	report := pool.Stats()
+	if report.TrackerLen != 0 || report.Tiers[0].Get != 0 {
+		t.Errorf("Expected no tracking or tier counters, got %d samples and %d gets", report.TrackerLen, report.Tiers[0].Get)
+	}
+	if report.TotalGet != 1 || report.TotalPut != 1 {
+		t.Errorf("Expected totals always counted, got %d/%d", report.TotalGet, report.TotalPut)
+	}
+
+	pool.SetStatsLevel(StatsAll)
+	buf := pool.Get(100)
+	clock.Advance(50 * time.Millisecond)
+	pool.Put(buf)
+	report = pool.Stats()
+	if report.TrackerLen != 1 || report.Tiers[0].Get != 1 {
+		t.Errorf("Expected tracking and tier counters, got %d samples and %d gets", report.TrackerLen, report.Tiers[0].Get)
+	}
+	if report.HoldSamples != 1 || report.HoldP99 < 49*time.Millisecond || report.HoldP99 > 51*time.Millisecond {
+		t.Errorf("Expected one 50ms hold, got %d samples p99 %v", report.HoldSamples, report.HoldP99)
+	}
+
+	// leases recorded while on are forgotten when hold times are switched off
+	leased := pool.Get(100)
+	pool.SetStatsLevel(StatsDefault)
+	pool.Put(leased)
+	if n := pool.Stats().HoldSamples; n != 1 {
+		t.Errorf("Expected no hold recorded after switching off, got %d samples", n)
+	}
+}
+
+// BenchmarkStatsLevel 测试不同统计级别下 Get/Put 的开销
+func BenchmarkStatsLevel(b *testing.B) {
+	for _, level := range []StatsLevel{StatsNone, StatsDefault, StatsAll} {
+		pool := NewPools([]int{128})
+		pool.SetStatsLevel(level)
+		b.Run(map[StatsLevel]string{StatsNone: "none", StatsDefault: "default", StatsAll: "all"}[level], func(b *testing.B) {
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				pool.Put(pool.Get(100))
+			}
+		})
+	}
+}
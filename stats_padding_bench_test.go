@@ -0,0 +1,98 @@
+package bytepool
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"unsafe"
+)
+
+// unpaddedStats mirrors the previous PoolStats layout without cache line padding
+type unpaddedStats struct {
+	Get int64
+	Put int64
+}
+
+func TestPoolStats_Padding(t *testing.T) {
+	var s PoolStats
+	if d := unsafe.Offsetof(s.Put) - unsafe.Offsetof(s.Get); d < cacheLineSize {
+		t.Errorf("Expected Get and Put on separate cache lines, got %d bytes apart", d)
+	}
+	if tail := unsafe.Sizeof(s) - unsafe.Offsetof(s.Put); tail < cacheLineSize {
+		t.Errorf("Expected Put to own its cache line, got %d trailing bytes", tail)
+	}
+}
+
+// benchmarkCounters increments one counter per goroutine, as Get/Put on different tiers do
+func benchmarkCounters(b *testing.B, counter func(i int) *int64) {
+	procs := runtime.GOMAXPROCS(0)
+	var next int64
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		c := counter(int(atomic.AddInt64(&next, 1)-1) % procs)
+		for pb.Next() {
+			atomic.AddInt64(c, 1)
+		}
+	})
+}
+
+// BenchmarkStatsCounters_Padded 测试带缓存行填充的统计计数器
+func BenchmarkStatsCounters_Padded(b *testing.B) {
+	stats := make([]PoolStats, runtime.GOMAXPROCS(0))
+	benchmarkCounters(b, func(i int) *int64 {
+		if i%2 == 0 {
+			return &stats[i/2].Get
+		}
+		return &stats[i/2].Put
+	})
+}
+
+// BenchmarkStatsCounters_Unpadded 测试无填充的统计计数器（伪共享）
+func BenchmarkStatsCounters_Unpadded(b *testing.B) {
+	stats := make([]unpaddedStats, runtime.GOMAXPROCS(0))
+	benchmarkCounters(b, func(i int) *int64 {
+		if i%2 == 0 {
+			return &stats[i/2].Get
+		}
+		return &stats[i/2].Put
+	})
+}
+
+// BenchmarkBytePool_ConcurrentTiers 测试多档位并发 Get/Put
+func BenchmarkBytePool_ConcurrentTiers(b *testing.B) {
+	pool := NewPools(SizePowerOfTwo())
+	sizes := pool.GetAvailableSizes()
+	var next int64
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		size := sizes[int(atomic.AddInt64(&next, 1))%len(sizes)]
+		for pb.Next() {
+			pool.Put(pool.Get(size))
+		}
+	})
+}
+
+// benchmarkSingleTier runs concurrent Get/Put on one tier, where every call hits the same counters
+func benchmarkSingleTier(b *testing.B, opts ...Option) {
+	pool := NewPools([]int{1024}, opts...)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			pool.Put(pool.Get(1000))
+		}
+	})
+}
+
+// BenchmarkBytePool_SharedStats 测试共享统计计数器下的单档位并发 Get/Put
+func BenchmarkBytePool_SharedStats(b *testing.B) {
+	benchmarkSingleTier(b)
+}
+
+// BenchmarkBytePool_BatchedStats 测试分片累积统计下的单档位并发 Get/Put
+func BenchmarkBytePool_BatchedStats(b *testing.B) {
+	benchmarkSingleTier(b, WithBatchedStats())
+}
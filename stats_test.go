@@ -0,0 +1,124 @@
+package bytepool
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBytePool_Stats(t *testing.T) {
+	pool := NewPools([]int{128, 256}, WithBackendForRange(256, 256, FreeListBackend(4)))
+
+	a := pool.Get(100)
+	b := pool.Get(200)
+	c := pool.Get(250)
+	pool.Put(c)
+	pool.Get(1000) // oversize
+
+	report := pool.Stats()
+	if report.TotalGet != 3 || report.TotalPut != 1 || report.Discarded != 1 {
+		t.Errorf("Unexpected totals: %+v", report)
+	}
+	if len(report.Tiers) != 2 || report.Tiers[0].Size != 128 || report.Tiers[1].Size != 256 {
+		t.Fatalf("Expected ordered tiers, got %+v", report.Tiers)
+	}
+
+	small, large := report.Tiers[0], report.Tiers[1]
+	if small.InUse != 1 || small.InUseBytes != 128 || small.Idle != 0 || small.IdleExact {
+		t.Errorf("Unexpected small tier stats: %+v", small)
+	}
+	if large.InUse != 1 || large.InUseBytes != 256 || large.Idle != 1 || large.IdleBytes != 256 || !large.IdleExact {
+		t.Errorf("Unexpected large tier stats: %+v", large)
+	}
+	if report.InUseBytes != 384 || report.IdleBytes != 256 || report.HeldBytes != 640 {
+		t.Errorf("Unexpected byte totals: in use %d, idle %d, held %d", report.InUseBytes, report.IdleBytes, report.HeldBytes)
+	}
+
+	pool.Put(a)
+	pool.Put(b)
+}
+
+func TestBytePool_FreezeThaw(t *testing.T) {
+	pool := NewPools([]int{128}, WithBackend(FreeListBackend(4)))
+	if err := pool.Reserve(128, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	report := pool.Freeze()
+	if !report.Frozen || !pool.Frozen() {
+		t.Error("Expected pool to be frozen")
+	}
+	if report.Tiers[0].Idle != 2 {
+		t.Errorf("Expected 2 idle buffers, got %d", report.Tiers[0].Idle)
+	}
+
+	// frozen pool must not touch its stores
+	buf := pool.Get(100)
+	if cap(buf) != 128 {
+		t.Errorf("Expected tier capacity, got %d", cap(buf))
+	}
+	pool.Put(buf)
+	pool.Put(make([]byte, 128))
+	if idle := pool.Stats().Tiers[0].Idle; idle != 2 {
+		t.Errorf("Expected idle count unchanged while frozen, got %d", idle)
+	}
+
+	pool.Thaw()
+	if pool.Frozen() {
+		t.Error("Expected pool to be thawed")
+	}
+	pool.Get(100)
+	if idle := pool.Stats().Tiers[0].Idle; idle != 1 {
+		t.Errorf("Expected store to be used after thaw, got idle %d", idle)
+	}
+}
+
+func TestBytePool_SyncPoolOccupancy(t *testing.T) {
+	pool := NewPools([]int{128})
+
+	bufs := [][]byte{pool.Get(100), pool.Get(100), pool.Get(100)}
+	for _, buf := range bufs {
+		pool.Put(buf)
+	}
+
+	tier := pool.Stats().Tiers[0]
+	if tier.Idle != 3 || tier.IdleBytes != 384 || tier.IdleExact {
+		t.Errorf("Expected approximately 3 idle buffers, got %+v", tier)
+	}
+	if got := pool.GetPoolStats()["pools"].(map[int]map[string]int64)[128]["idle"]; got != 3 {
+		t.Errorf("Expected idle 3 in pool stats, got %d", got)
+	}
+}
+
+func TestBytePool_Tiers(t *testing.T) {
+	pool := NewPools([]int{128, 1024, 4096})
+	pool.Get(100)
+
+	var sizes []int
+	for size, tier := range pool.Tiers() {
+		if size != tier.Size {
+			t.Errorf("Expected key %d to match tier size %d", size, tier.Size)
+		}
+		if size == 128 && tier.Get != 1 {
+			t.Errorf("Expected 1 get for tier 128, got %d", tier.Get)
+		}
+		sizes = append(sizes, size)
+	}
+	if !slices.Equal(sizes, []int{128, 1024, 4096}) {
+		t.Errorf("Expected tiers in size order, got %v", sizes)
+	}
+
+	for size := range pool.Tiers() {
+		if size != 128 {
+			t.Errorf("Expected to stop after the first tier, got %d", size)
+		}
+		break
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		for range pool.Tiers() {
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, got %v", allocs)
+	}
+}
@@ -0,0 +1,237 @@
+//go:build stress
+
+package bytepool
+
+import (
+	"flag"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Run with: go test -race -tags stress -run Stress -stress.duration 30s
+var (
+	stressDuration = flag.Duration("stress.duration", 5*time.Second, "how long each stress test runs")
+	stressSeed     = flag.Uint64("stress.seed", 0, "random seed, 0 picks one")
+)
+
+// stressSeedFor returns the random seed of a test and logs it so failures can be replayed
+func stressSeedFor(t *testing.T) uint64 {
+	seed := *stressSeed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	t.Logf("stress seed %d", seed)
+	return seed
+}
+
+// stressTracker returns the tracker queue for stress pools
+// The lock-free RingQueue allows dirty reads by design, which the race detector reports,
+// so race runs use the locked queue and TestStress_RingQueue covers the lock-free one
+func stressTracker() Option {
+	if raceEnabled {
+		return WithRingQueueType(MutexRingQueue)
+	}
+	return WithRingQueueType(LockFreeRingQueue)
+}
+
+func TestStress_Pool(t *testing.T) {
+	sizes := append(SizeSmall(), SizePowerOfTwo()[:8]...)
+	pool := NewPools(sizes, stressTracker(), WithTierFallback(1))
+	maxLen := pool.GetMax() + 64 // include oversize requests
+
+	seed := stressSeedFor(t)
+	var ops atomic.Int64
+	deadline := time.Now().Add(*stressDuration)
+	var wg sync.WaitGroup
+	for w := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := rand.New(rand.NewPCG(seed, uint64(w)))
+			pattern := byte(w + 1)
+			var held [][]byte
+			for time.Now().Before(deadline) {
+				switch r.IntN(4) {
+				case 0, 1:
+					length := 1 + r.IntN(maxLen)
+					buf := pool.Get(length)
+					if len(buf) != length {
+						t.Errorf("Expected length %d, got %d", length, len(buf))
+						return
+					}
+					// a buffer leased twice at once would be overwritten by its other holder
+					for i := range buf {
+						buf[i] = pattern
+					}
+					held = append(held, buf)
+				case 2:
+					if len(held) == 0 {
+						continue
+					}
+					i := r.IntN(len(held))
+					buf := held[i]
+					for j, b := range buf {
+						if b != pattern {
+							t.Errorf("Leased buffer modified by another holder at %d", j)
+							return
+						}
+					}
+					pool.Put(buf)
+					held = append(held[:i], held[i+1:]...)
+				case 3:
+					if r.IntN(64) == 0 {
+						_ = pool.Stats()
+						_ = pool.GetPoolStats()
+					}
+				}
+				ops.Add(1)
+			}
+			for _, buf := range held {
+				pool.Put(buf)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := pool.Outstanding(); n != 0 {
+		t.Errorf("Expected no outstanding buffers, got %d", n)
+	}
+	report := pool.Stats()
+	if report.TotalGet != report.TotalPut {
+		t.Errorf("Expected balanced counters, got get %d put %d", report.TotalGet, report.TotalPut)
+	}
+	for _, tier := range report.Tiers {
+		if tier.InUse != 0 {
+			t.Errorf("Expected tier %d to have nothing in use, got %d", tier.Size, tier.InUse)
+		}
+	}
+	t.Logf("%d operations", ops.Load())
+}
+
+func TestStress_BufferRefCount(t *testing.T) {
+	pool := NewPools([]int{128, 1024, 4096}, stressTracker())
+	seed := stressSeedFor(t)
+
+	deadline := time.Now().Add(*stressDuration)
+	var wg sync.WaitGroup
+	for w := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := rand.New(rand.NewPCG(seed, uint64(w)))
+			for time.Now().Before(deadline) {
+				length := 1 + r.IntN(4096)
+				buf := pool.GetBuffer(length)
+				sum := byte(r.IntN(255) + 1)
+				data, release := buf.Bytes()
+				for i := range data {
+					data[i] = sum
+				}
+				release()
+
+				// share the buffer with readers that each hold their own reference
+				readers := 1 + r.IntN(4)
+				var rg sync.WaitGroup
+				for range readers {
+					buf.Retain()
+					rg.Add(1)
+					go func() {
+						defer rg.Done()
+						defer buf.Release()
+						data, release := buf.Bytes()
+						defer release()
+						if len(data) != length {
+							t.Errorf("Expected length %d, got %d", length, len(data))
+						}
+						for i, b := range data {
+							if b != sum {
+								t.Errorf("Shared buffer changed at %d while referenced", i)
+								return
+							}
+						}
+					}()
+				}
+				buf.Release() // the owner may finish before the readers
+				rg.Wait()
+				if buf.refCount != 0 {
+					t.Errorf("Expected refCount 0 after all releases, got %d", buf.refCount)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := pool.Outstanding(); n != 0 {
+		t.Errorf("Expected no outstanding buffers, got %d", n)
+	}
+}
+
+// TestStress_RingQueue checks the invariants the lock-free queue keeps despite dirty
+// reads: reads never exceed the capacity and only return values that were pushed
+// The race detector reports the dirty reads themselves, so it verifies the locked queue
+func TestStress_RingQueue(t *testing.T) {
+	const size = 256
+	var q interface {
+		RingQueuer
+		Last(n int) []int
+		Since(pos uint64) ([]int, uint64)
+	} = NewRingQueue[int](size)
+	if raceEnabled {
+		q = NewLockedRingQueue[int](size)
+	}
+
+	// values encode writer and sequence, writer in the low byte, so any torn or
+	// invented value fails validation
+	valid := func(v, writers int) bool {
+		return v > 0 && v&0xff < writers
+	}
+	const writers = 4
+	seed := stressSeedFor(t)
+
+	deadline := time.Now().Add(*stressDuration)
+	var wg sync.WaitGroup
+	for w := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for seq := 1; time.Now().Before(deadline); seq++ {
+				q.Push(seq<<8 | w)
+			}
+		}()
+	}
+	for w := range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := rand.New(rand.NewPCG(seed, uint64(w)))
+			var pos uint64
+			for time.Now().Before(deadline) {
+				var got []int
+				switch r.IntN(3) {
+				case 0:
+					got = q.Bytes()
+				case 1:
+					got = q.Last(r.IntN(2 * size))
+				case 2:
+					got, pos = q.Since(pos)
+				}
+				if len(got) > size || q.Len() > q.Cap() {
+					t.Errorf("Expected at most %d values, got %d (len %d)", size, len(got), q.Len())
+					return
+				}
+				for _, v := range got {
+					// slots not yet written hold zero until the queue wraps
+					if v != 0 && !valid(v, writers) {
+						t.Errorf("Read value %#x that was never pushed", v)
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
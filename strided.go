@@ -0,0 +1,31 @@
+package bytepool
+
+import "unsafe"
+
+// GetStrided leases a single Buffer holding rows rows of rowBytes bytes for image and
+// video codecs requiring padded strides. It returns the buffer, rows*stride bytes long,
+// and the stride, rowBytes rounded up to strideAlign; every row starts at a multiple of
+// strideAlign in memory, so row i is data[i*stride : i*stride+rowBytes]
+// Returns a nil Buffer when the pool refuses the padded length, see WithMaxGetLength
+// Panics if rows or rowBytes is not positive or strideAlign is not a power of two
+func (p *BytePool) GetStrided(rows, rowBytes, strideAlign int) (*Buffer, int) {
+	if rows <= 0 || rowBytes <= 0 {
+		panic("rows and row bytes must be positive")
+	}
+	if strideAlign <= 0 || strideAlign&(strideAlign-1) != 0 {
+		panic("stride alignment must be a power of two")
+	}
+	stride := alignUp(rowBytes, strideAlign)
+	length := rows * stride
+
+	// lease room to move the first row to an aligned address
+	raw := p.Get(length + strideAlign - 1)
+	if raw == nil {
+		return nil, stride
+	}
+	addr := uintptr(unsafe.Pointer(unsafe.SliceData(raw)))
+	off := int(uintptr(strideAlign)-addr%uintptr(strideAlign)) % strideAlign
+	buf := NewBuffer(raw[off:off+length], p)
+	buf.release = func() { p.Put(raw) }
+	return buf, stride
+}
@@ -0,0 +1,39 @@
+package bytepool
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestBytePool_GetStrided(t *testing.T) {
+	pool := NewPools([]int{4096, 65536})
+
+	buf, stride := pool.GetStrided(16, 1000, 64)
+	if stride != 1024 {
+		t.Errorf("Expected stride 1024, got %d", stride)
+	}
+	data, release := buf.Bytes()
+	if len(data) != 16*1024 {
+		t.Errorf("Expected %d bytes, got %d", 16*1024, len(data))
+	}
+	for row := range 16 {
+		if addr := uintptr(unsafe.Pointer(&data[row*stride])); addr%64 != 0 {
+			t.Errorf("Expected row %d aligned to 64, got address %#x", row, addr)
+		}
+	}
+	release()
+	buf.Release()
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected the block returned, got %d outstanding", pool.Outstanding())
+	}
+	if report := pool.Stats(); report.Discarded != 0 {
+		t.Errorf("Expected the block pooled, got %d discarded", report.Discarded)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for a non power of two alignment")
+		}
+	}()
+	pool.GetStrided(1, 10, 48)
+}
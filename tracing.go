@@ -0,0 +1,34 @@
+package bytepool
+
+import (
+	"context"
+	"runtime/trace"
+	"strconv"
+)
+
+// WithTracing wraps Get, Put and Buffer.Release in runtime/trace regions annotated with
+// the requested size and the tier, so go tool trace shows pool activity alongside
+// goroutine scheduling. The overhead is a single branch while no trace is being recorded
+func WithTracing() Option {
+	return func(p *BytePool) {
+		p.tracing = true
+	}
+}
+
+func (p *BytePool) tracedGet(length int) []byte {
+	ctx := context.Background()
+	defer trace.StartRegion(ctx, "bytepool.Get").End()
+	trace.Log(ctx, "size", strconv.Itoa(length))
+	if length > 0 && length <= p.GetMax() {
+		trace.Log(ctx, "tier", strconv.Itoa(p.findBestSize(length)))
+	}
+	return p.get(length)
+}
+
+func (p *BytePool) tracedPut(buf []byte) {
+	ctx := context.Background()
+	defer trace.StartRegion(ctx, "bytepool.Put").End()
+	trace.Log(ctx, "size", strconv.Itoa(len(buf)))
+	trace.Log(ctx, "tier", strconv.Itoa(cap(buf)))
+	p.put(buf)
+}
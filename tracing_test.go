@@ -0,0 +1,34 @@
+package bytepool
+
+import (
+	"bytes"
+	"runtime/trace"
+	"testing"
+)
+
+func TestWithTracing(t *testing.T) {
+	pool := NewPools([]int{128, 256}, WithTracing())
+
+	var out bytes.Buffer
+	if err := trace.Start(&out); err != nil {
+		t.Skipf("trace unavailable: %v", err)
+	}
+	buf := pool.GetBuffer(200)
+	buf.Release()
+	pool.Put(pool.Get(100))
+	trace.Stop()
+
+	if out.Len() == 0 {
+		t.Error("Expected trace output")
+	}
+	for _, name := range []string{"bytepool.Get", "bytepool.Put", "bytepool.Release"} {
+		if !bytes.Contains(out.Bytes(), []byte(name)) {
+			t.Errorf("Expected region %q in trace", name)
+		}
+	}
+
+	stats := pool.GetPoolStats()
+	if stats["total_get"].(int64) != 2 || stats["total_put"].(int64) != 2 {
+		t.Errorf("Expected traced operations to be counted, got get=%v put=%v", stats["total_get"], stats["total_put"])
+	}
+}
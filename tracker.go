@@ -0,0 +1,30 @@
+package bytepool
+
+// tracker returns the active recent-length tracker
+func (p *BytePool) tracker() RingQueuer {
+	return *p.recentLengths.Load()
+}
+
+// SetTracker atomically replaces the recent-length tracker at runtime and returns the
+// previous one, e.g. to raise the sample capacity during an incident and restore it later
+// Samples in the previous tracker are not carried over. A ring file mirror configured
+// with WithRingFileMirror keeps receiving samples
+func (p *BytePool) SetTracker(q RingQueuer) RingQueuer {
+	if q == nil || q.Cap() <= 0 {
+		panic("tracker must be non-nil with a positive capacity")
+	}
+	if m, ok := q.(*mirrorQueue); ok {
+		q = m.RingQueuer
+	}
+	if p.ringMirror != nil {
+		q = &mirrorQueue{RingQueuer: q, file: p.ringMirror}
+	}
+	prev := p.recentLengths.Swap(&q)
+	if prev == nil {
+		return nil
+	}
+	if m, ok := (*prev).(*mirrorQueue); ok {
+		return m.RingQueuer
+	}
+	return *prev
+}
@@ -0,0 +1,96 @@
+package bytepool
+
+import (
+	"math/bits"
+	"strconv"
+)
+
+// TrackerBuckets selects what the recent-length tracker records per sampled Get
+type TrackerBuckets int
+
+const (
+	// TrackerRawLengths records the requested lengths, the default
+	TrackerRawLengths TrackerBuckets = iota
+	// TrackerTierBuckets records the index of the tier serving the length, len(sizes)
+	// for oversize lengths
+	TrackerTierBuckets
+	// TrackerLog2Buckets records bits.Len(length), bucket b holding lengths in [2^(b-1), 2^b)
+	TrackerLog2Buckets
+)
+
+// String returns the name of the mode
+func (b TrackerBuckets) String() string {
+	switch b {
+	case TrackerRawLengths:
+		return "raw"
+	case TrackerTierBuckets:
+		return "tier"
+	case TrackerLog2Buckets:
+		return "log2"
+	default:
+		return "TrackerBuckets(" + strconv.Itoa(int(b)) + ")"
+	}
+}
+
+// maxBucket is the largest bucket a bucketed tracker holds, larger ones are clamped
+const maxBucket = 255
+
+// WithTrackerBuckets records low-cardinality buckets instead of raw lengths in the
+// recent-length tracker. The default tracker then stores a byte per sample instead of
+// an int, and downstream aggregation only counts bucket values. Everything reading the
+// tracker, such as GetPoolStats recent_lengths, SaveStats and LengthHistogram, sees the
+// bucket values; tier indexes refer to GetAvailableSizes
+func WithTrackerBuckets(mode TrackerBuckets) Option {
+	if mode < TrackerRawLengths || mode > TrackerLog2Buckets {
+		panic("unknown tracker buckets mode")
+	}
+	return func(p *BytePool) {
+		p.trackerBuckets = mode
+		p.customTracker = true
+	}
+}
+
+// trackValue returns the value the tracker records for a Get of length
+func (p *BytePool) trackValue(st *poolState, length int) int {
+	switch p.trackerBuckets {
+	case TrackerTierBuckets:
+		return st.tierIndex(length)
+	case TrackerLog2Buckets:
+		return bits.Len(uint(length))
+	}
+	return length
+}
+
+// bucketQueue is a lock-free tracker storing bucket values in a byte each
+type bucketQueue struct {
+	q *RingQueue[uint8]
+}
+
+func newBucketQueue(size int) *bucketQueue {
+	return &bucketQueue{q: NewRingQueue[uint8](size)}
+}
+
+// Push records a bucket, clamped to maxBucket
+func (b *bucketQueue) Push(bucket int) {
+	b.q.Push(uint8(min(max(bucket, 0), maxBucket)))
+}
+
+// Bytes returns the recorded buckets
+func (b *bucketQueue) Bytes() []int {
+	raw := b.q.Bytes()
+	out := make([]int, len(raw))
+	for i, v := range raw {
+		out[i] = int(v)
+	}
+	return out
+}
+
+// Len returns the number of recorded buckets
+func (b *bucketQueue) Len() int {
+	return b.q.Len()
+}
+
+// Cap returns the tracker capacity
+func (b *bucketQueue) Cap() int {
+	return b.q.Cap()
+}
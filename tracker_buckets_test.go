@@ -0,0 +1,44 @@
+package bytepool
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWithTrackerBuckets(t *testing.T) {
+	tests := []struct {
+		mode TrackerBuckets
+		want []int
+	}{
+		{TrackerRawLengths, []int{100, 1000, 5000}},
+		{TrackerTierBuckets, []int{0, 1, 2}},
+		{TrackerLog2Buckets, []int{7, 10, 13}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			pool := NewPools([]int{128, 1024}, WithTrackerCapacity(64), WithTrackerBuckets(tt.mode))
+			for _, length := range []int{100, 1000, 5000} {
+				pool.Get(length)
+			}
+			if got := pool.tracker().Bytes(); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v recorded, got %v", tt.want, got)
+			}
+			if pool.Stats().TrackerCap != 64 {
+				t.Errorf("Expected capacity kept, got %d", pool.Stats().TrackerCap)
+			}
+			_, bucketed := pool.tracker().(*bucketQueue)
+			if bucketed != (tt.mode != TrackerRawLengths) {
+				t.Errorf("Expected byte tracker only for bucketed modes, got %T", pool.tracker())
+			}
+		})
+	}
+}
+
+func TestBucketQueue_Clamp(t *testing.T) {
+	q := newBucketQueue(2)
+	q.Push(300)
+	q.Push(-1)
+	if got := q.Bytes(); !slices.Equal(got, []int{maxBucket, 0}) {
+		t.Errorf("Expected clamped buckets, got %v", got)
+	}
+}
@@ -0,0 +1,98 @@
+package bytepool
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestBytePool_SetTracker(t *testing.T) {
+	pool := NewPools([]int{128, 256})
+	pool.Get(10)
+
+	bigger := NewLockedRingQueue[int](1024)
+	prev := pool.SetTracker(bigger)
+	if _, ok := prev.(*RingQueue[int]); !ok {
+		t.Errorf("Expected previous default tracker, got %T", prev)
+	}
+
+	pool.Get(20)
+	pool.Get(30)
+	if got := pool.GetPoolStats()["recent_lengths"].([]int); !slices.Equal(got, []int{20, 30}) {
+		t.Errorf("Expected [20 30] from new tracker, got %v", got)
+	}
+
+	if restored := pool.SetTracker(prev); restored != bigger {
+		t.Error("Expected SetTracker to return the replaced tracker")
+	}
+	if got := pool.GetPoolStats()["recent_lengths"].([]int); !slices.Equal(got, []int{10}) {
+		t.Errorf("Expected [10] after restore, got %v", got)
+	}
+}
+
+func TestBytePool_SetTrackerConcurrent(t *testing.T) {
+	pool := NewPools([]int{128, 256})
+
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 1000 {
+				pool.Put(pool.Get(i*10 + j%100 + 1))
+			}
+		}()
+	}
+	for range 100 {
+		pool.SetTracker(NewRingQueue[int](64))
+	}
+	wg.Wait()
+
+	if pool.Outstanding() != 0 {
+		t.Errorf("Expected balanced pool, got %d outstanding", pool.Outstanding())
+	}
+}
+
+// zeroCapQueue is a custom tracker reporting an invalid capacity
+type zeroCapQueue struct{ RingQueuer }
+
+func (zeroCapQueue) Cap() int { return 0 }
+
+func TestBytePool_TrackerCapacityStats(t *testing.T) {
+	pool := NewPools([]int{128}, WithRingQueue(NewLockedRingQueue[int](64)))
+	pool.Get(1)
+	pool.Get(2)
+
+	stats := pool.GetPoolStats()
+	if stats["tracker_len"].(int) != 2 || stats["tracker_cap"].(int) != 64 {
+		t.Errorf("Expected tracker 2/64, got %v/%v", stats["tracker_len"], stats["tracker_cap"])
+	}
+	if report := pool.Stats(); report.TrackerLen != 2 || report.TrackerCap != 64 {
+		t.Errorf("Expected report tracker 2/64, got %d/%d", report.TrackerLen, report.TrackerCap)
+	}
+
+	// invalid custom queues are ignored in favor of the default
+	pool = NewPools([]int{128}, WithRingQueue(zeroCapQueue{NewRingQueue[int](8)}), WithRingQueue(nil))
+	if got, want := pool.Stats().TrackerCap, DefaultTrackerCapacity(); got != want {
+		t.Errorf("Expected default tracker capacity %d, got %d", want, got)
+	}
+}
+
+func TestWithTrackerCapacity(t *testing.T) {
+	if got := DefaultTrackerCapacity(); got < 256 || got > maxDefaultTrackerCap {
+		t.Errorf("Expected default capacity within [256, %d], got %d", maxDefaultTrackerCap, got)
+	}
+
+	pool := NewPools([]int{128}, WithRingQueueType(MutexRingQueue), WithTrackerCapacity(1024))
+	if _, ok := pool.tracker().(*LockedRingQueue[int]); !ok {
+		t.Errorf("Expected tracker type to be kept, got %T", pool.tracker())
+	}
+	if got := pool.Stats().TrackerCap; got != 1024 {
+		t.Errorf("Expected tracker capacity 1024, got %d", got)
+	}
+
+	pool = NewPools([]int{128}, WithTrackerCapacity(32), WithRingQueueType(MutexRingQueue))
+	if got := pool.Stats().TrackerCap; got != 32 {
+		t.Errorf("Expected capacity kept across queue type change, got %d", got)
+	}
+}
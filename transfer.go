@@ -0,0 +1,43 @@
+package bytepool
+
+// TransferTo moves ownership of buf from p to dst, e.g. from an ingest pool to an egress pool
+// When the capacity of buf is a tier of dst the buffer itself moves: p records a Put and
+// dst a Get, so both pools' statistics stay balanced. Otherwise the content is copied into
+// a buffer leased from dst and buf is returned to p. The caller must use the returned
+// slice and eventually Put it into dst
+func (p *BytePool) TransferTo(dst *BytePool, buf []byte) []byte {
+	if dst == p || buf == nil || cap(buf) == 0 {
+		return buf
+	}
+
+	capacity := cap(buf)
+	dstTier := dst.state.Load().tier(capacity)
+	if dstTier == nil {
+		out := dst.Get(len(buf))
+		if out == nil {
+			// refused by dst, keep the data in a plain allocation
+			out = make([]byte, len(buf))
+		}
+		copy(out, buf)
+		p.Put(buf)
+		return out
+	}
+
+	if watermarking.Load() {
+		p.checkWatermark(buf)
+	}
+	if stat := p.state.Load().tierStat(capacity); stat != nil {
+		p.countPut(stat, capacity)
+	}
+	dst.countGet(dstTier.stats, capacity)
+	if p.tracksLeases() {
+		p.forgetLease(buf)
+	}
+	if dst.tracksLeases() {
+		dst.recordLease(buf)
+	}
+	if dst.debug {
+		dst.watermark(buf)
+	}
+	return buf
+}
@@ -0,0 +1,39 @@
+package bytepool
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBytePool_TransferTo(t *testing.T) {
+	ingest := NewPools([]int{128, 256}, WithDebug())
+	egress := NewPools([]int{256, 512}, WithDebug())
+
+	// compatible tier: the buffer itself moves
+	buf := ingest.Get(200)
+	copy(buf, "frame")
+	moved := ingest.TransferTo(egress, buf)
+	if &moved[0] != &buf[0] {
+		t.Error("Expected buffer to move without copying")
+	}
+	if ingest.Outstanding() != 0 || egress.Outstanding() != 1 {
+		t.Errorf("Expected ownership moved, got ingest=%d egress=%d", ingest.Outstanding(), egress.Outstanding())
+	}
+	egress.Put(moved) // no cross pool panic after transfer
+
+	// incompatible tier: the content is copied
+	buf = ingest.Get(100)
+	copy(buf, "payload")
+	copied := ingest.TransferTo(egress, buf)
+	if cap(copied) != 256 || !bytes.HasPrefix(copied, []byte("payload")) || len(copied) != 100 {
+		t.Errorf("Expected copy into a 256 byte tier, got cap %d", cap(copied))
+	}
+	egress.Put(copied)
+
+	if ingest.Outstanding() != 0 || egress.Outstanding() != 0 {
+		t.Errorf("Expected balanced pools, got ingest=%d egress=%d", ingest.Outstanding(), egress.Outstanding())
+	}
+	if s := ingest.Stats(); s.TotalGet != 2 || s.TotalPut != 2 {
+		t.Errorf("Unexpected ingest stats %+v", s)
+	}
+}
@@ -0,0 +1,61 @@
+package bytepool
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+	"weak"
+)
+
+var (
+	// nextPoolID hands out process unique pool ids
+	nextPoolID atomic.Uint64
+	// watermarks maps the backing array address of buffers leased by debug pools to their origin
+	watermarks sync.Map
+	// watermarking is set once any debug pool leased a buffer, so other pools check their Puts
+	watermarking atomic.Bool
+)
+
+// bufferMark records the pool a buffer was leased from
+type bufferMark struct {
+	owner uint64
+	name  string
+	ptr   weak.Pointer[byte] // detects a stale entry whose address was reused after GC
+}
+
+// watermark records p as the origin of buf, called on Get in debug mode
+func (p *BytePool) watermark(buf []byte) {
+	base := unsafe.SliceData(buf[:cap(buf)])
+	watermarks.Store(uintptr(unsafe.Pointer(base)), bufferMark{owner: p.id, name: p.describe(), ptr: weak.Make(base)})
+	watermarking.Store(true)
+}
+
+// checkWatermark panics if buf was leased by a debug pool other than p
+// A buffer returned to its origin pool has its watermark removed
+func (p *BytePool) checkWatermark(buf []byte) {
+	base := unsafe.SliceData(buf[:cap(buf)])
+	key := uintptr(unsafe.Pointer(base))
+	v, ok := watermarks.Load(key)
+	if !ok {
+		return
+	}
+	mark := v.(bufferMark)
+	if mark.ptr.Value() != base {
+		watermarks.CompareAndDelete(key, v)
+		return
+	}
+	if mark.owner != p.id {
+		if p.misuse(MisuseForeignPut, MisusePanic, "buffer leased from %s put", mark.name) {
+			p.logEvent(slog.LevelWarn, eventForeignPut, "bytepool: buffer leased from another pool", slog.String("origin", mark.name))
+		}
+		return
+	}
+	watermarks.CompareAndDelete(key, v)
+}
+
+// describe names the pool by id and labels for misuse reports
+func (p *BytePool) describe() string {
+	return fmt.Sprintf("pool#%d%s", p.id, p.labelSuffix())
+}
@@ -0,0 +1,45 @@
+package bytepool
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestWatermark_CrossPoolPut(t *testing.T) {
+	ingest := NewPools([]int{128}, WithDebug(), WithLabels(map[string]string{"name": "ingest"}))
+	egress := NewPools([]int{128}, WithLabels(map[string]string{"name": "egress"}))
+
+	buf := ingest.Get(100)
+	func() {
+		defer func() {
+			msg, _ := recover().(string)
+			if !strings.Contains(msg, "{name=ingest}") || !strings.Contains(msg, "{name=egress}") {
+				t.Errorf("Expected cross pool panic naming both pools, got %q", msg)
+			}
+		}()
+		egress.Put(buf)
+	}()
+
+	// returning to the origin pool is fine and clears the watermark
+	ingest.Put(buf)
+	if _, ok := watermarks.Load(uintptrOf(buf)); ok {
+		t.Error("Expected watermark removed after Put to the origin pool")
+	}
+	egress.Put(egress.Get(100))
+}
+
+func TestWatermark_NotInProduction(t *testing.T) {
+	a := NewPools([]int{128})
+	b := NewPools([]int{128})
+	buf := a.Get(100)
+	b.Put(buf) // undetected without debug mode
+	if a.id == b.id {
+		t.Error("Expected unique pool ids")
+	}
+}
+
+// uintptrOf returns the watermark key of buf
+func uintptrOf(buf []byte) uintptr {
+	return uintptr(unsafe.Pointer(unsafe.SliceData(buf[:cap(buf)])))
+}
@@ -0,0 +1,67 @@
+package bytepool
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"unsafe"
+	"weak"
+)
+
+// pkgPrefix prefixes the function names of this package in stack frames
+const pkgPrefix = "github.com/ixugo/bytepool."
+
+// putSite records where a cleared buffer was returned to the pool
+type putSite struct {
+	site string
+	ptr  weak.Pointer[byte] // detects a stale entry whose address was reused after GC
+}
+
+// WithZeroCheck verifies in debug mode that buffers of tiers cleared on Put are still zero
+// when leased again, catching code that keeps writing through a reference after Put
+// A violation panics with the call site of the Put that returned the buffer
+// Only effective together with WithDebug and WithZeroOnPut or WithHygieneForRange
+func WithZeroCheck() Option {
+	return func(p *BytePool) {
+		p.zeroCheck = true
+	}
+}
+
+// recordPutSite remembers the caller returning buf
+func (p *BytePool) recordPutSite(buf []byte) {
+	base := unsafe.SliceData(buf)
+	p.putSites.Store(uintptr(unsafe.Pointer(base)), putSite{site: callSite(), ptr: weak.Make(base)})
+}
+
+// checkZeroed panics if buf, leased from tier t, was modified since it was cleared
+func (p *BytePool) checkZeroed(t *tierState, buf []byte) {
+	if t == nil || t.hygiene != HygieneZero {
+		return
+	}
+	full := buf[:cap(buf)]
+	base := unsafe.SliceData(full)
+	site := "unknown"
+	if v, ok := p.putSites.LoadAndDelete(uintptr(unsafe.Pointer(base))); ok && v.(putSite).ptr.Value() == base {
+		site = v.(putSite).site
+	}
+	if i := slices.IndexFunc(full, func(b byte) bool { return b != 0 }); i >= 0 {
+		panic(fmt.Sprintf("bytepool: %s buffer of tier %d modified after Put at offset %d, put at %s",
+			p.describe(), t.size, i, site))
+	}
+}
+
+// callSite returns file:line of the first caller outside this package
+func callSite() string {
+	var pcs [16]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, pkgPrefix) || strings.HasSuffix(frame.File, "_test.go") {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package bytepool
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBytePool_ZeroCheck(t *testing.T) {
+	pool := NewPools([]int{128}, WithBackend(FreeListBackend(1)), WithZeroOnPut(), WithDebug(), WithZeroCheck())
+
+	buf := pool.Get(100)
+	buf[0] = 1
+	pool.Put(buf)
+	buf = pool.Get(100) // cleared, passes
+	pool.Put(buf)
+	buf[5] = 7 // written after Put
+
+	defer func() {
+		msg, _ := recover().(string)
+		if !strings.Contains(msg, "offset 5") || !strings.Contains(msg, "zerocheck_test.go:") {
+			t.Errorf("Expected violation with offset and put site, got %q", msg)
+		}
+	}()
+	pool.Get(100)
+}
+
+func TestBytePool_ZeroCheckSkipsUnclearedTiers(t *testing.T) {
+	pool := NewPools([]int{128}, WithBackend(FreeListBackend(1)), WithDebug(), WithZeroCheck())
+
+	buf := pool.Get(100)
+	pool.Put(buf)
+	buf[0] = 1
+	pool.Get(100) // no hygiene, nothing to verify
+}